@@ -3,13 +3,16 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"text/template"
+	"time"
 
 	// Packages
 	"github.com/djthorpe/go-wasmbuild/etc"
@@ -36,11 +39,10 @@ type BuildContext struct {
 	// Output path for build
 	Output string `json:"output,omitempty"`
 
-	// Go tool command and flags
-	GoCmd  string   `json:"go_cmd,omitempty"`
-	GoRoot string   `json:"go_root,omitempty"`
-	GoArgs []string `json:"go_args,omitempty"`
-	GoEnv  []string `json:"go_env,omitempty"`
+	// Go tool command, and the targets to build
+	GoCmd   string         `json:"go_cmd,omitempty"`
+	GoRoot  string         `json:"go_root,omitempty"`
+	Targets []*BuildTarget `json:"targets,omitempty"`
 
 	// WasmExec Javascript path
 	WasmExecJS   *File `json:"wasm_exec_js,omitempty"`
@@ -108,6 +110,12 @@ func (c Config) BuildContext(ctx *Context, path, output string, watch bool) (*Bu
 		goroot = strings.TrimSpace(string(output))
 	}
 
+	// Resolve the build targets
+	targets, err := c.BuildTargets(ctx.GoFlags)
+	if err != nil {
+		return nil, err
+	}
+
 	// wasm_exec.js
 	wasmPathExecJS := RegularFileFromPathList(ctx.WasmExec, goroot)
 	if wasmPathExecJS == "" {
@@ -162,18 +170,12 @@ func (c Config) BuildContext(ctx *Context, path, output string, watch bool) (*Bu
 
 	// Return build context
 	return &BuildContext{
-		Config: c,
-		Path:   path,
-		Output: output,
-		GoCmd:  ctx.Go,
-		GoRoot: goroot,
-		GoArgs: append([]string{
-			"build",
-		}, strings.Fields(ctx.GoFlags)...),
-		GoEnv: []string{
-			"GOOS=js",
-			"GOARCH=wasm",
-		},
+		Config:       c,
+		Path:         path,
+		Output:       output,
+		GoCmd:        ctx.Go,
+		GoRoot:       goroot,
+		Targets:      targets,
 		WasmExecJS:   wasmExecJS,
 		WasmExecHTML: wasmExecHTML,
 		FavIcon:      NewFile(etc.FaviconPNG, "favicon.png"),
@@ -211,69 +213,31 @@ func (c *BuildCmd) Run(ctx *Context) error {
 		return err
 	}
 
-	// Compile
-	file, err := buildContext.CompileExec(ctx)
+	// Compile all targets
+	files, err := buildContext.CompileTargets(ctx)
 	if err != nil {
 		return err
 	}
 
-	// Copy files to output directory
-	for _, files := range []*File{
-		file,
-		buildContext.WasmExecHTML,
-		buildContext.WasmExecJS,
-		buildContext.FavIcon,
-	} {
-		// Write file
-		ctx.log.Info("cp ", files.Path, " ", buildContext.Output)
-		if err := files.WriteTo(buildContext.Output); err != nil {
-			return fmt.Errorf("failed to copy %s: %w", files.Path, err)
-		}
-	}
-
-	// Copy assets to output directory
-	for _, asset := range config.Assets {
-		if filepath.IsAbs(asset) == false {
-			asset = filepath.Join(c.Path, asset)
-		}
-		dest := fmt.Sprintf("%s/%s", buildContext.Output, filepath.Base(asset))
-
-		// Walk the asset path and copy files
-		err := filepath.Walk(asset, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
-			if strings.HasPrefix(info.Name(), ".") {
-				return nil
-			}
-
-			// Relative path from asset root
-			relPath, err := filepath.Rel(asset, path)
-			if err != nil {
-				return err
-			}
-
-			destPath := filepath.Join(dest, relPath)
-			if info.Mode().IsDir() {
-				ctx.log.Info("mkdir ", destPath)
-				if err := os.MkdirAll(destPath, 0755); err != nil {
-					return err
-				}
-				// Walk into directory
-				return nil
-			}
-
-			// Copy file across
-			ctx.log.Info("cp ", path, " ", filepath.Dir(destPath))
-			if err := CopyFile(path, destPath); err != nil {
-				return err
+	// Copy files for each target to the output directory
+	for _, target := range buildContext.Targets {
+		output := filepath.Join(buildContext.Output, target.Output)
+		for _, files := range []*File{
+			files[target.Name],
+			buildContext.WasmExecHTML,
+			buildContext.WasmExecJS,
+			buildContext.FavIcon,
+		} {
+			// Write file
+			ctx.log.Info("cp ", files.Path, " ", output)
+			if err := files.WriteTo(output); err != nil {
+				return fmt.Errorf("failed to copy %s: %w", files.Path, err)
 			}
+		}
 
-			// Return success
-			return nil
-		})
-		if err != nil {
-			return fmt.Errorf("failed to copy asset %s: %w", asset, err)
+		// Copy assets to output directory
+		if err := buildContext.CopyAssets(ctx, output); err != nil {
+			return err
 		}
 	}
 
@@ -287,15 +251,74 @@ func (c *BuildCmd) Run(ctx *Context) error {
 ///////////////////////////////////////////////////////////////////////////////
 // PUBLIC METHODS
 
-// Return a exec.Cmd for building the WASM application
-func (bc *BuildContext) GoBuildCmd(args ...string) *exec.Cmd {
-	cmd := exec.Command(bc.GoCmd, append(bc.GoArgs, args...)...)
+// Return a exec.Cmd for building a target of the WASM application
+func (bc *BuildContext) GoBuildCmd(target *BuildTarget, args ...string) *exec.Cmd {
+	cmd := exec.Command(bc.GoCmd, append(append([]string{}, target.GoArgs...), args...)...)
 	cmd.Dir = bc.Path
-	cmd.Env = append(os.Environ(), bc.GoEnv...)
+	cmd.Env = append(os.Environ(), target.GoEnv...)
 	return cmd
 }
 
+// Return the target with the given name, or the default target if the
+// name is empty. Returns nil if the target does not exist.
+func (c *BuildContext) Target(name string) *BuildTarget {
+	if name == "" && len(c.Targets) > 0 {
+		return c.Targets[0]
+	}
+	for _, target := range c.Targets {
+		if target.Name == name {
+			return target
+		}
+	}
+	return nil
+}
+
+// Compile the default target
 func (c *BuildContext) CompileExec(ctx *Context) (*File, error) {
+	target := c.Target("")
+	if target == nil {
+		return nil, fmt.Errorf("no build targets")
+	}
+	return c.CompileTarget(ctx, target)
+}
+
+// Compile all targets in parallel, returning the compiled files keyed by
+// target name
+func (c *BuildContext) CompileTargets(ctx *Context) (map[string]*File, error) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var result error
+
+	files := make(map[string]*File, len(c.Targets))
+	for _, target := range c.Targets {
+		wg.Add(1)
+		go func(target *BuildTarget) {
+			defer wg.Done()
+			file, err := c.CompileTarget(ctx, target)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil && target.Name != "" {
+				result = errors.Join(result, fmt.Errorf("target %q: %w", target.Name, err))
+			} else if err != nil {
+				result = errors.Join(result, err)
+			} else {
+				files[target.Name] = file
+			}
+		}(target)
+	}
+
+	// Wait for all compilations to complete
+	wg.Wait()
+
+	// Return any errors
+	if result != nil {
+		return nil, result
+	}
+	return files, nil
+}
+
+// Compile a target
+func (c *BuildContext) CompileTarget(ctx *Context, target *BuildTarget) (*File, error) {
 	// Create temporary directory for build
 	tmpDir, err := os.MkdirTemp("", "wasmbuild-compile-*")
 	if err != nil {
@@ -305,14 +328,7 @@ func (c *BuildContext) CompileExec(ctx *Context) (*File, error) {
 
 	// Build to temporary location
 	wasmPath := filepath.Join(tmpDir, filepath.Base(c.Path)+".wasm")
-	cmd := c.GoBuildCmd("-o", wasmPath)
-	// Update the output path to temp location
-	for i, arg := range cmd.Args {
-		if arg == "-o" && i+1 < len(cmd.Args) {
-			cmd.Args[i+1] = wasmPath
-			break
-		}
-	}
+	cmd := c.GoBuildCmd(target, "-o", wasmPath)
 
 	// Log the compile
 	ctx.log.Info(cmd.String())
@@ -323,21 +339,75 @@ func (c *BuildContext) CompileExec(ctx *Context) (*File, error) {
 	cmd.Stderr = &stderrBuf
 
 	// Run the command
+	start := time.Now()
 	if err := cmd.Run(); err != nil {
 		stderr := stderrBuf.String()
-		return nil, fmt.Errorf("compilation failed: %w\n%s", err, stderr)
+		return nil, fmt.Errorf("compilation failed after %v: %w\n%s", time.Since(start).Round(time.Millisecond), err, stderr)
 	}
 
 	// Read the compiled wasm file into memory
 	wasmData, err := os.ReadFile(wasmPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read compiled wasm file: %w", err)
+	} else {
+		ctx.log.Build(target.Name, len(wasmData), time.Since(start))
 	}
 
 	// Return as File object
 	return NewFile(wasmData, filepath.Base(c.Path)+".wasm"), nil
 }
 
+// Copy assets to an output directory
+func (c *BuildContext) CopyAssets(ctx *Context, output string) error {
+	for _, asset := range c.Assets {
+		if filepath.IsAbs(asset) == false {
+			asset = filepath.Join(c.Path, asset)
+		}
+		dest := filepath.Join(output, filepath.Base(asset))
+
+		// Walk the asset path and copy files
+		err := filepath.Walk(asset, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if strings.HasPrefix(info.Name(), ".") {
+				return nil
+			}
+
+			// Relative path from asset root
+			relPath, err := filepath.Rel(asset, path)
+			if err != nil {
+				return err
+			}
+
+			destPath := filepath.Join(dest, relPath)
+			if info.Mode().IsDir() {
+				ctx.log.Info("mkdir ", destPath)
+				if err := os.MkdirAll(destPath, 0755); err != nil {
+					return err
+				}
+				// Walk into directory
+				return nil
+			}
+
+			// Copy file across
+			ctx.log.Info("cp ", path, " ", filepath.Dir(destPath))
+			if err := CopyFile(path, destPath); err != nil {
+				return err
+			}
+
+			// Return success
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to copy asset %s: %w", asset, err)
+		}
+	}
+
+	// Return success
+	return nil
+}
+
 func CopyFile(src, dest string) error {
 	// Open source file
 	srcFile, err := os.Open(src)
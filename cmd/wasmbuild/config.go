@@ -2,9 +2,13 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	// Packages
 	yaml "gopkg.in/yaml.v3"
@@ -14,8 +18,45 @@ import (
 // TYPES
 
 type Config struct {
-	Vars   map[string]string `yaml:"vars,omitempty" json:"vars,omitempty"`
-	Assets []string          `yaml:"assets,omitempty" json:"assets,omitempty"`
+	Vars    map[string]string `yaml:"vars,omitempty" json:"vars,omitempty"`
+	Assets  []string          `yaml:"assets,omitempty" json:"assets,omitempty"`
+	Targets []Target          `yaml:"targets,omitempty" json:"targets,omitempty"`
+	Mocks   []Mock            `yaml:"mocks,omitempty" json:"mocks,omitempty"`
+}
+
+// Target is a named build variant of the application. The first target
+// is the default.
+type Target struct {
+	Name    string            `yaml:"name" json:"name"`
+	GoFlags string            `yaml:"goflags,omitempty" json:"goflags,omitempty"`
+	Tags    []string          `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+	Output  string            `yaml:"output,omitempty" json:"output,omitempty"`
+}
+
+// Mock is a route served by the development server with a static file or
+// a Go template, standing in for a backend API
+type Mock struct {
+	Route       string            `yaml:"route" json:"route"`
+	Method      string            `yaml:"method,omitempty" json:"method,omitempty"`
+	File        string            `yaml:"file,omitempty" json:"file,omitempty"`
+	Template    string            `yaml:"template,omitempty" json:"template,omitempty"`
+	Status      int               `yaml:"status,omitempty" json:"status,omitempty"`
+	Latency     time.Duration     `yaml:"latency,omitempty" json:"latency,omitempty"`
+	ContentType string            `yaml:"content_type,omitempty" json:"content_type,omitempty"`
+	Headers     map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+}
+
+// BuildTarget is a target resolved into arguments for the go tool
+type BuildTarget struct {
+	Name string `json:"name,omitempty"`
+
+	// Output path for the target, relative to the build output path
+	Output string `json:"output,omitempty"`
+
+	// Go tool arguments and environment
+	GoArgs []string `json:"go_args,omitempty"`
+	GoEnv  []string `json:"go_env,omitempty"`
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -43,6 +84,78 @@ func ParseYAMLPath(path, base string) (*Config, error) {
 	return ParseYAML(f)
 }
 
+// BuildTargets resolves the targets in the configuration into go tool
+// arguments and environment, with goflags applied to every target. When no
+// targets are declared, a single unnamed target is returned which builds
+// into the output path.
+func (c Config) BuildTargets(goflags string) ([]*BuildTarget, error) {
+	targets := c.Targets
+	if len(targets) == 0 {
+		targets = []Target{{}}
+	}
+
+	result := make([]*BuildTarget, 0, len(targets))
+	names := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		// Check the name
+		if len(c.Targets) > 0 {
+			if target.Name == "" {
+				return nil, fmt.Errorf("target without a name")
+			} else if strings.ContainsAny(target.Name, "/\\ ") {
+				return nil, fmt.Errorf("invalid target name: %q", target.Name)
+			} else if names[target.Name] {
+				return nil, fmt.Errorf("duplicate target: %q", target.Name)
+			}
+			names[target.Name] = true
+		}
+
+		// Output defaults to the target name, and must be within the output path
+		output := target.Output
+		if output == "" {
+			output = target.Name
+		}
+		output = filepath.Clean(output)
+		if filepath.IsAbs(output) || output == ".." || strings.HasPrefix(output, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("target %q: output must be a relative subdirectory: %q", target.Name, target.Output)
+		}
+		if output == "." {
+			output = ""
+		}
+
+		// Go arguments
+		args := []string{"build"}
+		args = append(args, strings.Fields(goflags)...)
+		args = append(args, strings.Fields(os.ExpandEnv(target.GoFlags))...)
+		if len(target.Tags) > 0 {
+			args = append(args, "-tags="+strings.Join(target.Tags, ","))
+		}
+
+		// Go environment, with variables in sorted order
+		env := []string{
+			"GOOS=js",
+			"GOARCH=wasm",
+		}
+		keys := make([]string, 0, len(target.Env))
+		for key := range target.Env {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			env = append(env, key+"="+os.ExpandEnv(target.Env[key]))
+		}
+
+		result = append(result, &BuildTarget{
+			Name:   target.Name,
+			Output: output,
+			GoArgs: args,
+			GoEnv:  env,
+		})
+	}
+
+	// Return success
+	return result, nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // STRINGIFY
 
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	// Packages
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Targets(t *testing.T) {
+	config, err := ParseYAML(strings.NewReader(`
+targets:
+  - name: standard
+  - name: small
+    goflags: -ldflags=-w
+    output: dist/small
+  - name: debug
+    tags: [debug, trace]
+    env:
+      GOWASM: satconv
+      CGO_ENABLED: "0"
+`))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Len(t, config.Targets, 3)
+
+	targets, err := config.BuildTargets("-trimpath")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Len(t, targets, 3)
+
+	assert.Equal(t, "standard", targets[0].Name)
+	assert.Equal(t, "standard", targets[0].Output)
+	assert.Equal(t, []string{"build", "-trimpath"}, targets[0].GoArgs)
+	assert.Equal(t, []string{"GOOS=js", "GOARCH=wasm"}, targets[0].GoEnv)
+
+	assert.Equal(t, "small", targets[1].Name)
+	assert.Equal(t, "dist/small", targets[1].Output)
+	assert.Equal(t, []string{"build", "-trimpath", "-ldflags=-w"}, targets[1].GoArgs)
+
+	assert.Equal(t, "debug", targets[2].Name)
+	assert.Equal(t, []string{"build", "-trimpath", "-tags=debug,trace"}, targets[2].GoArgs)
+	assert.Equal(t, []string{"GOOS=js", "GOARCH=wasm", "CGO_ENABLED=0", "GOWASM=satconv"}, targets[2].GoEnv)
+}
+
+func TestConfig_DefaultTarget(t *testing.T) {
+	targets, err := Config{}.BuildTargets("")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Len(t, targets, 1)
+	assert.Equal(t, "", targets[0].Name)
+	assert.Equal(t, "", targets[0].Output)
+	assert.Equal(t, []string{"build"}, targets[0].GoArgs)
+}
+
+func TestConfig_TargetErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets []Target
+	}{
+		{"missing name", []Target{{}}},
+		{"invalid name", []Target{{Name: "a/b"}}},
+		{"duplicate name", []Target{{Name: "a"}, {Name: "a"}}},
+		{"absolute output", []Target{{Name: "a", Output: "/tmp"}}},
+		{"parent output", []Target{{Name: "a", Output: "../a"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Config{Targets: tt.targets}.BuildTargets("")
+			assert.Error(t, err)
+		})
+	}
+}
@@ -22,6 +22,9 @@ import (
 type DepContext struct {
 	BuildContext
 
+	// Poll interval, or zero to use filesystem notifications
+	Poll time.Duration `json:"poll,omitempty"`
+
 	// Modified channel - returns nil or an error
 	modified chan error
 
@@ -44,15 +47,30 @@ type DepPackageInfo struct {
 	} `json:"Module"`
 }
 
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	// Minimum time between modification notifications
+	debounceDelay = 500 * time.Millisecond
+)
+
 ///////////////////////////////////////////////////////////////////////////////
 // LIFECYCLE
 
 // DepContext creates a DepContext from the Config, returning all the
 // information needed to build a WASM application.
-func (b BuildContext) DepContext(ctx *Context) (*DepContext, error) {
+// When poll is non-zero, dependencies are scanned at that interval rather
+// than watched with filesystem notifications.
+func (b BuildContext) DepContext(ctx *Context, poll time.Duration) (*DepContext, error) {
+	if poll < 0 {
+		return nil, fmt.Errorf("invalid poll interval: %v", poll)
+	}
+
 	// Return the DepContext
 	return &DepContext{
 		BuildContext: b,
+		Poll:         poll,
 		modified:     make(chan error),
 	}, nil
 }
@@ -89,7 +107,7 @@ func (c *DepCmd) Run(ctx *Context) error {
 	}
 
 	// Create a dependency context from the build context
-	dep, err := buildContext.DepContext(ctx)
+	dep, err := buildContext.DepContext(ctx, c.Poll)
 	if err != nil {
 		return err
 	} else {
@@ -256,7 +274,8 @@ func (d *DepContext) Dependencies() ([]string, error) {
 	return paths, err
 }
 
-// Run a watcher for dependencies using fsnotify
+// Run a watcher for dependencies, using fsnotify or polling when a
+// poll interval is set
 func (d *DepContext) Run(ctx context.Context) error {
 	// Get paths of dependencies
 	paths, err := d.Dependencies()
@@ -264,6 +283,11 @@ func (d *DepContext) Run(ctx context.Context) error {
 		return err
 	}
 
+	// Poll for changes when fsnotify events are not available
+	if d.Poll > 0 {
+		return d.poll(ctx, paths, d.Dependencies)
+	}
+
 	// Create fsnotify watcher
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
@@ -282,7 +306,6 @@ func (d *DepContext) Run(ctx context.Context) error {
 
 	// Track modification times for debouncing
 	last := time.Now()
-	debounceDelay := 500 * time.Millisecond
 
 	for {
 		select {
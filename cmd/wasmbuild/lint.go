@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	// Packages
+	lint "github.com/djthorpe/go-wasmbuild/pkg/lint"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type LintCmd struct {
+	BuildPath
+	Settle  time.Duration `default:"250ms" help:"Time to wait for the application to render before checking"`
+	Timeout time.Duration `default:"30s" help:"Maximum time to wait for the application to render"`
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	// The file added to the application's main package when linting
+	lintFile = "zz_wasmbuild_lint.go"
+
+	// Source of the file added to the application, which waits for the
+	// application to render, then checks the document on the event loop,
+	// so that it is not changed by dispatched functions while it is being
+	// checked, writes the diagnostics as JSON to a file and exits
+	lintSource = `// Code generated by wasmbuild lint. DO NOT EDIT.
+
+package main
+
+import (
+	wasmbuild_json "encoding/json"
+	wasmbuild_os "os"
+	wasmbuild_time "time"
+
+	wasmbuild_dom "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	wasmbuild_lint "github.com/djthorpe/go-wasmbuild/pkg/lint"
+)
+
+func init() {
+	go func() {
+		wasmbuild_time.Sleep(%d)
+		window := wasmbuild_dom.GetWindow()
+		checked := make(chan []wasmbuild_lint.Diagnostic, 1)
+		window.Dispatch(func() {
+			checked <- wasmbuild_lint.Check(window.Document())
+		})
+		diagnostics := <-checked
+		if data, err := wasmbuild_json.Marshal(diagnostics); err != nil {
+			wasmbuild_os.Stderr.WriteString(err.Error())
+			wasmbuild_os.Exit(1)
+		} else if err := wasmbuild_os.WriteFile(%q, data, 0o644); err != nil {
+			wasmbuild_os.Stderr.WriteString(err.Error())
+			wasmbuild_os.Exit(1)
+		}
+		wasmbuild_os.Exit(0)
+	}()
+}
+`
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// COMMANDS
+
+func (c *LintCmd) Run(ctx *Context) error {
+	path, err := filepath.Abs(c.Path)
+	if err != nil {
+		return fmt.Errorf("failed to determine absolute path: %w", err)
+	}
+
+	// Render the application and check the document
+	diagnostics, err := c.Lint(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	// Output diagnostics relative to the current directory
+	name := c.Path
+	if rel, err := filepath.Rel(".", path); err == nil {
+		name = rel
+	}
+	for _, d := range diagnostics {
+		fmt.Printf("%s:%s: %s (%s)\n", name, d.Path, d.Message, d.Rule)
+	}
+
+	// Return failure if there were any problems
+	if len(diagnostics) > 0 {
+		return fmt.Errorf("lint failed: %d problem(s)", len(diagnostics))
+	}
+
+	// Return success
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Lint builds the application at path natively, with an additional file
+// which checks the rendered document, then runs it and returns the
+// diagnostics
+func (c *LintCmd) Lint(ctx *Context, path string) ([]lint.Diagnostic, error) {
+	tmpDir, err := os.MkdirTemp("", "wasmbuild-lint-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Write the source and overlay files
+	source := filepath.Join(tmpDir, lintFile)
+	result := filepath.Join(tmpDir, "lint.json")
+	if err := os.WriteFile(source, []byte(fmt.Sprintf(lintSource, c.Settle, result)), 0o644); err != nil {
+		return nil, err
+	}
+	overlay := filepath.Join(tmpDir, "overlay.json")
+	if data, err := json.Marshal(map[string]map[string]string{
+		"Replace": {filepath.Join(path, lintFile): source},
+	}); err != nil {
+		return nil, err
+	} else if err := os.WriteFile(overlay, data, 0o644); err != nil {
+		return nil, err
+	}
+
+	// Build the application natively
+	exe := filepath.Join(tmpDir, filepath.Base(path))
+	args := append([]string{"build", "-overlay", overlay, "-o", exe}, strings.Fields(ctx.GoFlags)...)
+	cmd := exec.Command(ctx.Go, append(args, ".")...)
+	cmd.Dir = path
+
+	var stderr bytes.Buffer
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = &stderr
+	ctx.log.Info(cmd.String())
+	start := time.Now()
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("compilation failed after %v: %w\n%s", time.Since(start).Round(time.Millisecond), err, stderr.String())
+	} else if info, err := os.Stat(exe); err == nil {
+		ctx.log.Build("", int(info.Size()), time.Since(start))
+	}
+
+	// Run the application, which writes the diagnostics and exits
+	timeout, cancel := context.WithTimeout(ctx.ctx, c.Settle+c.Timeout)
+	defer cancel()
+	stderr.Reset()
+	cmd = exec.CommandContext(timeout, exe)
+	cmd.Dir = path
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = &stderr
+	ctx.log.Info(cmd.String())
+	if err := cmd.Run(); errors.Is(timeout.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("application did not render within %v", c.Settle+c.Timeout)
+	} else if err != nil {
+		return nil, fmt.Errorf("application failed: %w\n%s", err, stderr.String())
+	}
+
+	// Read the diagnostics
+	data, err := os.ReadFile(result)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("application exited before rendering")
+	} else if err != nil {
+		return nil, err
+	}
+	var diagnostics []lint.Diagnostic
+	if err := json.Unmarshal(data, &diagnostics); err != nil {
+		return nil, fmt.Errorf("failed to parse diagnostics: %w", err)
+	}
+
+	// Return success
+	return diagnostics, nil
+}
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
-	"os"
+	"strings"
+	"sync"
+	"time"
 
 	// Packages
 	"github.com/fatih/color"
@@ -14,81 +17,238 @@ import (
 ///////////////////////////////////////////////////////////////////////////////
 // TYPES
 
-// Logger handles logging at different levels based on verbose flag
+// Logger handles logging at different levels, as coloured text or as JSON
 type Logger struct {
-	infoLogger  *log.Logger
-	errorLogger *log.Logger
-	verbose     bool
-	infoColor   *color.Color
-	errorColor  *color.Color
+	mu         sync.Mutex
+	w          io.Writer
+	level      slog.Level
+	timestamps bool
+	json       *slog.Logger
+	colors     map[slog.Level]*color.Color
+}
+
+// responseWriter records the status and size of a response
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
 }
 
 ///////////////////////////////////////////////////////////////////////////////
-// LIFECYCLE
+// GLOBALS
 
-// NewLogger creates a new logger instance
-func NewLogger(verbose bool) *Logger {
-	flags := 0
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
 
+// NewLogger creates a new logger instance which writes messages at or above
+// level to w, in text or json format. Timestamps are always included in
+// json output, and in text output when timestamps is true.
+func NewLogger(w io.Writer, format string, level slog.Level, timestamps bool) (*Logger, error) {
 	l := &Logger{
-		errorLogger: log.New(os.Stderr, "", flags),
-		verbose:     verbose,
-		infoColor:   color.New(color.Bold),
-		errorColor:  color.New(color.FgRed),
+		w:          w,
+		level:      level,
+		timestamps: timestamps,
+		colors: map[slog.Level]*color.Color{
+			slog.LevelDebug: color.New(color.Faint),
+			slog.LevelInfo:  color.New(color.Bold),
+			slog.LevelWarn:  color.New(color.FgYellow),
+			slog.LevelError: color.New(color.FgRed),
+		},
 	}
 
-	if verbose {
-		l.infoLogger = log.New(os.Stderr, "", flags)
-	} else {
-		// Discard info logs when not verbose
-		l.infoLogger = log.New(io.Discard, "", 0)
+	switch format {
+	case "", LogFormatText:
+		// No-op
+	case LogFormatJSON:
+		l.json = slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+			Level: level,
+		}))
+	default:
+		return nil, fmt.Errorf("invalid log format: %q", format)
 	}
 
-	return l
+	// Return success
+	return l, nil
+}
+
+// ParseLogLevel returns the level for a level name (debug, info, warn or
+// error). When the name is empty, the level is info when verbose and warn
+// otherwise.
+func ParseLogLevel(name string, verbose bool) (slog.Level, error) {
+	var level slog.Level
+	if name == "" {
+		if verbose {
+			return slog.LevelInfo, nil
+		}
+		return slog.LevelWarn, nil
+	}
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return level, fmt.Errorf("invalid log level: %q", name)
+	}
+	return level, nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////
 // PUBLIC METHODS
 
-// Info logs informational messages (only when verbose)
+// Debug logs debugging messages
+func (l *Logger) Debug(v ...interface{}) {
+	l.log(slog.LevelDebug, fmt.Sprint(v...))
+}
+
+// Debugf logs formatted debugging messages
+func (l *Logger) Debugf(format string, v ...interface{}) {
+	l.log(slog.LevelDebug, fmt.Sprintf(format, v...))
+}
+
+// Info logs informational messages
 func (l *Logger) Info(v ...interface{}) {
-	if l.verbose {
-		l.infoColor.Fprint(os.Stderr, v...)
-		fmt.Fprintln(os.Stderr)
-	}
+	l.log(slog.LevelInfo, fmt.Sprint(v...))
 }
 
-// Infof logs formatted informational messages (only when verbose)
+// Infof logs formatted informational messages
 func (l *Logger) Infof(format string, v ...interface{}) {
-	if l.verbose {
-		l.infoColor.Fprintf(os.Stderr, format, v...)
-		if format[len(format)-1] != '\n' {
-			fmt.Fprintln(os.Stderr)
-		}
-	}
+	l.log(slog.LevelInfo, fmt.Sprintf(format, v...))
+}
+
+// Warn logs warning messages
+func (l *Logger) Warn(v ...interface{}) {
+	l.log(slog.LevelWarn, fmt.Sprint(v...))
 }
 
-// Error logs error messages (always shown)
+// Warnf logs formatted warning messages
+func (l *Logger) Warnf(format string, v ...interface{}) {
+	l.log(slog.LevelWarn, fmt.Sprintf(format, v...))
+}
+
+// Error logs error messages
 func (l *Logger) Error(v ...interface{}) {
-	l.errorColor.Fprint(os.Stderr, v...)
-	fmt.Fprintln(os.Stderr)
+	l.log(slog.LevelError, fmt.Sprint(v...))
 }
 
-// Errorf logs formatted error messages (always shown)
+// Errorf logs formatted error messages
 func (l *Logger) Errorf(format string, v ...interface{}) {
-	l.errorColor.Fprintf(os.Stderr, format, v...)
-	if format[len(format)-1] != '\n' {
-		fmt.Fprintln(os.Stderr)
+	l.log(slog.LevelError, fmt.Sprintf(format, v...))
+}
+
+// Build logs the time taken to compile a target, and the size of the
+// output. Failed builds are not logged here, but returned as errors and
+// logged where they are handled
+func (l *Logger) Build(target string, size int, latency time.Duration) {
+	attrs := []slog.Attr{}
+	if target != "" {
+		attrs = append(attrs, slog.String("target", target))
 	}
+	attrs = append(attrs, slog.Int("bytes", size), slog.Duration("duration", latency))
+	l.log(slog.LevelInfo, "build", attrs...)
 }
 
 ///////////////////////////////////////////////////////////////////////////////
 // PRIVATE METHODS
 
-// logging middleware
+// log a message with attributes
+func (l *Logger) log(level slog.Level, msg string, attrs ...slog.Attr) {
+	if level < l.level {
+		return
+	}
+
+	// JSON output
+	if l.json != nil {
+		l.json.LogAttrs(context.Background(), level, strings.TrimRight(msg, "\n"), attrs...)
+		return
+	}
+
+	// Text output, with attributes as key=value pairs
+	var b strings.Builder
+	if l.timestamps {
+		b.WriteString(time.Now().Format(time.RFC3339))
+		b.WriteByte(' ')
+	}
+	b.WriteString(strings.TrimRight(msg, "\n"))
+	for _, attr := range attrs {
+		b.WriteByte(' ')
+		b.WriteString(attr.String())
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.color(level).Fprint(l.w, b.String())
+	fmt.Fprintln(l.w)
+}
+
+// color returns the color for a level
+func (l *Logger) color(level slog.Level) *color.Color {
+	switch {
+	case level >= slog.LevelError:
+		return l.colors[slog.LevelError]
+	case level >= slog.LevelWarn:
+		return l.colors[slog.LevelWarn]
+	case level >= slog.LevelInfo:
+		return l.colors[slog.LevelInfo]
+	default:
+		return l.colors[slog.LevelDebug]
+	}
+}
+
+// logging middleware, which logs each request with the response status,
+// size and latency
 func logging(next http.Handler, logger *Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		logger.Infof("%s %s %s", r.Method, r.URL.Path, r.RemoteAddr)
-		next.ServeHTTP(w, r)
+		start := time.Now()
+		rw := &responseWriter{ResponseWriter: w}
+		next.ServeHTTP(rw, r)
+		if rw.status == 0 {
+			rw.status = http.StatusOK
+		}
+
+		// Server errors are logged as warnings
+		level := slog.LevelInfo
+		if rw.status >= http.StatusInternalServerError {
+			level = slog.LevelWarn
+		}
+		logger.log(level, "request",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.RequestURI()),
+			slog.Int("status", rw.status),
+			slog.Int("bytes", rw.bytes),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("remote", r.RemoteAddr),
+		)
 	})
 }
+
+///////////////////////////////////////////////////////////////////////////////
+// RESPONSE WRITER
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(data)
+	w.bytes += n
+	return n, err
+}
+
+// Flush is required for server-sent events
+func (w *responseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying response writer, for http.ResponseController
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
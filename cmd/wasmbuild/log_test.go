@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	// Packages
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLog_ParseLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		verbose bool
+		level   slog.Level
+		err     bool
+	}{
+		{"", false, slog.LevelWarn, false},
+		{"", true, slog.LevelInfo, false},
+		{"debug", false, slog.LevelDebug, false},
+		{"info", false, slog.LevelInfo, false},
+		{"WARN", true, slog.LevelWarn, false},
+		{"error", true, slog.LevelError, false},
+		{"verbose", false, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, err := ParseLogLevel(tt.name, tt.verbose)
+			if tt.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.level, level)
+			}
+		})
+	}
+}
+
+func TestLog_Text(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger, err := NewLogger(buf, LogFormatText, slog.LevelInfo, false)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	logger.Debug("hidden")
+	logger.Info("info ", 1)
+	logger.Warnf("warn %d\n", 2)
+	logger.Error("error")
+	logger.Build("small", 1024, 1500*time.Millisecond)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, []string{
+		"info 1",
+		"warn 2",
+		"error",
+		"build target=small bytes=1024 duration=1.5s",
+	}, lines)
+}
+
+func TestLog_Timestamps(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger, err := NewLogger(buf, LogFormatText, slog.LevelInfo, true)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	logger.Info("message")
+	fields := strings.Fields(buf.String())
+	if assert.Len(t, fields, 2) {
+		_, err := time.Parse(time.RFC3339, fields[0])
+		assert.NoError(t, err)
+		assert.Equal(t, "message", fields[1])
+	}
+}
+
+func TestLog_JSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger, err := NewLogger(buf, LogFormatJSON, slog.LevelWarn, false)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	logger.Info("hidden")
+	logger.Build("", 1024, time.Second)
+	logger.Warn("warning")
+	logger.Error("error")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !assert.Len(t, lines, 2) {
+		t.FailNow()
+	}
+
+	var warning map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &warning))
+	assert.Equal(t, "WARN", warning["level"])
+	assert.Equal(t, "warning", warning["msg"])
+	assert.Contains(t, warning, "time")
+
+	var message map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &message))
+	assert.Equal(t, "ERROR", message["level"])
+	assert.Equal(t, "error", message["msg"])
+}
+
+func TestLog_InvalidFormat(t *testing.T) {
+	_, err := NewLogger(new(bytes.Buffer), "xml", slog.LevelInfo, false)
+	assert.Error(t, err)
+}
+
+func TestLog_Request(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger, err := NewLogger(buf, LogFormatJSON, slog.LevelInfo, false)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	})
+	handler.HandleFunc("/stream", func(w http.ResponseWriter, r *http.Request) {
+		_, ok := w.(http.Flusher)
+		assert.True(t, ok, "expected response writer to be a flusher")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	handler.HandleFunc("/error", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "failed", http.StatusInternalServerError)
+	})
+
+	server := logging(handler, logger)
+	for _, path := range []string{"/ok?q=1", "/stream", "/error"} {
+		server.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !assert.Len(t, lines, 3) {
+		t.FailNow()
+	}
+
+	tests := []struct {
+		level  string
+		path   string
+		status float64
+		bytes  float64
+	}{
+		{"INFO", "/ok?q=1", 200, 5},
+		{"INFO", "/stream", 202, 0},
+		{"WARN", "/error", 500, 7},
+	}
+	for i, tt := range tests {
+		var entry map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(lines[i]), &entry))
+		assert.Equal(t, tt.level, entry["level"])
+		assert.Equal(t, "request", entry["msg"])
+		assert.Equal(t, "GET", entry["method"])
+		assert.Equal(t, tt.path, entry["path"])
+		assert.Equal(t, tt.status, entry["status"])
+		assert.Equal(t, tt.bytes, entry["bytes"])
+		assert.Contains(t, entry, "latency")
+		assert.Contains(t, entry, "remote")
+	}
+}
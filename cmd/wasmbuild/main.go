@@ -19,6 +19,11 @@ type Context struct {
 	Config   string `default:"wasmbuild.yaml" help:"Path to configuration YAML file (relative to source path)"`
 	Verbose  bool   `short:"v" help:"Enable verbose output"`
 
+	// Logging
+	LogLevel      string `placeholder:"LEVEL" help:"Log level (debug, info, warn, error), defaults to info when verbose and warn otherwise"`
+	LogFormat     string `enum:"text,json" default:"text" help:"Log format (text, json)"`
+	LogTimestamps bool   `help:"Include timestamps in text log output"`
+
 	// Private
 	log    *Logger
 	ctx    context.Context
@@ -30,6 +35,7 @@ type CLI struct {
 	Build BuildCmd `cmd:"" help:"Build a WASM application"`
 	Serve ServeCmd `cmd:"" help:"Serve a WASM application"`
 	Dep   DepCmd   `cmd:"" help:"Show dependencies of a WASM application"`
+	Lint  LintCmd  `cmd:"" help:"Check the rendered document of a WASM application for accessibility and HTML problems"`
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -40,7 +46,10 @@ func main() {
 	kong := kong.Parse(cli)
 
 	// Additional context setup
-	cli.Context.log = NewLogger(cli.Verbose)
+	level, err := ParseLogLevel(cli.LogLevel, cli.Verbose)
+	kong.FatalIfErrorf(err)
+	cli.Context.log, err = NewLogger(os.Stderr, cli.LogFormat, level, cli.LogTimestamps)
+	kong.FatalIfErrorf(err)
 
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancel()
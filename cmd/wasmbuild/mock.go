@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// MockHandler serves a mock route from a static file or a Go template
+type MockHandler struct {
+	Mock
+
+	// Pattern for registering the handler with a http.ServeMux
+	Pattern string
+
+	// Absolute path to the file or template
+	path string
+
+	// Variables from the configuration, available to templates
+	vars map[string]string
+}
+
+// mockRequest is the data passed to a mock template
+type mockRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   any
+	Vars   map[string]string
+
+	req *http.Request
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	defaultMockContentType = "application/json"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// MockHandlers returns handlers for the mocks in the configuration, with
+// files and templates relative to the base path
+func (c Config) MockHandlers(base string) ([]*MockHandler, error) {
+	result := make([]*MockHandler, 0, len(c.Mocks))
+	patterns := make(map[string]bool, len(c.Mocks))
+	for _, mock := range c.Mocks {
+		handler, err := NewMockHandler(mock, base, c.Vars)
+		if err != nil {
+			return nil, err
+		} else if patterns[handler.Pattern] {
+			return nil, fmt.Errorf("duplicate mock: %q", handler.Pattern)
+		}
+		patterns[handler.Pattern] = true
+		result = append(result, handler)
+	}
+	return result, nil
+}
+
+// NewMockHandler returns a handler for a mock, with the file or template
+// relative to the base path
+func NewMockHandler(mock Mock, base string, vars map[string]string) (*MockHandler, error) {
+	// Check the route and method
+	if !strings.HasPrefix(mock.Route, "/") {
+		return nil, fmt.Errorf("mock route must start with '/': %q", mock.Route)
+	}
+	mock.Method = strings.ToUpper(strings.TrimSpace(mock.Method))
+	if strings.ContainsAny(mock.Method, " /") {
+		return nil, fmt.Errorf("mock %q: invalid method %q", mock.Route, mock.Method)
+	}
+
+	// Check the status and latency
+	if mock.Status == 0 {
+		mock.Status = http.StatusOK
+	} else if mock.Status < 100 || mock.Status > 599 {
+		return nil, fmt.Errorf("mock %q: invalid status %d", mock.Route, mock.Status)
+	}
+	if mock.Latency < 0 {
+		return nil, fmt.Errorf("mock %q: invalid latency %v", mock.Route, mock.Latency)
+	}
+
+	// Check the file or template, at most one of which is set
+	path := mock.File
+	if mock.File != "" && mock.Template != "" {
+		return nil, fmt.Errorf("mock %q: cannot have both file and template", mock.Route)
+	} else if mock.Template != "" {
+		path = mock.Template
+	}
+	if path != "" {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(base, path)
+		}
+		if info, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("mock %q: %w", mock.Route, err)
+		} else if !info.Mode().IsRegular() {
+			return nil, fmt.Errorf("mock %q: not a regular file: %q", mock.Route, path)
+		}
+	}
+
+	// Determine the content type, from the file extension or the default
+	if mock.ContentType == "" && path != "" {
+		mock.ContentType = mime.TypeByExtension(filepath.Ext(strings.TrimSuffix(path, ".tmpl")))
+	}
+	if mock.ContentType == "" {
+		mock.ContentType = defaultMockContentType
+	}
+
+	// Determine the pattern
+	pattern := mock.Route
+	if mock.Method != "" {
+		pattern = mock.Method + " " + pattern
+	}
+
+	// Return success
+	return &MockHandler{
+		Mock:    mock,
+		Pattern: pattern,
+		path:    path,
+		vars:    vars,
+	}, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// ServeHTTP reads the file or executes the template on each request, so
+// that changes are served without restarting
+func (m *MockHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Simulate latency, unless the client goes away
+	if m.Latency > 0 {
+		select {
+		case <-time.After(m.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	// Render the response body
+	body, err := m.render(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Write the response
+	for key, value := range m.Headers {
+		w.Header().Set(key, value)
+	}
+	if len(body) > 0 {
+		w.Header().Set("Content-Type", m.ContentType)
+	}
+	w.WriteHeader(m.Status)
+	w.Write(body)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// render returns the contents of the file, or the output of the template
+func (m *MockHandler) render(r *http.Request) ([]byte, error) {
+	switch {
+	case m.File != "":
+		return os.ReadFile(m.path)
+	case m.Template != "":
+		tmpl, err := template.New(filepath.Base(m.path)).Funcs(template.FuncMap{
+			"json": func(v any) (string, error) {
+				data, err := json.Marshal(v)
+				return string(data), err
+			},
+		}).ParseFiles(m.path)
+		if err != nil {
+			return nil, err
+		}
+		data, err := newMockRequest(r, m.vars)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, nil
+	}
+}
+
+// newMockRequest returns the template data for a request, decoding a JSON
+// request body if there is one
+func newMockRequest(r *http.Request, vars map[string]string) (*mockRequest, error) {
+	data := &mockRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header,
+		Vars:   vars,
+		req:    r,
+	}
+	if r.Body != nil {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(body)) > 0 {
+			if err := json.Unmarshal(body, &data.Body); err != nil {
+				data.Body = string(body)
+			}
+		}
+	}
+	return data, nil
+}
+
+// Param returns a wildcard from the route, for example "id" in the route
+// "/api/users/{id}"
+func (r *mockRequest) Param(name string) string {
+	return r.req.PathValue(name)
+}
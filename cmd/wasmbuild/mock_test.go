@@ -0,0 +1,197 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	// Packages
+	"github.com/stretchr/testify/assert"
+)
+
+// mockServer returns a server for the mocks in a configuration, with files
+// written to a temporary directory
+func mockServer(t *testing.T, config string, files map[string]string) *http.ServeMux {
+	t.Helper()
+	dir := t.TempDir()
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c, err := ParseYAML(strings.NewReader(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mocks, err := c.MockHandlers(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	for _, mock := range mocks {
+		if err := handle(mux, mock.Pattern, mock); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return mux
+}
+
+func TestMock_File(t *testing.T) {
+	mux := mockServer(t, `
+mocks:
+  - route: /api/users
+    method: get
+    file: users.json
+  - route: /api/users
+    method: POST
+    status: 201
+    headers:
+      Location: /api/users/3
+`, map[string]string{
+		"users.json": `[{"name":"alice"}]`,
+	})
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, `[{"name":"alice"}]`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/users", strings.NewReader(`{}`)))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/api/users/3", w.Header().Get("Location"))
+	assert.Empty(t, w.Body.String())
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/users", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestMock_Template(t *testing.T) {
+	mux := mockServer(t, `
+vars:
+  Version: "1.0"
+mocks:
+  - route: /api/users/{id}
+    template: user.json.tmpl
+  - route: /api/echo
+    method: POST
+    template: echo.tmpl
+    content_type: text/plain
+`, map[string]string{
+		"user.json.tmpl": `{"id":{{ .Param "id" | json }},"page":{{ .Query.Get "page" | json }},"version":"{{ .Vars.Version }}"}`,
+		"echo.tmpl":      `{{ .Method }} {{ .Body.name }}`,
+	})
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users/42?page=2", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"id":"42","page":"2","version":"1.0"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/echo", strings.NewReader(`{"name":"bob"}`)))
+	assert.Equal(t, "text/plain", w.Header().Get("Content-Type"))
+	assert.Equal(t, "POST bob", w.Body.String())
+}
+
+func TestMock_TemplateError(t *testing.T) {
+	mux := mockServer(t, `
+mocks:
+  - route: /api/broken
+    template: broken.tmpl
+`, map[string]string{
+		"broken.tmpl": `{{ .Missing }`,
+	})
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/broken", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestMock_Latency(t *testing.T) {
+	mux := mockServer(t, `
+mocks:
+  - route: /api/slow
+    status: 503
+    latency: 100ms
+`, nil)
+
+	start := time.Now()
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/slow", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestMock_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "data.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		mock []Mock
+	}{
+		{"relative route", []Mock{{Route: "api"}}},
+		{"invalid method", []Mock{{Route: "/api", Method: "GET /"}}},
+		{"invalid status", []Mock{{Route: "/api", Status: 99}}},
+		{"negative latency", []Mock{{Route: "/api", Latency: -time.Second}}},
+		{"file and template", []Mock{{Route: "/api", File: "data.json", Template: "data.json"}}},
+		{"missing file", []Mock{{Route: "/api", File: "missing.json"}}},
+		{"directory", []Mock{{Route: "/api", File: "."}}},
+		{"duplicate", []Mock{{Route: "/api", Method: "GET"}, {Route: "/api", Method: "get"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Config{Mocks: tt.mock}.MockHandlers(dir)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMock_Asset(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string]string{
+		"data.json": `{"source":"asset"}`,
+		"mock.json": `{"source":"mock"}`,
+		"logo.svg":  `<svg/>`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := &ServeContext{}
+	c.Path = dir
+	c.Assets = []string{"data.json", "logo.svg"}
+	mocks, err := Config{Mocks: []Mock{{Route: "/data.json", File: "mock.json"}}}.MockHandlers(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.mocks = mocks
+
+	// The mock is registered in place of the asset at the same route
+	mux := http.NewServeMux()
+	if !assert.NoError(t, c.handleAssets(mux)) {
+		t.FailNow()
+	}
+	for _, mock := range c.mocks {
+		if !assert.NoError(t, handle(mux, mock.Pattern, mock)) {
+			t.FailNow()
+		}
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/data.json", nil))
+	assert.JSONEq(t, `{"source":"mock"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/logo.svg", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `<svg/>`, w.Body.String())
+}
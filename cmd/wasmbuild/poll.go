@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// poll scans the dependency paths at the poll interval, and sends on the
+// modified channel when the snapshot of the paths changes. This is used
+// where filesystem notifications are not delivered, for example on NFS or
+// on bind-mounted volumes in containers. If discover is not nil, it is
+// called after each change to pick up new dependency paths.
+func (d *DepContext) poll(ctx context.Context, paths []string, discover func() ([]string, error)) error {
+	ticker := time.NewTicker(d.Poll)
+	defer ticker.Stop()
+
+	// Take the initial snapshot
+	snapshot := pollSnapshot(paths)
+
+	// Each change restarts the debounce timer, so that a burst of changes
+	// results in a single notification once no change has been seen for
+	// the debounce delay
+	debounce := time.NewTimer(debounceDelay)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-debounce.C:
+			if !d.send(ctx, nil) {
+				return nil
+			}
+		case <-ticker.C:
+			if next := pollSnapshot(paths); next != snapshot {
+				snapshot = next
+				debounce.Reset(debounceDelay)
+
+				// Re-discover dependencies, as directories may have been added
+				if discover != nil {
+					if newPaths, err := discover(); err != nil {
+						if !d.send(ctx, fmt.Errorf("failed to re-discover dependencies: %w", err)) {
+							return nil
+						}
+					} else {
+						paths = newPaths
+						snapshot = pollSnapshot(paths)
+					}
+				}
+			}
+		}
+	}
+}
+
+// send a modification event, returning false if the context was cancelled
+// before the event was received
+func (d *DepContext) send(ctx context.Context, err error) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case d.modified <- err:
+		return true
+	}
+}
+
+// pollSnapshot returns a hash of the name, size and modification time of
+// each file path and of the entries of each directory path. Like fsnotify,
+// only the direct entries of a directory are considered. Hidden files are
+// ignored, and directories or paths which cannot be read contribute only
+// their name.
+func pollSnapshot(paths []string) uint64 {
+	hash := fnv.New64a()
+
+	// Sort paths so the snapshot does not depend on order
+	paths = append([]string(nil), paths...)
+	sort.Strings(paths)
+
+	// Write a file entry into the hash
+	write := func(path string, info os.FileInfo) {
+		var buf [16]byte
+		hash.Write([]byte(path))
+		hash.Write([]byte{0})
+		if info == nil {
+			return
+		}
+		binary.LittleEndian.PutUint64(buf[0:], uint64(info.Size()))
+		binary.LittleEndian.PutUint64(buf[8:], uint64(info.ModTime().UnixNano()))
+		hash.Write(buf[:])
+	}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			write(path, nil)
+			continue
+		} else if !info.IsDir() {
+			write(path, info)
+			continue
+		}
+
+		// The directory's own modification time changes with hidden files,
+		// so is not included
+		write(path+string(filepath.Separator), nil)
+
+		// Directory entries are returned sorted by name
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			} else if info.IsDir() {
+				write(filepath.Join(path, entry.Name())+string(filepath.Separator), nil)
+			} else {
+				write(filepath.Join(path, entry.Name()), info)
+			}
+		}
+	}
+
+	return hash.Sum64()
+}
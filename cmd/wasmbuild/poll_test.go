@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	// Packages
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoll_Snapshot(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "main.go")
+	assert.NoError(t, os.WriteFile(file, []byte("package main\n"), 0o644))
+
+	t.Run("unchanged", func(t *testing.T) {
+		assert.Equal(t, pollSnapshot([]string{dir}), pollSnapshot([]string{dir}))
+	})
+
+	t.Run("path order", func(t *testing.T) {
+		other := t.TempDir()
+		assert.Equal(t, pollSnapshot([]string{dir, other}), pollSnapshot([]string{other, dir}))
+	})
+
+	t.Run("file modified", func(t *testing.T) {
+		before := pollSnapshot([]string{dir})
+		assert.NoError(t, os.WriteFile(file, []byte("package main\n\nfunc main() {}\n"), 0o644))
+		assert.NotEqual(t, before, pollSnapshot([]string{dir}))
+	})
+
+	t.Run("mtime changed", func(t *testing.T) {
+		before := pollSnapshot([]string{dir})
+		mtime := time.Now().Add(time.Hour)
+		assert.NoError(t, os.Chtimes(file, mtime, mtime))
+		assert.NotEqual(t, before, pollSnapshot([]string{dir}))
+	})
+
+	t.Run("file added and removed", func(t *testing.T) {
+		before := pollSnapshot([]string{dir})
+		added := filepath.Join(dir, "added.go")
+		assert.NoError(t, os.WriteFile(added, []byte("package main\n"), 0o644))
+		assert.NotEqual(t, before, pollSnapshot([]string{dir}))
+		assert.NoError(t, os.Remove(added))
+		assert.Equal(t, before, pollSnapshot([]string{dir}))
+	})
+
+	t.Run("hidden files ignored", func(t *testing.T) {
+		before := pollSnapshot([]string{dir})
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, ".swp"), []byte("x"), 0o644))
+		assert.Equal(t, before, pollSnapshot([]string{dir}))
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		missing := filepath.Join(dir, "missing")
+		before := pollSnapshot([]string{missing})
+		assert.NoError(t, os.Mkdir(missing, 0o755))
+		assert.NotEqual(t, before, pollSnapshot([]string{missing}))
+	})
+}
+
+func TestPoll_Modified(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "main.go")
+	assert.NoError(t, os.WriteFile(file, []byte("package main\n"), 0o644))
+
+	dep := &DepContext{
+		Poll:     10 * time.Millisecond,
+		modified: make(chan error),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- dep.poll(ctx, []string{dir}, nil)
+	}()
+
+	// No modification event when nothing changes
+	select {
+	case err := <-dep.modified:
+		t.Fatal("unexpected modification event:", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// A burst of changes results in a single event, once the changes have
+	// settled for the debounce delay
+	var changed time.Time
+	for i := 0; i < 3; i++ {
+		mtime := time.Now().Add(time.Duration(i+1) * time.Minute)
+		assert.NoError(t, os.Chtimes(file, mtime, mtime))
+		changed = time.Now()
+		time.Sleep(20 * time.Millisecond)
+	}
+	select {
+	case err := <-dep.modified:
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(changed), debounceDelay)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for modification event")
+	}
+	select {
+	case err := <-dep.modified:
+		t.Fatal("unexpected modification event:", err)
+	case <-time.After(debounceDelay + 100*time.Millisecond):
+	}
+
+	// Cancelling the context ends polling
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for poll to return")
+	}
+}
+
+func TestPoll_Discover(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "sub")
+
+	dep := &DepContext{
+		Poll:     10 * time.Millisecond,
+		modified: make(chan error),
+	}
+
+	// Discovery adds the new subdirectory to the watched paths
+	discovered := make(chan struct{}, 1)
+	discover := func() ([]string, error) {
+		select {
+		case discovered <- struct{}{}:
+		default:
+		}
+		return []string{dir, sub}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go dep.poll(ctx, []string{dir}, discover)
+
+	// Allow the initial snapshot to be taken before making changes
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(t, os.Mkdir(sub, 0o755))
+	select {
+	case err := <-dep.modified:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for modification event")
+	}
+	select {
+	case <-discovered:
+	default:
+		t.Fatal("expected dependencies to be re-discovered")
+	}
+
+	// Changes within the new subdirectory are now detected
+	assert.NoError(t, os.WriteFile(filepath.Join(sub, "sub.go"), []byte("package sub\n"), 0o644))
+	select {
+	case err := <-dep.modified:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for modification event")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 )
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -21,7 +22,8 @@ type ServeCmd struct {
 }
 
 type WatchFlag struct {
-	Watch bool `short:"w" help:"Watch for changes in dependencies"`
+	Watch bool          `short:"w" help:"Watch for changes in dependencies"`
+	Poll  time.Duration `placeholder:"INTERVAL" help:"When watching, poll for changes at this interval instead of using filesystem notifications (e.g. 1s)"`
 }
 
 type ServeContext struct {
@@ -31,6 +33,16 @@ type ServeContext struct {
 
 	// Broadcast notifications to clients
 	broadcaster *ServeBroadcaster `json:"-"`
+
+	// Logger
+	log *Logger
+
+	// Compiled WebAssembly files for each target
+	mu    sync.RWMutex
+	files map[string]*File
+
+	// Mock API routes
+	mocks []*MockHandler
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -39,11 +51,19 @@ type ServeContext struct {
 // ServeContext creates a ServeContext from a DepContext, returning all the
 // information needed to serve a WASM application.
 func (d DepContext) ServeContext(ctx *Context, listen string, watch bool) (*ServeContext, error) {
+	// Resolve the mock routes
+	mocks, err := d.MockHandlers(d.Path)
+	if err != nil {
+		return nil, err
+	}
+
 	// Return the ServeContext
 	return &ServeContext{
 		DepContext: d,
 		Listen:     listen,
 		Watch:      watch,
+		log:        ctx.log,
+		mocks:      mocks,
 	}, nil
 }
 
@@ -68,7 +88,7 @@ func (c *ServeCmd) Run(ctx *Context) error {
 	}
 
 	// Create a dependency context from the build context
-	dep, err := buildContext.DepContext(ctx)
+	dep, err := buildContext.DepContext(ctx, c.Poll)
 	if err != nil {
 		return err
 	}
@@ -79,12 +99,12 @@ func (c *ServeCmd) Run(ctx *Context) error {
 		return err
 	}
 
-	// Compile the .wasm file
-	file, err := serveContext.CompileExec(ctx)
+	// Compile the .wasm files
+	files, err := serveContext.CompileTargets(ctx)
 	if err != nil {
 		return err
 	} else {
-		serveContext.wasm = file
+		serveContext.setFiles(files)
 	}
 
 	// Log the serve context
@@ -122,20 +142,13 @@ func (c *ServeContext) Serve(ctx *Context, files ...*File) error {
 		}
 	}
 
-	// Serve assets
-	for _, asset := range c.Assets {
-		if filepath.IsAbs(asset) == false {
-			asset = filepath.Join(c.Path, asset)
-		}
-		if info, err := os.Stat(asset); err != nil {
+	// Serve assets, and mock routes which take precedence over them
+	if err := c.handleAssets(handler); err != nil {
+		return err
+	}
+	for _, mock := range c.mocks {
+		if err := handle(handler, mock.Pattern, mock); err != nil {
 			return err
-		} else if info.Mode().IsRegular() {
-			file, err := NewFileFromSource(asset, filepath.Base(asset))
-			if err != nil {
-				return err
-			} else {
-				handler.Handle(file.URL(), file.Handler())
-			}
 		}
 	}
 
@@ -145,11 +158,8 @@ func (c *ServeContext) Serve(ctx *Context, files ...*File) error {
 		handler.HandleFunc("/_notify", c.NotifyHandler)
 	}
 
-	// WASM file handler
-	handler.HandleFunc(c.wasm.URL(), func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/wasm")
-		w.Write(c.wasm.Data)
-	})
+	// WASM file handler, with the target selected by query parameter
+	handler.HandleFunc(c.wasm.URL(), c.WasmHandler)
 
 	// Output listening info
 	url, err := url.Parse(fmt.Sprintf("http://%s%s", c.Listen, c.WasmExecHTML.URL()))
@@ -180,12 +190,12 @@ func (c *ServeContext) Serve(ctx *Context, files ...*File) error {
 				case <-ctx.ctx.Done():
 					return
 				case <-c.DepContext.modified:
-					// Re-compile the .wasm file
-					if file, err := c.CompileExec(ctx); err != nil {
+					// Re-compile the .wasm files
+					if files, err := c.CompileTargets(ctx); err != nil {
 						c.broadcaster.error(err)
 						ctx.log.Error(err)
 					} else {
-						c.wasm = file
+						c.setFiles(files)
 						c.broadcaster.reload()
 					}
 				}
@@ -229,9 +239,79 @@ func (c *ServeContext) Serve(ctx *Context, files ...*File) error {
 	return nil
 }
 
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// handle registers a handler, returning an error rather than panicking
+// when the pattern conflicts with an existing one
+func handle(mux *http.ServeMux, pattern string, handler http.Handler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	mux.Handle(pattern, handler)
+	return nil
+}
+
+// handleAssets registers the assets which are files, except where a mock
+// has the same route, so that data shipped by the build can be mocked
+// when serving
+func (c *ServeContext) handleAssets(mux *http.ServeMux) error {
+	mocked := make(map[string]bool, len(c.mocks))
+	for _, mock := range c.mocks {
+		mocked[mock.Route] = true
+	}
+	for _, asset := range c.Assets {
+		if filepath.IsAbs(asset) == false {
+			asset = filepath.Join(c.Path, asset)
+		}
+		if info, err := os.Stat(asset); err != nil {
+			return err
+		} else if info.Mode().IsRegular() {
+			file, err := NewFileFromSource(asset, filepath.Base(asset))
+			if err != nil {
+				return err
+			} else if !mocked[file.URL()] {
+				mux.Handle(file.URL(), file.Handler())
+			}
+		}
+	}
+	return nil
+}
+
+// setFiles sets the compiled files for each target
+func (c *ServeContext) setFiles(files map[string]*File) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.files = files
+	c.wasm = files[c.Target("").Name]
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // HANDLERS
 
+// wasm handler, which serves the target named by the "target" query
+// parameter, or the default target
+func (c *ServeContext) WasmHandler(w http.ResponseWriter, r *http.Request) {
+	target := c.Target(r.URL.Query().Get("target"))
+	if target == nil {
+		http.Error(w, fmt.Sprintf("Unknown target: %q", r.URL.Query().Get("target")), http.StatusNotFound)
+		return
+	}
+
+	c.mu.RLock()
+	file := c.files[target.Name]
+	c.mu.RUnlock()
+	if file == nil {
+		http.Error(w, fmt.Sprintf("Target not compiled: %q", target.Name), http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/wasm")
+	w.Write(file.Data)
+}
+
 // notify handler
 func (c *ServeContext) NotifyHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/event-stream")
@@ -258,7 +338,7 @@ func (c *ServeContext) NotifyHandler(w http.ResponseWriter, r *http.Request) {
 	for {
 		select {
 		case msg := <-notify:
-			fmt.Println("Notify client:", msg.Type)
+			c.log.Debug("Notify client: ", msg.Type)
 			switch msg.Type {
 			case "reload":
 				fmt.Fprintf(w, "event: reload\ndata: reload\n\n")
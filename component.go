@@ -23,6 +23,10 @@ type Component interface {
 	// Add an event listener to the component's root element
 	AddEventListener(event string, handler func(Node)) Component
 
+	// Add an event handler to the component's root element, and return a
+	// handle to remove it
+	AddEventHandler(event string, handler func(Event), opts ...EventListenerOption) EventListener
+
 	// Apply options to the component
 	Apply(opts ...any) Component
 }
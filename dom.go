@@ -1,21 +1,50 @@
 package dom
 
-import "io"
+import (
+	"io"
+	"iter"
+	"net/url"
+	"time"
+)
 
 ///////////////////////////////////////////////////////////////////////////////
 // TYPES
 
 type NodeType int
 
+// EventPhase is the phase of event dispatch
+type EventPhase int
+
+// EventListenerOption is an option for adding an event listener
+type EventListenerOption uint
+
+// DocumentPosition is a mask of the position of a node relative to
+// another node
+type DocumentPosition uint
+
+// NodeFilter is a mask of the node types shown by a tree walker or node
+// iterator
+type NodeFilter uint32
+
+// FilterResult is returned by the filter function of a tree walker or
+// node iterator
+type FilterResult int
+
+// ShadowRootMode determines whether a shadow root is accessible from its
+// host
+type ShadowRootMode string
+
 ///////////////////////////////////////////////////////////////////////////////
 // INTERFACES
 
 // Node implements https://developer.mozilla.org/en-US/docs/Web/API/Node
 type Node interface {
-	// Properties
+	// Properties, where Equals compares the identity of nodes and
+	// IsEqualNode compares their type, names, attributes, data and children
 	ChildNodes() []Node
 	Contains(Node) bool
 	Equals(Node) bool
+	IsEqualNode(Node) bool
 	FirstChild() Node
 	HasChildNodes() bool
 	IsConnected() bool
@@ -36,14 +65,38 @@ type Node interface {
 	RemoveChild(Node)
 	ReplaceChild(Node, Node)
 	Component() Component
+
+	// CompareDocumentPosition returns the position of the other node
+	// relative to this node, GetRootNode returns the topmost ancestor,
+	// which is the shadow root for nodes in a shadow tree, and
+	// Normalize removes empty text nodes and merges adjacent text nodes in
+	// the descendants
+	CompareDocumentPosition(Node) DocumentPosition
+	GetRootNode() Node
+	Normalize()
+
+	// Event Methods, which return false if the event was cancelled
+	DispatchEvent(Event) bool
+
+	// Iterators, which yield the descendants in tree order, the ancestors
+	// from the parent to the root, and the siblings after or before the
+	// node, moving away from it
+	Descendants() iter.Seq[Node]
+	Ancestors() iter.Seq[Node]
+	NextSiblings() iter.Seq[Node]
+	PreviousSiblings() iter.Seq[Node]
 }
 
 // Element implements https://developer.mozilla.org/en-US/docs/Web/API/Element
 type Element interface {
 	Node
 
-	// Properties
+	// Properties, where the tag name of an HTML element is uppercase and
+	// the tag name of other elements is the qualified name
 	TagName() string
+	NamespaceURI() string
+	Prefix() string
+	LocalName() string
 	ID() string
 	SetID(string)
 	ClassName() string
@@ -54,6 +107,13 @@ type Element interface {
 	Attributes() []Attr
 	Style() Style
 	ClassList() TokenList
+	Dataset() StringMap
+
+	// Shadow DOM, where AttachShadow panics if the element cannot host a
+	// shadow root or already has one, and ShadowRoot returns nil when
+	// there is no shadow root or it is closed
+	AttachShadow(ShadowRootMode) ShadowRoot
+	ShadowRoot() ShadowRoot
 
 	// Attribute Methods
 	RemoveAttribute(string)
@@ -66,9 +126,21 @@ type Element interface {
 	HasAttribute(string) bool
 	HasAttributes() bool
 
-	// Selection Methods
+	// Namespaced Attribute Methods, where the namespace is empty for
+	// attributes which are not in a namespace. SetAttributeNS takes a
+	// qualified name, and the other methods take a local name.
+	SetAttributeNS(namespace, name, value string) Attr
+	GetAttributeNS(namespace, name string) string
+	HasAttributeNS(namespace, name string) bool
+	RemoveAttributeNS(namespace, name string)
+
+	// Selection Methods, where selector methods panic on an invalid selector
 	GetElementsByClassName(string) []Element
 	GetElementsByTagName(string) []Element
+	QuerySelector(string) Element
+	QuerySelectorAll(string) []Element
+	Matches(string) bool
+	Closest(string) Element
 
 	// DOM Manipulation Methods
 	Children() []Element
@@ -81,8 +153,16 @@ type Element interface {
 	ReplaceWith(...Node)
 	InsertAdjacentElement(string, Element) Element
 
-	// Event Methods
-	AddEventListener(string, func(Node)) Element
+	// Event Methods, where AddEventHandler returns a handle to remove the
+	// listener
+	AddEventListener(string, func(Node), ...EventListenerOption) Element
+	AddEventHandler(string, func(Event), ...EventListenerOption) EventListener
+
+	// Synthetic Events, which dispatch events as if the user had clicked the
+	// element, submitted a form or changed the value of a form control
+	Click()
+	Submit()
+	Input(string)
 
 	// Focus Methods
 	Blur()
@@ -94,21 +174,167 @@ type Document interface {
 	Node
 
 	// Properties
+	ActiveElement() Element
 	Body() Element
-	//CharacterSet() string
-	//ContentType() string
+	CharacterSet() string
+	ContentType() string
 	Doctype() DocumentType
-	//DocumentElement() Element
-	//DocumentURI() string
-	//Head() Element
+	DocumentElement() Element
+	DocumentURI() string
+	Head() Element
 	Title() string
+	SetTitle(string)
 
-	// Methods
+	// Methods, where CreateElement creates an HTML element and
+	// CreateElementNS creates an element with a namespace and qualified
+	// name, panicking if the name is not valid for the namespace
 	CreateElement(string) Element
+	CreateElementNS(namespace, name string) Element
 	CreateAttribute(string) Attr
 	CreateComment(string) Comment
 	CreateTextNode(string) Text
-	//ActiveElement() Element
+	CreateDocumentFragment() DocumentFragment
+	CreateRange() Range
+	GetElementById(string) Element
+
+	// Event Methods, for events dispatched to the document or bubbling
+	// from its descendants, where AddEventHandler returns a handle to
+	// remove the listener
+	AddEventHandler(string, func(Event), ...EventListenerOption) EventListener
+
+	// Traversal Methods, where the filter function may be nil to accept
+	// all nodes shown by the whatToShow mask
+	CreateTreeWalker(root Node, whatToShow NodeFilter, filter func(Node) FilterResult) TreeWalker
+	CreateNodeIterator(root Node, whatToShow NodeFilter, filter func(Node) FilterResult) NodeIterator
+
+	// ImportNode returns a copy of a node owned by this document, and
+	// AdoptNode moves a node to this document. Both panic if the node is
+	// a document.
+	ImportNode(Node, bool) Node
+	AdoptNode(Node) Node
+
+	// Selection Methods, which panic on an invalid selector
+	QuerySelector(string) Element
+	QuerySelectorAll(string) []Element
+}
+
+// DocumentFragment implements https://developer.mozilla.org/en-US/docs/Web/API/DocumentFragment
+// When a fragment is appended or inserted into a node, its children are
+// moved into the node and the fragment is left empty.
+type DocumentFragment interface {
+	Node
+
+	// Properties
+	Children() []Element
+	ChildElementCount() int
+	FirstElementChild() Element
+	LastElementChild() Element
+
+	// Selection Methods, which panic on an invalid selector
+	QuerySelector(string) Element
+	QuerySelectorAll(string) []Element
+}
+
+// HTMLTemplateElement implements https://developer.mozilla.org/en-US/docs/Web/API/HTMLTemplateElement
+// The parsed contents of a template are the children of its content
+// fragment rather than the template, so they are not rendered or
+// selected until the content is cloned into a document.
+type HTMLTemplateElement interface {
+	Element
+
+	// Properties
+	Content() DocumentFragment
+}
+
+// ShadowRoot implements https://developer.mozilla.org/en-US/docs/Web/API/ShadowRoot
+// The shadow root is the root of a tree which is rendered in place of the
+// children of its host. The children of the host are rendered in the slot
+// elements of the shadow tree.
+type ShadowRoot interface {
+	DocumentFragment
+
+	// Properties
+	Mode() ShadowRootMode
+	Host() Element
+	InnerHTML() string
+	SetInnerHTML(string)
+}
+
+// HTMLSlotElement implements https://developer.mozilla.org/en-US/docs/Web/API/HTMLSlotElement
+// Children of the host with a slot attribute are assigned to the first
+// slot with the same name, and other children to the first slot without a
+// name. When flatten is true, assigned slots are replaced with their
+// assigned nodes, and a slot with no assigned nodes returns its children.
+type HTMLSlotElement interface {
+	Element
+
+	// Properties
+	Name() string
+	SetName(string)
+
+	// Methods
+	AssignedNodes(flatten bool) []Node
+	AssignedElements(flatten bool) []Element
+}
+
+// Range implements https://developer.mozilla.org/en-US/docs/Web/API/Range
+// Boundary offsets are child indexes, or character offsets within text
+// and comment nodes. Methods panic on an invalid boundary point.
+type Range interface {
+	// Properties
+	StartContainer() Node
+	StartOffset() int
+	EndContainer() Node
+	EndOffset() int
+	Collapsed() bool
+	CommonAncestorContainer() Node
+
+	// Boundary Methods
+	SetStart(Node, int)
+	SetEnd(Node, int)
+	SelectNode(Node)
+	SelectNodeContents(Node)
+	Collapse(toStart bool)
+
+	// Content Methods
+	CloneContents() DocumentFragment
+	ExtractContents() DocumentFragment
+	DeleteContents()
+}
+
+// TreeWalker implements https://developer.mozilla.org/en-US/docs/Web/API/TreeWalker
+// The methods move the current node and return it, or return nil and
+// leave the current node unchanged when there is no matching node.
+type TreeWalker interface {
+	// Properties
+	Root() Node
+	WhatToShow() NodeFilter
+	CurrentNode() Node
+	SetCurrentNode(Node)
+
+	// Methods
+	ParentNode() Node
+	FirstChild() Node
+	LastChild() Node
+	PreviousSibling() Node
+	NextSibling() Node
+	PreviousNode() Node
+	NextNode() Node
+}
+
+// NodeIterator implements https://developer.mozilla.org/en-US/docs/Web/API/NodeIterator
+// The reference node is updated when it is removed from the document.
+type NodeIterator interface {
+	// Properties
+	Root() Node
+	WhatToShow() NodeFilter
+	ReferenceNode() Node
+	PointerBeforeReferenceNode() bool
+
+	// Methods, where Detach stops updating the iterator on removal
+	NextNode() Node
+	PreviousNode() Node
+	Detach()
 }
 
 type Text interface {
@@ -117,6 +343,9 @@ type Text interface {
 	// Properties
 	Data() string
 	Length() int
+
+	// Methods
+	SetData(string)
 }
 
 type Comment interface {
@@ -125,6 +354,9 @@ type Comment interface {
 	// Properties
 	Data() string
 	Length() int
+
+	// Methods
+	SetData(string)
 }
 
 type Attr interface {
@@ -133,15 +365,31 @@ type Attr interface {
 	// Properties
 	OwnerElement() Element
 	Name() string
+	NamespaceURI() string
+	Prefix() string
+	LocalName() string
 	Value() string
 	SetValue(string)
 }
 
 // Style implements https://developer.mozilla.org/en-US/docs/Web/API/CSSStyleDeclaration
+// Property names are CSS property names, such as "background-color", and
+// setting an empty value removes the property. The priority of a property
+// is "important" or empty.
 type Style interface {
+	// Properties
+	Length() int
+	CSSText() string
+	SetCSSText(string)
+
 	// Methods
+	Item(int) string
+	Names() []string
 	Get(string) string
 	Set(string, string)
+	SetProperty(name, value, priority string)
+	RemoveProperty(string) string
+	GetPropertyPriority(string) string
 }
 
 // Document implements https://developer.mozilla.org/en-US/docs/Web/API/DocumentType
@@ -155,14 +403,180 @@ type DocumentType interface {
 }
 
 // Window implements https://developer.mozilla.org/en-US/docs/Web/API/Window
+// The DOM is not safe for concurrent use: it should only be accessed from
+// the event loop, which calls event listeners, timers and the functions
+// queued with Dispatch. The native implementation panics when the DOM is
+// modified by another goroutine while the event loop is calling a function.
 type Window interface {
 	// Properties
 	Document() Document
+	CustomElements() CustomElementRegistry
+	Location() Location
+	History() History
+	LocalStorage() Storage
+	SessionStorage() Storage
+	InnerWidth() int
+	InnerHeight() int
+
+	// Timers, which call a function once after a delay, repeatedly with an
+	// interval, or before the next repaint with the time since the window
+	// was created. In the native build, time only moves forward when the
+	// clock is advanced.
+	SetTimeout(func(), time.Duration) Timer
+	SetInterval(func(), time.Duration) Timer
+	RequestAnimationFrame(func(time.Duration)) Timer
+
+	// MatchMedia returns a list which reports whether the document matches
+	// a media query
+	MatchMedia(string) MediaQueryList
+
+	// Dispatch queues a function to be called on the event loop, so that
+	// code in other goroutines, such as the callbacks of network requests,
+	// can access the DOM. Functions are called in the order they are queued.
+	// It is the only method which can be called from any goroutine.
+	Dispatch(func())
+
+	// Event Methods, for events which are dispatched to the window such as
+	// popstate, resize and storage, where the target of the event is nil
+	AddEventHandler(string, func(Event), ...EventListenerOption) EventListener
+	DispatchEvent(Event) bool
 
 	// Methods
 	Write(io.Writer, Node) (int, error)
-	NewMutationObserver(callback func()) MutationObserver
-	//Read(io.Reader, string) (Document, error)
+	WriteIndent(w io.Writer, node Node, indent string) (int, error)
+	Read(io.Reader, string) (Document, error)
+	ParseFragment(io.Reader, Element) ([]Node, error)
+	NewMutationObserver(callback func([]MutationRecord)) MutationObserver
+	NewEvent(eventType string, bubbles, cancelable bool) Event
+	NewKeyboardEvent(eventType string, init map[string]interface{}) KeyboardEvent
+	NewMouseEvent(eventType string, init map[string]interface{}) MouseEvent
+	NewInputEvent(eventType string, init map[string]interface{}) InputEvent
+}
+
+// Location implements https://developer.mozilla.org/en-US/docs/Web/API/Location
+type Location interface {
+	// Properties, where URL returns a copy of the parsed URL
+	URL() *url.URL
+	Href() string
+	Origin() string
+	Protocol() string
+	Host() string
+	Hostname() string
+	Port() string
+	Pathname() string
+	Search() string
+	Hash() string
+
+	// Methods, which resolve the URL against the current URL, where Assign
+	// adds an entry to the history and Replace replaces the current entry.
+	// They panic if the URL cannot be parsed.
+	Assign(string)
+	Replace(string)
+}
+
+// History implements https://developer.mozilla.org/en-US/docs/Web/API/History
+// The state is stored as JSON, so State returns the decoded value, such as
+// a map[string]any for a struct. PushState and ReplaceState panic if the
+// state cannot be encoded or the URL cannot be parsed.
+type History interface {
+	// Properties
+	Length() int
+	State() any
+
+	// Methods, where moving through the history dispatches a popstate
+	// event to the window
+	PushState(state any, url string)
+	ReplaceState(state any, url string)
+	Back()
+	Forward()
+	Go(int)
+}
+
+// Storage implements https://developer.mozilla.org/en-US/docs/Web/API/Storage
+// When an item in local storage changes, a storage event is dispatched to
+// the other windows which share it.
+type Storage interface {
+	// Properties
+	Length() int
+	Key(int) string
+
+	// Methods, where GetItem returns false if there is no item for the key
+	GetItem(string) (string, bool)
+	SetItem(string, string)
+	RemoveItem(string)
+	Clear()
+
+	// Typed Methods, where JSON returns ErrNotFound if there is no item
+	JSON(key string, v any) error
+	SetJSON(key string, v any) error
+
+	// WithPrefix returns the items with keys which start with the prefix,
+	// with the prefix removed from the keys, so that applications can share
+	// the storage. Clear only removes the items with the prefix.
+	WithPrefix(string) Storage
+}
+
+// Timer is a handle to a timeout, interval or animation frame
+type Timer interface {
+	// Cancel the timer, which has no effect if it has already been called
+	// or cancelled
+	Cancel()
+}
+
+// MediaQueryList implements https://developer.mozilla.org/en-US/docs/Web/API/MediaQueryList
+type MediaQueryList interface {
+	// Properties
+	Media() string
+	Matches() bool
+
+	// AddChangeListener calls the function with the new value of Matches
+	// when it changes, and returns a handle to remove the listener
+	AddChangeListener(func(bool)) EventListener
+}
+
+// CustomElementRegistry implements https://developer.mozilla.org/en-US/docs/Web/API/CustomElementRegistry
+// for custom elements backed by Go values. The constructor is called when an
+// element with a defined name is created or upgraded, and the value it
+// returns receives the callbacks which it implements.
+type CustomElementRegistry interface {
+	// Define registers a constructor for a name, and upgrades the elements
+	// in the document with the name. Changes to the observed attributes are
+	// passed to AttributeChangedCallback. It panics if the name is not a
+	// valid custom element name or is already defined.
+	Define(name string, constructor func(Element) CustomElement, observedAttributes ...string)
+
+	// IsDefined returns true if the name has been defined
+	IsDefined(name string) bool
+
+	// Upgrade upgrades the elements in the tree of root which are defined
+	Upgrade(root Node)
+
+	// Instance returns the value for an upgraded custom element, or nil
+	Instance(Element) CustomElement
+}
+
+// CustomElement is the value for a custom element, which may implement
+// ConnectedCallback, DisconnectedCallback and AttributeChangedCallback
+type CustomElement interface {
+	Element() Element
+}
+
+// ConnectedCallback is called when a custom element is added to a document
+type ConnectedCallback interface {
+	ConnectedCallback()
+}
+
+// DisconnectedCallback is called when a custom element is removed from a
+// document
+type DisconnectedCallback interface {
+	DisconnectedCallback()
+}
+
+// AttributeChangedCallback is called when an observed attribute of a custom
+// element is added, changed or removed, where the old or new value is empty
+// when the attribute is not set
+type AttributeChangedCallback interface {
+	AttributeChangedCallback(name, oldValue, newValue string)
 }
 
 // TokenList implements https://developer.mozilla.org/en-US/docs/Web/API/DOMTokenList
@@ -179,11 +593,145 @@ type TokenList interface {
 	Toggle(value string, force ...bool) bool
 }
 
+// StringMap implements https://developer.mozilla.org/en-US/docs/Web/API/DOMStringMap
+// as a live view of the data-* attributes of an element, where names are
+// camelCase and methods panic on a name with a hyphen before a lowercase
+// letter. The typed getters return ErrNotFound when the attribute is not
+// set and ErrBadParameter when the value cannot be parsed.
+type StringMap interface {
+	// Properties
+	Length() int
+	Keys() []string
+
+	// Methods
+	Get(string) string
+	Set(string, string)
+	Has(string) bool
+	Delete(string)
+
+	// Typed Methods
+	Int(string) (int, error)
+	SetInt(string, int)
+	Bool(string) (bool, error)
+	SetBool(string, bool)
+	JSON(name string, v any) error
+	SetJSON(name string, v any) error
+}
+
+// Event implements https://developer.mozilla.org/en-US/docs/Web/API/Event
+type Event interface {
+	// Properties
+	Type() string
+	Target() Node
+	CurrentTarget() Node
+	EventPhase() EventPhase
+	Bubbles() bool
+	Cancelable() bool
+	Composed() bool
+	DefaultPrevented() bool
+
+	// Methods
+	PreventDefault()
+	StopPropagation()
+	StopImmediatePropagation()
+}
+
+// EventModifiers are the state of the modifier keys when an event occurred
+type EventModifiers interface {
+	AltKey() bool
+	CtrlKey() bool
+	MetaKey() bool
+	ShiftKey() bool
+}
+
+// KeyboardEvent implements https://developer.mozilla.org/en-US/docs/Web/API/KeyboardEvent
+// The init options are "bubbles", "cancelable", "key", "code", "repeat",
+// "altKey", "ctrlKey", "metaKey" and "shiftKey".
+type KeyboardEvent interface {
+	Event
+	EventModifiers
+
+	// Properties
+	Key() string
+	Code() string
+	Repeat() bool
+}
+
+// MouseEvent implements https://developer.mozilla.org/en-US/docs/Web/API/MouseEvent
+// The init options are "bubbles", "cancelable", "clientX", "clientY",
+// "button", "buttons", "altKey", "ctrlKey", "metaKey" and "shiftKey".
+type MouseEvent interface {
+	Event
+	EventModifiers
+
+	// Properties
+	ClientX() float64
+	ClientY() float64
+	Button() int
+	Buttons() int
+}
+
+// InputEvent implements https://developer.mozilla.org/en-US/docs/Web/API/InputEvent
+// The init options are "bubbles", "cancelable", "data" and "inputType".
+type InputEvent interface {
+	Event
+
+	// Properties
+	Data() string
+	InputType() string
+}
+
+// PopStateEvent implements https://developer.mozilla.org/en-US/docs/Web/API/PopStateEvent
+type PopStateEvent interface {
+	Event
+
+	// Properties
+	State() any
+}
+
+// StorageEvent implements https://developer.mozilla.org/en-US/docs/Web/API/StorageEvent
+// The key and values are empty when the storage is cleared.
+type StorageEvent interface {
+	Event
+
+	// Properties
+	Key() string
+	OldValue() string
+	NewValue() string
+	URL() string
+}
+
+// EventListener is a handle to a listener added with AddEventHandler
+type EventListener interface {
+	// Return the event type
+	Type() string
+
+	// Remove the listener, which has no effect if it has already been removed
+	Remove()
+}
+
 // MutationObserver implements https://developer.mozilla.org/en-US/docs/Web/API/MutationObserver
+// The options are "childList", "attributes", "characterData", "subtree",
+// "attributeOldValue", "characterDataOldValue" and "attributeFilter".
 type MutationObserver interface {
 	// Methods
 	Observe(target Node, options map[string]interface{})
 	Disconnect()
+	TakeRecords() []MutationRecord
+}
+
+// MutationRecord implements https://developer.mozilla.org/en-US/docs/Web/API/MutationRecord
+// The type is "childList", "attributes" or "characterData".
+type MutationRecord interface {
+	// Properties
+	Type() string
+	Target() Node
+	AddedNodes() []Node
+	RemovedNodes() []Node
+	PreviousSibling() Node
+	NextSibling() Node
+	AttributeName() string
+	OldValue() string
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -205,6 +753,72 @@ const (
 	NOTATION_NODE
 )
 
+const (
+	DOCUMENT_POSITION_DISCONNECTED            DocumentPosition = 0x01
+	DOCUMENT_POSITION_PRECEDING               DocumentPosition = 0x02
+	DOCUMENT_POSITION_FOLLOWING               DocumentPosition = 0x04
+	DOCUMENT_POSITION_CONTAINS                DocumentPosition = 0x08
+	DOCUMENT_POSITION_CONTAINED_BY            DocumentPosition = 0x10
+	DOCUMENT_POSITION_IMPLEMENTATION_SPECIFIC DocumentPosition = 0x20
+)
+
+const (
+	SHOW_ELEMENT                NodeFilter = 0x1
+	SHOW_ATTRIBUTE              NodeFilter = 0x2
+	SHOW_TEXT                   NodeFilter = 0x4
+	SHOW_CDATA_SECTION          NodeFilter = 0x8
+	SHOW_PROCESSING_INSTRUCTION NodeFilter = 0x40
+	SHOW_COMMENT                NodeFilter = 0x80
+	SHOW_DOCUMENT               NodeFilter = 0x100
+	SHOW_DOCUMENT_TYPE          NodeFilter = 0x200
+	SHOW_DOCUMENT_FRAGMENT      NodeFilter = 0x400
+	SHOW_ALL                    NodeFilter = 0xFFFFFFFF
+)
+
+const (
+	// Accept the node
+	FILTER_ACCEPT FilterResult = iota + 1
+
+	// Reject the node and, for a tree walker, its descendants
+	FILTER_REJECT
+
+	// Skip the node, but not its descendants
+	FILTER_SKIP
+)
+
+const (
+	SHADOW_ROOT_OPEN   ShadowRootMode = "open"
+	SHADOW_ROOT_CLOSED ShadowRootMode = "closed"
+)
+
+// Namespaces of elements and attributes
+const (
+	NS_HTML   = "http://www.w3.org/1999/xhtml"
+	NS_SVG    = "http://www.w3.org/2000/svg"
+	NS_MATHML = "http://www.w3.org/1998/Math/MathML"
+	NS_XLINK  = "http://www.w3.org/1999/xlink"
+	NS_XML    = "http://www.w3.org/XML/1998/namespace"
+	NS_XMLNS  = "http://www.w3.org/2000/xmlns/"
+)
+
+const (
+	EVENT_NONE EventPhase = iota
+	CAPTURING_PHASE
+	AT_TARGET
+	BUBBLING_PHASE
+)
+
+const (
+	// Listen during the capture phase, rather than the bubble phase
+	EVENT_CAPTURE EventListenerOption = 1 << iota
+
+	// Remove the listener after it has been called once
+	EVENT_ONCE
+
+	// Ignore calls to PreventDefault from the listener
+	EVENT_PASSIVE
+)
+
 ///////////////////////////////////////////////////////////////////////////////
 // STRINGIFY
 
@@ -238,3 +852,16 @@ func (t NodeType) String() string {
 		return "UNKNOWN_NODE"
 	}
 }
+
+func (p EventPhase) String() string {
+	switch p {
+	case CAPTURING_PHASE:
+		return "CAPTURING_PHASE"
+	case AT_TARGET:
+		return "AT_TARGET"
+	case BUBBLING_PHASE:
+		return "BUBBLING_PHASE"
+	default:
+		return "EVENT_NONE"
+	}
+}
@@ -0,0 +1,91 @@
+package dom
+
+// FormControl is implemented by input, select, textarea and button elements.
+// The value and checked state are properties, which are initially set from
+// the value and checked attributes, and are then independent of them.
+type FormControl interface {
+	Element
+
+	// Properties
+	Name() string
+	Value() string
+	SetValue(string)
+	Disabled() bool
+	SetDisabled(bool)
+
+	// Return the form which the control belongs to, or nil
+	Form() HTMLFormElement
+
+	// Constraint validation, where CheckValidity dispatches an invalid
+	// event and returns false when the control is invalid
+	WillValidate() bool
+	CheckValidity() bool
+	ValidationMessage() string
+	SetCustomValidity(string)
+}
+
+// HTMLInputElement implements https://developer.mozilla.org/en-US/docs/Web/API/HTMLInputElement
+type HTMLInputElement interface {
+	FormControl
+
+	// Properties
+	Type() string
+	DefaultValue() string
+	Checked() bool
+	SetChecked(bool)
+	Required() bool
+}
+
+// HTMLSelectElement implements https://developer.mozilla.org/en-US/docs/Web/API/HTMLSelectElement
+type HTMLSelectElement interface {
+	FormControl
+
+	// Properties
+	Multiple() bool
+	Required() bool
+	Options() []HTMLOptionElement
+	SelectedIndex() int
+	SetSelectedIndex(int)
+}
+
+// HTMLOptionElement implements https://developer.mozilla.org/en-US/docs/Web/API/HTMLOptionElement
+type HTMLOptionElement interface {
+	Element
+
+	// Properties
+	Value() string
+	Text() string
+	Index() int
+	Disabled() bool
+	Selected() bool
+	SetSelected(bool)
+}
+
+// HTMLTextAreaElement implements https://developer.mozilla.org/en-US/docs/Web/API/HTMLTextAreaElement
+type HTMLTextAreaElement interface {
+	FormControl
+
+	// Properties
+	DefaultValue() string
+	Required() bool
+}
+
+// HTMLButtonElement implements https://developer.mozilla.org/en-US/docs/Web/API/HTMLButtonElement
+type HTMLButtonElement interface {
+	FormControl
+
+	// Properties
+	Type() string
+}
+
+// HTMLFormElement implements https://developer.mozilla.org/en-US/docs/Web/API/HTMLFormElement
+type HTMLFormElement interface {
+	Element
+
+	// Properties
+	Elements() []FormControl
+
+	// Methods, where Reset restores the controls to their default values
+	CheckValidity() bool
+	Reset()
+}
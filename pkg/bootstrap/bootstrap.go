@@ -55,3 +55,12 @@ func New() *app {
 		},
 	}
 }
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// AddEventHandler adds an event handler to the root element of the
+// application, rather than the document
+func (app *app) AddEventHandler(event string, handler func(Event), opts ...EventListenerOption) EventListener {
+	return app.component.AddEventHandler(event, handler, opts...)
+}
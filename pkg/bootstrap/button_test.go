@@ -416,3 +416,25 @@ func TestButtonToolbar_ComponentInterface(t *testing.T) {
 	toolbar := bs.ButtonToolbar()
 	assert.Implements(t, (*dom.Component)(nil), toolbar)
 }
+
+func TestButton_AddEventHandler(t *testing.T) {
+	form := bs.Form()
+	btn := bs.Button(bs.PRIMARY)
+	btn.Element().SetAttribute("type", "submit")
+	form.Append(btn)
+
+	// The handler can cancel the form submission
+	var submitted bool
+	handle := form.AddEventHandler("submit", func(evt dom.Event) {
+		submitted = true
+		evt.PreventDefault()
+	})
+	btn.Element().Click()
+	assert.True(t, submitted, "Clicking the button should submit the form")
+
+	// The handler is not called once removed
+	submitted = false
+	handle.Remove()
+	btn.Element().Click()
+	assert.False(t, submitted, "Removed handler should not be called")
+}
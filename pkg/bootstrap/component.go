@@ -181,6 +181,12 @@ func (component *component) AddEventListener(event string, handler func(Node)) C
 	return component
 }
 
+// AddEventHandler adds an event handler to the component's root element,
+// and returns a handle to remove it
+func (component *component) AddEventHandler(event string, handler func(Event), opts ...EventListenerOption) EventListener {
+	return component.root.AddEventHandler(event, handler, opts...)
+}
+
 // Apply applies options to the component's root element
 // It preserves existing id, classes, and attributes by reading them first
 func (component *component) Apply(opts ...any) Component {
@@ -251,11 +257,11 @@ func (component *component) Apply(opts ...any) Component {
 //
 // All bootstrap components automatically set data-component attribute via newComponent()
 func ComponentFromElement(elem Element) Component {
-	if !elem.HasAttribute("data-component") {
+	if !elem.Dataset().Has("component") {
 		return nil
 	}
 
-	componentType := name(elem.GetAttribute("data-component"))
+	componentType := name(elem.Dataset().Get("component"))
 
 	// Create a basic component wrapper
 	c := &component{
@@ -48,7 +48,9 @@ func (container *container) Element() Element {
 // METHODS
 
 func (container *container) Append(children ...any) Component {
-	// Append Component, Element or string children to the root element
+	// Append Component, Element or string children to the root element,
+	// batched into a single fragment
+	fragment := dom.GetWindow().Document().CreateDocumentFragment()
 	for _, child := range children {
 		// Convert to Element if necessary
 		if component, ok := child.(Component); ok {
@@ -56,10 +58,9 @@ func (container *container) Append(children ...any) Component {
 		} else if str, ok := child.(string); ok {
 			child = dom.GetWindow().Document().CreateTextNode(str)
 		}
-
-		// Append to root
-		container.root.AppendChild(child.(Node))
+		fragment.AppendChild(child.(Node))
 	}
+	container.root.AppendChild(fragment)
 
 	// Return the container for chaining
 	return container
@@ -104,9 +104,16 @@ func Label(text string, opt ...Opt) *label {
 // When called with no arguments, it returns the current value.
 // When called with one argument, it sets the value and returns it.
 func (i *input) Value(value ...string) string {
+	control, ok := i.root.(FormControl)
 	if len(value) > 0 {
 		i.root.SetAttribute("value", value[0])
+		if ok {
+			control.SetValue(value[0])
+		}
 		return value[0]
 	}
+	if ok {
+		return control.Value()
+	}
 	return i.root.GetAttribute("value")
 }
@@ -0,0 +1,57 @@
+package bootstrap_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	bs "github.com/djthorpe/go-wasmbuild/pkg/bootstrap"
+	lint "github.com/djthorpe/go-wasmbuild/pkg/lint"
+	assert "github.com/stretchr/testify/assert"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// LINT TESTS
+
+func TestLint_Components(t *testing.T) {
+	tests := []struct {
+		name      string
+		component dom.Component
+	}{
+		{"button", bs.Button(bs.PRIMARY).Append("OK")},
+		{"outline button", bs.OutlineButton(bs.SECONDARY).Append("Cancel")},
+		{"close button", bs.CloseButton()},
+		{"icon button", bs.Button(bs.PRIMARY, bs.WithAriaLabel("Settings")).Append(bs.Icon("gear"))},
+		{"image", bs.Image("photo.jpg", bs.WithAttribute("alt", "Photo"))},
+		{"headings", bs.Container().Append(bs.Heading(1).Append("Title"), bs.Heading(2).Append("Section"))},
+		{"labelled input", bs.Form().Append(bs.Label("Name").Append(bs.Input("name")))},
+		{"labelled number input", bs.Form().Append(bs.Label("Count").Append(bs.NumberInput("count")))},
+		{"link", bs.Link("#").Append("Home")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Empty(t, lint.Check(tt.component.Element()))
+		})
+	}
+}
+
+func TestLint_ComponentProblems(t *testing.T) {
+	tests := []struct {
+		name      string
+		component dom.Component
+		rule      string
+	}{
+		{"empty button", bs.Button(bs.PRIMARY), lint.RuleButtonName},
+		{"image without alt", bs.Image("photo.jpg"), lint.RuleImageAlt},
+		{"skipped heading", bs.Container().Append(bs.Heading(1).Append("Title"), bs.Heading(3).Append("Section")), lint.RuleHeadingOrder},
+		{"input without label", bs.Form().Append(bs.Input("name")), lint.RuleInputLabel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics := lint.Check(tt.component.Element())
+			if assert.Len(t, diagnostics, 1) {
+				assert.Equal(t, tt.rule, diagnostics[0].Rule)
+			}
+		})
+	}
+}
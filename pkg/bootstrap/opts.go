@@ -173,7 +173,7 @@ func (component *component) applyTo(root Element, opts ...Opt) error {
 	}
 
 	// Set data-component attribute second (for consistency in output)
-	root.SetAttribute("data-component", string(component.name))
+	root.Dataset().Set("component", string(component.name))
 
 	// Set ID if provided
 	if opt.id != "" {
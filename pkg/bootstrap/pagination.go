@@ -100,15 +100,7 @@ func (p *paginationItem) Link() Element {
 // Only accepts *paginationItem - use PaginationItem() to create items
 // Panics if a non-PaginationItem type is passed
 func (p *pagination) Append(children ...any) Component {
-	for _, child := range children {
-		// Only accept PaginationItem components
-		item, ok := child.(*paginationItem)
-		if !ok {
-			panic("Pagination.Append only accepts *paginationItem - use PaginationItem() to create items")
-		}
-		p.body.AppendChild(item.Element())
-	}
-
+	p.body.AppendChild(p.fragment("Append", children...))
 	return p
 }
 
@@ -116,23 +108,23 @@ func (p *pagination) Append(children ...any) Component {
 // Only accepts *paginationItem - use PaginationItem() to create items
 // Panics if a non-PaginationItem type is passed
 func (p *pagination) Insert(children ...any) Component {
-	firstChild := p.body.FirstChild()
+	p.body.InsertBefore(p.fragment("Insert", children...), p.body.FirstChild())
+	return p
+}
 
+// fragment returns the elements of PaginationItem components in a fragment,
+// and panics if a non-PaginationItem type is passed
+func (p *pagination) fragment(method string, children ...any) DocumentFragment {
+	fragment := dom.GetWindow().Document().CreateDocumentFragment()
 	for _, child := range children {
 		// Only accept PaginationItem components
 		item, ok := child.(*paginationItem)
 		if !ok {
-			panic("Pagination.Insert only accepts *paginationItem - use PaginationItem() to create items")
-		}
-
-		if firstChild != nil {
-			p.body.InsertBefore(item.Element(), firstChild)
-		} else {
-			p.body.AppendChild(item.Element())
+			panic("Pagination." + method + " only accepts *paginationItem - use PaginationItem() to create items")
 		}
+		fragment.AppendChild(item.Element())
 	}
-
-	return p
+	return fragment
 }
 
 // Active marks specified pagination items as active by adding the "active" class
@@ -132,6 +132,49 @@ func Row(children ...any) *tableRow {
 	return &tableRow{component: *c}
 }
 
+// TemplateRow creates a table row from the TR element in the content of a
+// template, where the children of each element with a data-slot attribute
+// are replaced with the value for the slot. Values can be strings,
+// Components or Nodes. Panics if the template does not contain a row.
+//
+// Example:
+//
+//	template := doc.QuerySelector("template#employee").(HTMLTemplateElement)
+//	table.Append(
+//	    TemplateRow(template, map[string]any{"name": "John", "status": Badge().Append("Active")}),
+//	)
+func TemplateRow(template HTMLTemplateElement, slots map[string]any) *tableRow {
+	// Insert components as their elements
+	values := make(map[string]any, len(slots))
+	for key, value := range slots {
+		if component, ok := value.(Component); ok {
+			values[key] = component.Element()
+		} else {
+			values[key] = value
+		}
+	}
+
+	// Stamp out the template and find the row
+	var tr Element
+	for _, child := range dom.Instantiate(template, values).Children() {
+		if child.TagName() == "TR" {
+			tr = child
+			break
+		}
+	}
+	if tr == nil {
+		panic("TemplateRow() requires a template which contains a TR element")
+	}
+
+	// Use newComponent and applyTo to set data-component attribute
+	c := newComponent(TableRowComponent, tr)
+	if err := c.applyTo(tr); err != nil {
+		panic(err)
+	}
+
+	return &tableRow{component: *c}
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // TABLE ROW METHODS
 
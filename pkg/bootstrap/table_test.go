@@ -4,6 +4,8 @@ import (
 	"strings"
 	"testing"
 
+	dom "github.com/djthorpe/go-wasmbuild/pkg/dom"
+
 	. "github.com/djthorpe/go-wasmbuild"
 )
 
@@ -238,6 +240,52 @@ func TestTable_WithRows(t *testing.T) {
 	}
 }
 
+func TestTable_TemplateRow(t *testing.T) {
+	div := dom.GetWindow().Document().CreateElement("DIV")
+	div.SetInnerHTML(`<template><tr><td data-slot="name">-</td><td data-slot="status"></td></tr></template>`)
+	template := div.FirstElementChild().(HTMLTemplateElement)
+
+	table := Table()
+	table.Append(
+		TemplateRow(template, map[string]any{"name": "John", "status": Badge().Append("Active")}),
+		TemplateRow(template, nil),
+	)
+	if count := table.Count(); count != 2 {
+		t.Fatalf("Expected 2 rows, got %d", count)
+	}
+
+	// Slots are replaced with the values, and left unchanged without a value
+	rows := table.Element().QuerySelectorAll("tbody tr")
+	if name := rows[0].FirstElementChild().TextContent(); name != "John" {
+		t.Errorf("Expected 'John', got '%s'", name)
+	}
+	if badge := rows[0].LastElementChild().FirstElementChild(); badge == nil || badge.TextContent() != "Active" {
+		t.Errorf("Expected a badge in the status cell")
+	}
+	if name := rows[1].FirstElementChild().TextContent(); name != "-" {
+		t.Errorf("Expected '-', got '%s'", name)
+	}
+	if component := rows[1].GetAttribute("data-component"); component != "table-row" {
+		t.Errorf("Expected data-component 'table-row', got '%s'", component)
+	}
+
+	// The template is not modified
+	if html := template.InnerHTML(); html != `<tr><td data-slot="name">-</td><td data-slot="status"></td></tr>` {
+		t.Errorf("Expected template to be unchanged, got '%s'", html)
+	}
+}
+
+func TestTable_TemplateRowInvalid(t *testing.T) {
+	template := dom.GetWindow().Document().CreateElement("TEMPLATE").(HTMLTemplateElement)
+	template.SetInnerHTML(`<p>not a row</p>`)
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for a template without a row")
+		}
+	}()
+	TemplateRow(template, nil)
+}
+
 func TestTable_AppendInvalidComponent(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
@@ -0,0 +1,60 @@
+package bs_test
+
+import (
+	"testing"
+
+	// Packages
+	"github.com/djthorpe/go-wasmbuild/pkg/bs"
+	"github.com/djthorpe/go-wasmbuild/pkg/lint"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-wasmbuild/pkg/mvc"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// LINT TESTS
+
+func TestLintViews(t *testing.T) {
+	tests := []struct {
+		name string
+		view View
+	}{
+		{"button", bs.Button().Append("OK")},
+		{"outline button", bs.OutlineButton().Append("Cancel")},
+		{"button group", bs.ButtonGroup(bs.WithAriaLabel("Actions")).Append(bs.Button().Append("One"), bs.Button().Append("Two"))},
+		{"icon button", bs.Button(bs.WithAriaLabel("Settings")).Append(bs.Icon("gear"))},
+		{"image", bs.Image("photo.jpg", bs.WithAlt("Photo"))},
+		{"rounded image", bs.RoundedImage("photo.jpg", bs.WithAlt(""))},
+		{"headings", bs.Container().Append(bs.Heading(1).Append("Title"), bs.Heading(2).Append("Section"))},
+		{"link", bs.Link("#").Append("Home")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diagnostics := lint.Check(tt.view.Root()); len(diagnostics) != 0 {
+				t.Errorf("lint.Check() = %v, want no diagnostics", diagnostics)
+			}
+		})
+	}
+}
+
+func TestLintViewProblems(t *testing.T) {
+	tests := []struct {
+		name string
+		view View
+		rule string
+	}{
+		{"empty button", bs.Button(), lint.RuleButtonName},
+		{"icon button", bs.Button().Append(bs.Icon("gear")), lint.RuleButtonName},
+		{"image without alt", bs.Image("photo.jpg"), lint.RuleImageAlt},
+		{"skipped heading", bs.Container().Append(bs.Heading(2).Append("Title"), bs.Heading(4).Append("Section")), lint.RuleHeadingOrder},
+		{"duplicate id", bs.Container().Append(bs.Link("#one", WithID("a")).Append("One"), bs.Link("#two", WithID("a")).Append("Two")), lint.RuleDuplicateID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics := lint.Check(tt.view.Root())
+			if len(diagnostics) != 1 || diagnostics[0].Rule != tt.rule {
+				t.Errorf("lint.Check() = %v, want one %q diagnostic", diagnostics, tt.rule)
+			}
+		})
+	}
+}
@@ -4,9 +4,6 @@ package dom
 
 import (
 	"fmt"
-	"html"
-	"io"
-	"strconv"
 	"strings"
 
 	dom "github.com/djthorpe/go-wasmbuild"
@@ -39,12 +36,34 @@ func (this *attr) Name() string {
 	return this.name
 }
 
+func (this *attr) NamespaceURI() string {
+	return this.namespace
+}
+
+func (this *attr) Prefix() string {
+	prefix, _ := splitName(this.name)
+	return prefix
+}
+
+func (this *attr) LocalName() string {
+	_, local := splitName(this.name)
+	return local
+}
+
 func (this *attr) Value() string {
 	return this.cdata
 }
 
 func (this *attr) SetValue(cdata string) {
+	checkEventLoop()
+	old := this.cdata
 	this.cdata = cdata
+
+	// Sync the owner element
+	if elem := elementOf(this.parent); elem != nil {
+		elem.syncAttribute(this.name, old, cdata)
+		queueMutation(mutationRecord{kind: mutationAttributes, target: this.parent, attributeName: this.name, oldValue: old})
+	}
 }
 
 func (this *attr) OwnerElement() dom.Element {
@@ -55,7 +74,7 @@ func (this *attr) OwnerElement() dom.Element {
 // PUBLIC METHODS
 
 func (this *attr) CloneNode(bool) dom.Node {
-	return NewNode(this.document, this.name, this.nodetype, this.cdata)
+	return newNode(this.document, this.namespace, this.name, this.nodetype, this.cdata)
 }
 
 // Child manipulation methods are no-ops for attribute nodes (leaf nodes)
@@ -79,7 +98,3 @@ func (this *attr) ReplaceChild(dom.Node, dom.Node) {
 func (this *attr) v() *node {
 	return this.node
 }
-
-func (this *attr) write(w io.Writer) (int, error) {
-	return w.Write([]byte(this.name + "=" + strconv.Quote(html.EscapeString(this.cdata))))
-}
@@ -20,8 +20,8 @@ func Test_Attr_001(t *testing.T) {
 		{"name", "test", `name="test"`},
 		{"name", "&", `name="&amp;"`},
 		{"name", "<test>", `name="&lt;test&gt;"`},
-		{"name", `"test"`, `name="&#34;test&#34;"`},
-		{"name", `'test'`, `name="&#39;test&#39;"`},
+		{"name", `"test"`, `name="&quot;test&quot;"`},
+		{"name", `'test'`, `name="'test'"`},
 	}
 	for _, test := range tests {
 		attr := doc.CreateAttribute(test.name)
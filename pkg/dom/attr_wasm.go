@@ -33,6 +33,18 @@ func (this *attr) Name() string {
 	return this.Get("name").String()
 }
 
+func (this *attr) NamespaceURI() string {
+	return nullString(this.Get("namespaceURI"))
+}
+
+func (this *attr) Prefix() string {
+	return nullString(this.Get("prefix"))
+}
+
+func (this *attr) LocalName() string {
+	return this.Get("localName").String()
+}
+
 func (this *attr) Value() string {
 	return this.Get("value").String()
 }
@@ -4,8 +4,6 @@ package dom
 
 import (
 	"fmt"
-	"html"
-	"io"
 	"strings"
 
 	dom "github.com/djthorpe/go-wasmbuild"
@@ -18,14 +16,6 @@ type comment struct {
 	*node
 }
 
-/////////////////////////////////////////////////////////////////////
-// GLOBALS
-
-var (
-	startcomment = []byte("<!--")
-	endcomment   = []byte("-->")
-)
-
 ///////////////////////////////////////////////////////////////////////////////
 // STRINGIFY
 
@@ -45,12 +35,16 @@ func (this *comment) Data() string {
 }
 
 func (this *comment) Length() int {
-	return len(this.cdata)
+	return dataLength(this.cdata)
 }
 
 /////////////////////////////////////////////////////////////////////
 // PUBLIC METHODS
 
+func (this *comment) SetData(data string) {
+	this.setData(data)
+}
+
 func (this *comment) CloneNode(bool) dom.Node {
 	return NewNode(this.document, this.name, this.nodetype, this.cdata)
 }
@@ -76,23 +70,3 @@ func (this *comment) ReplaceChild(dom.Node, dom.Node) {
 func (this *comment) v() *node {
 	return this.node
 }
-
-func (this *comment) write(w io.Writer) (int, error) {
-	s := 0
-	if n, err := w.Write(startcomment); err != nil {
-		return 0, err
-	} else {
-		s += n
-	}
-	if n, err := w.Write([]byte(html.EscapeString(this.cdata))); err != nil {
-		return 0, err
-	} else {
-		s += n
-	}
-	if n, err := w.Write(endcomment); err != nil {
-		return 0, err
-	} else {
-		s += n
-	}
-	return s, nil
-}
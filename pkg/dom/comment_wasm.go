@@ -34,6 +34,13 @@ func (this *comment) Length() int {
 	return this.Get("length").Int()
 }
 
+/////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (this *comment) SetData(data string) {
+	this.Set("data", data)
+}
+
 /////////////////////////////////////////////////////////////////////
 // PRIVATE METHODS
 
@@ -0,0 +1,76 @@
+package dom_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare_IsEqualNode(t *testing.T) {
+	a := newTree(`<p class="a" id="x">text<!--c--><b>b</b></p>`)
+	b := newTree(`<p id="x" class="a">text<!--c--><b>b</b></p>`)
+
+	// Attribute order does not matter, but names, values and data do
+	assert.True(t, a.IsEqualNode(b))
+	assert.False(t, a.Equals(b))
+	assert.True(t, a.IsEqualNode(a.CloneNode(true)))
+	assert.False(t, a.IsEqualNode(a.CloneNode(false)))
+	assert.False(t, a.IsEqualNode(nil))
+
+	b.QuerySelector("b").SetAttribute("title", "b")
+	assert.False(t, a.IsEqualNode(b))
+	assert.False(t, a.IsEqualNode(newTree(`<p class="a" id="x">text<!--d--><b>b</b></p>`)))
+	assert.False(t, a.IsEqualNode(newTree(`<p class="a" id="y">text<!--c--><b>b</b></p>`)))
+	assert.False(t, a.IsEqualNode(newTree(`<div class="a" id="x">text<!--c--><b>b</b></div>`)))
+}
+
+func TestCompare_CompareDocumentPosition(t *testing.T) {
+	div := newTree(`<p><b></b></p><i></i>`)
+	p, b, i := div.QuerySelector("p"), div.QuerySelector("b"), div.QuerySelector("i")
+
+	assert.Equal(t, dom.DocumentPosition(0), p.CompareDocumentPosition(p))
+	assert.Equal(t, dom.DOCUMENT_POSITION_FOLLOWING, p.CompareDocumentPosition(i))
+	assert.Equal(t, dom.DOCUMENT_POSITION_PRECEDING, i.CompareDocumentPosition(b))
+	assert.Equal(t, dom.DOCUMENT_POSITION_CONTAINED_BY|dom.DOCUMENT_POSITION_FOLLOWING, p.CompareDocumentPosition(b))
+	assert.Equal(t, dom.DOCUMENT_POSITION_CONTAINS|dom.DOCUMENT_POSITION_PRECEDING, b.CompareDocumentPosition(div))
+
+	// Nodes in different trees are disconnected, and ordered consistently
+	other := domPkg.GetWindow().Document().CreateElement("div")
+	a, z := p.CompareDocumentPosition(other), other.CompareDocumentPosition(p)
+	assert.NotZero(t, a&dom.DOCUMENT_POSITION_DISCONNECTED)
+	assert.NotZero(t, a&dom.DOCUMENT_POSITION_IMPLEMENTATION_SPECIFIC)
+	assert.NotEqual(t, a&dom.DOCUMENT_POSITION_PRECEDING, z&dom.DOCUMENT_POSITION_PRECEDING)
+}
+
+func TestCompare_GetRootNode(t *testing.T) {
+	div := newTree(`<p><b></b></p>`)
+	assert.True(t, div.Equals(div.QuerySelector("b").GetRootNode()))
+	assert.True(t, div.Equals(div.GetRootNode()))
+
+	doc := domPkg.GetWindow().Document()
+	assert.True(t, doc.Equals(doc.Body().GetRootNode()))
+}
+
+func TestCompare_Normalize(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	div := doc.CreateElement("div")
+	div.AppendChild(doc.CreateTextNode("a"))
+	div.AppendChild(doc.CreateTextNode(""))
+	div.AppendChild(doc.CreateTextNode("b"))
+	span := div.AppendChild(doc.CreateElement("span")).(dom.Element)
+	span.AppendChild(doc.CreateTextNode("c"))
+	span.AppendChild(doc.CreateTextNode("d"))
+	div.AppendChild(doc.CreateTextNode(""))
+
+	div.Normalize()
+	if assert.Len(t, div.ChildNodes(), 2) {
+		assert.Equal(t, "ab", div.FirstChild().TextContent())
+	}
+	if assert.Len(t, span.ChildNodes(), 1) {
+		assert.Equal(t, "cd", span.FirstChild().TextContent())
+	}
+	assert.Equal(t, "<span>cd</span>", div.InnerHTML()[2:])
+}
@@ -0,0 +1,210 @@
+//go:build !js
+
+package dom
+
+import (
+	"regexp"
+	"slices"
+	"sync"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// TYPES
+
+// customElementRegistry holds the custom element definitions of a window,
+// which are used for the elements in the window document. Documents which
+// are not displayed in a window have no registry.
+type customElementRegistry struct {
+	sync.RWMutex
+	window      *window
+	definitions map[string]*customElementDefinition
+}
+
+type customElementDefinition struct {
+	constructor func(dom.Element) dom.CustomElement
+	observed    []string
+}
+
+var _ dom.CustomElementRegistry = (*customElementRegistry)(nil)
+
+/////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+var (
+	// A lowercase name which starts with a letter and contains a hyphen
+	reCustomElementName = regexp.MustCompile(`^[a-z][-._0-9a-z\x{B7}\x{C0}-\x{10FFFF}]*-[-._0-9a-z\x{B7}\x{C0}-\x{10FFFF}]*$`)
+
+	// Names with a hyphen which are used by SVG and MathML elements
+	reservedElementNames = []string{
+		"annotation-xml", "color-profile", "font-face", "font-face-src",
+		"font-face-uri", "font-face-format", "font-face-name", "missing-glyph",
+	}
+)
+
+/////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+func newCustomElementRegistry(window *window) *customElementRegistry {
+	return &customElementRegistry{
+		window:      window,
+		definitions: make(map[string]*customElementDefinition),
+	}
+}
+
+/////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+// CustomElements returns the custom element registry of the window
+func (this *window) CustomElements() dom.CustomElementRegistry {
+	return this.custom
+}
+
+/////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Define registers a constructor for a name, and upgrades the elements in
+// the window document with the name
+func (this *customElementRegistry) Define(name string, constructor func(dom.Element) dom.CustomElement, observedAttributes ...string) {
+	if constructor == nil {
+		panic(dom.ErrBadParameter.With("constructor is nil"))
+	}
+	if !reCustomElementName.MatchString(name) || slices.Contains(reservedElementNames, name) {
+		panic(dom.ErrBadParameter.Withf("invalid custom element name %q", name))
+	}
+	this.Lock()
+	if _, exists := this.definitions[name]; exists {
+		this.Unlock()
+		panic(dom.ErrDuplicateEntry.Withf("custom element %q is already defined", name))
+	}
+	this.definitions[name] = &customElementDefinition{constructor, slices.Clone(observedAttributes)}
+	this.Unlock()
+
+	// Upgrade the elements, without holding the lock, as the callbacks can
+	// define other elements
+	this.Upgrade(this.window.Document())
+}
+
+func (this *customElementRegistry) IsDefined(name string) bool {
+	return this.definition(name) != nil
+}
+
+// Upgrade upgrades the elements in the tree of root, including shadow
+// trees, which are defined and have not been upgraded
+func (this *customElementRegistry) Upgrade(root dom.Node) {
+	if root == nil {
+		return
+	}
+	for _, elem := range shadowIncludingElements(root) {
+		this.upgrade(elem)
+	}
+}
+
+func (this *customElementRegistry) Instance(elem dom.Element) dom.CustomElement {
+	if elem := elementOf(elem); elem != nil {
+		return elem.custom
+	}
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// customElementsOf returns the registry for the elements of a document,
+// or nil when the document is not displayed in a window
+func customElementsOf(doc dom.Document) *customElementRegistry {
+	if doc, ok := doc.(*document); ok && doc.window != nil {
+		return doc.window.custom
+	}
+	return nil
+}
+
+// definition returns the definition for a name, or nil
+func (this *customElementRegistry) definition(name string) *customElementDefinition {
+	this.RLock()
+	defer this.RUnlock()
+	return this.definitions[name]
+}
+
+// defined returns true if any names have been defined
+func (this *customElementRegistry) defined() bool {
+	this.RLock()
+	defer this.RUnlock()
+	return len(this.definitions) > 0
+}
+
+// upgrade calls the constructor for an element which is defined, then
+// the attribute changed callback for each observed attribute and the
+// connected callback when the element is connected
+func (this *customElementRegistry) upgrade(elem *element) {
+	if this == nil || elem.custom != nil || elem.namespace != dom.NS_HTML {
+		return
+	}
+	definition := this.definition(elem.LocalName())
+	if definition == nil {
+		return
+	}
+	if elem.custom = definition.constructor(elem.domElement()); elem.custom == nil {
+		return
+	}
+	if callback, ok := elem.custom.(dom.AttributeChangedCallback); ok {
+		for _, name := range definition.observed {
+			if elem.HasAttribute(name) {
+				callback.AttributeChangedCallback(name, "", elem.GetAttribute(name))
+			}
+		}
+	}
+	if callback, ok := elem.custom.(dom.ConnectedCallback); ok && elem.IsConnected() {
+		callback.ConnectedCallback()
+	}
+}
+
+// attributeChanged calls the attribute changed callback of an upgraded
+// element when an observed attribute changes
+func (this *customElementRegistry) attributeChanged(elem *element, name, old, value string) {
+	callback, ok := elem.custom.(dom.AttributeChangedCallback)
+	if this == nil || !ok {
+		return
+	}
+	if definition := this.definition(elem.LocalName()); definition != nil && slices.Contains(definition.observed, name) {
+		callback.AttributeChangedCallback(name, old, value)
+	}
+}
+
+// connected calls the connected or disconnected callbacks of upgraded
+// elements in the trees of nodes, after they are inserted into or removed
+// from parent, when parent is connected
+func (this *customElementRegistry) connected(parent dom.Node, nodes []dom.Node, connected bool) {
+	if this == nil || len(nodes) == 0 || !parent.IsConnected() || !this.defined() {
+		return
+	}
+	for _, n := range nodes {
+		for _, elem := range shadowIncludingElements(n) {
+			if connected {
+				if callback, ok := elem.custom.(dom.ConnectedCallback); ok {
+					callback.ConnectedCallback()
+				}
+			} else if callback, ok := elem.custom.(dom.DisconnectedCallback); ok {
+				callback.DisconnectedCallback()
+			}
+		}
+	}
+}
+
+// shadowIncludingElements returns the inclusive descendant elements of a
+// node in tree order, including the elements in shadow trees
+func shadowIncludingElements(n dom.Node) []*element {
+	var result []*element
+	if elem := elementOf(n); elem != nil {
+		result = append(result, elem)
+		if elem.shadow != nil {
+			result = append(result, shadowIncludingElements(elem.shadow)...)
+		}
+	}
+	for _, child := range getNode(n).children {
+		result = append(result, shadowIncludingElements(child)...)
+	}
+	return result
+}
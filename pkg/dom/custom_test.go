@@ -0,0 +1,161 @@
+package dom_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+// employeeTable is a custom element which records its callbacks
+type employeeTable struct {
+	elem   dom.Element
+	events []string
+}
+
+func (e *employeeTable) Element() dom.Element {
+	return e.elem
+}
+
+func (e *employeeTable) ConnectedCallback() {
+	e.events = append(e.events, "connected")
+}
+
+func (e *employeeTable) DisconnectedCallback() {
+	e.events = append(e.events, "disconnected")
+}
+
+func (e *employeeTable) AttributeChangedCallback(name, old, value string) {
+	e.events = append(e.events, fmt.Sprintf("%s:%q>%q", name, old, value))
+}
+
+// defineEmployeeTable defines a custom element in a window which observes
+// the page attribute
+func defineEmployeeTable(window dom.Window, name string) {
+	window.CustomElements().Define(name, func(elem dom.Element) dom.CustomElement {
+		return &employeeTable{elem: elem}
+	}, "page")
+}
+
+func instanceOf(t *testing.T, window dom.Window, elem dom.Element) *employeeTable {
+	t.Helper()
+	instance, ok := window.CustomElements().Instance(elem).(*employeeTable)
+	if !ok {
+		t.Fatalf("not a custom element: %v", elem)
+	}
+	return instance
+}
+
+func TestCustomElement_Define(t *testing.T) {
+	window := domPkg.GetWindowWithTitle("")
+	registry := window.CustomElements()
+	assert.False(t, registry.IsDefined("employee-table"))
+	defineEmployeeTable(window, "employee-table")
+	assert.True(t, registry.IsDefined("employee-table"))
+
+	// Names must contain a hyphen, be lowercase and not be reserved
+	for _, name := range []string{"employee", "Employee-Table", "1-table", "font-face"} {
+		assert.Panics(t, func() { defineEmployeeTable(window, name) }, name)
+	}
+	assert.Panics(t, func() { defineEmployeeTable(window, "employee-table") })
+
+	// Each window has its own registry
+	other := domPkg.GetWindowWithTitle("")
+	assert.False(t, other.CustomElements().IsDefined("employee-table"))
+	assert.Nil(t, other.CustomElements().Instance(other.Document().CreateElement("employee-table")))
+	assert.NotNil(t, registry.Instance(window.Document().CreateElement("employee-table")))
+}
+
+func TestCustomElement_Callbacks(t *testing.T) {
+	window := domPkg.GetWindowWithTitle("")
+	defineEmployeeTable(window, "employee-list")
+	doc := window.Document()
+
+	// Elements are upgraded when they are created
+	elem := doc.CreateElement("employee-list")
+	instance := instanceOf(t, window, elem)
+	assert.True(t, elem.Equals(instance.Element()))
+	assert.Empty(t, instance.events)
+
+	// Only observed attributes are passed to the callback
+	elem.SetAttribute("page", "1")
+	elem.SetAttribute("title", "Employees")
+	elem.SetAttribute("page", "2")
+	elem.RemoveAttribute("page")
+	assert.Equal(t, []string{`page:"">"1"`, `page:"1">"2"`, `page:"2">""`}, instance.events)
+
+	// Connected and disconnected callbacks, including for descendants
+	instance.events = nil
+	div := doc.CreateElement("div")
+	div.AppendChild(elem)
+	assert.Empty(t, instance.events)
+	doc.Body().AppendChild(div)
+	div.Remove()
+	assert.Equal(t, []string{"connected", "disconnected"}, instance.events)
+
+	// Elements in a connected shadow tree are connected
+	instance.events = nil
+	host := doc.CreateElement("div")
+	host.AttachShadow(dom.SHADOW_ROOT_OPEN).AppendChild(elem)
+	doc.Body().AppendChild(host)
+	host.Remove()
+	assert.Equal(t, []string{"connected", "disconnected"}, instance.events)
+}
+
+func TestCustomElement_Parse(t *testing.T) {
+	window := domPkg.GetWindowWithTitle("")
+	defineEmployeeTable(window, "employee-grid")
+
+	// Parsed elements are upgraded, and observed attributes are passed to
+	// the callback
+	div := window.Document().CreateElement("div")
+	div.SetInnerHTML(`<employee-grid page="3"></employee-grid><other-grid></other-grid>`)
+	instance := instanceOf(t, window, div.FirstElementChild())
+	assert.Equal(t, []string{`page:"">"3"`}, instance.events)
+	assert.Nil(t, window.CustomElements().Instance(div.LastElementChild()))
+}
+
+func TestCustomElement_Upgrade(t *testing.T) {
+	window := domPkg.GetWindowWithTitle("")
+	doc := window.Document()
+	connected := doc.CreateElement("employee-card")
+	connected.SetAttribute("page", "1")
+	doc.Body().AppendChild(connected)
+	defer connected.Remove()
+	disconnected := doc.CreateElement("employee-card")
+	assert.Nil(t, window.CustomElements().Instance(connected))
+
+	// Elements in the document are upgraded when the name is defined
+	defineEmployeeTable(window, "employee-card")
+	assert.Equal(t, []string{`page:"">"1"`, "connected"}, instanceOf(t, window, connected).events)
+	assert.Nil(t, window.CustomElements().Instance(disconnected))
+
+	// Other elements are upgraded explicitly
+	window.CustomElements().Upgrade(disconnected)
+	assert.Empty(t, instanceOf(t, window, disconnected).events)
+}
+
+func TestCustomElement_Goroutines(t *testing.T) {
+	window := domPkg.GetWindowWithTitle("")
+	registry := window.CustomElements()
+
+	// Names can be defined and looked up from several goroutines
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := fmt.Sprintf("employee-%d", i)
+			defineEmployeeTable(window, name)
+			assert.True(t, registry.IsDefined(name))
+		}()
+	}
+	wg.Wait()
+	for i := range 8 {
+		assert.True(t, registry.IsDefined(fmt.Sprintf("employee-%d", i)))
+	}
+}
@@ -0,0 +1,171 @@
+//go:build js
+
+package dom
+
+import (
+	"strings"
+	"syscall/js"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	jsutil "github.com/djthorpe/go-wasmbuild/pkg/js"
+)
+
+/////////////////////////////////////////////////////////////////////
+// TYPES
+
+// customElementRegistry defines custom elements with classes which call
+// the Go callbacks. The Go value for an element is created by the first
+// callback, or when the value is requested, and is released when the
+// element is disconnected and not connected again in the same task.
+type customElementRegistry struct {
+	js.Value
+	definitions map[string]*customElementDefinition
+	instances   map[int]dom.CustomElement
+	next        int
+}
+
+type customElementDefinition struct {
+	class       *jsutil.Class
+	constructor func(dom.Element) dom.CustomElement
+	callbacks   []*jsutil.Function
+}
+
+var _ dom.CustomElementRegistry = (*customElementRegistry)(nil)
+
+/////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	// The property which holds the key of the Go value for an element
+	customElementKey = "__wasmbuild"
+)
+
+var (
+	customElements = &customElementRegistry{
+		Value:       js.Global().Get("customElements"),
+		definitions: make(map[string]*customElementDefinition),
+		instances:   make(map[int]dom.CustomElement),
+	}
+)
+
+/////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (this *window) CustomElements() dom.CustomElementRegistry {
+	return customElements
+}
+
+/////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Define creates a class which extends HTMLElement and calls the Go
+// callbacks, and defines it for the name
+func (this *customElementRegistry) Define(name string, constructor func(dom.Element) dom.CustomElement, observedAttributes ...string) {
+	if constructor == nil {
+		panic(dom.ErrBadParameter.With("constructor is nil"))
+	}
+	if _, exists := this.definitions[name]; exists {
+		panic(dom.ErrDuplicateEntry.Withf("custom element %q is already defined", name))
+	}
+	class := jsutil.NewClass(customElementClassName(name), jsutil.GetClass("HTMLElement"))
+	if class == nil {
+		panic(dom.ErrBadParameter.Withf("invalid custom element name %q", name))
+	}
+	observed := make([]any, 0, len(observedAttributes))
+	for _, attr := range observedAttributes {
+		observed = append(observed, attr)
+	}
+	class.Value().Set("observedAttributes", observed)
+
+	// Set the callbacks on the prototype
+	definition := &customElementDefinition{class: class, constructor: constructor}
+	definition.callbacks = []*jsutil.Function{
+		class.NewFunction("connectedCallback", func(self jsutil.Value, _ []jsutil.Value) any {
+			if callback, ok := this.instance(self).(dom.ConnectedCallback); ok {
+				callback.ConnectedCallback()
+			}
+			return nil
+		}),
+		class.NewFunction("disconnectedCallback", func(self jsutil.Value, _ []jsutil.Value) any {
+			if callback, ok := this.instance(self).(dom.DisconnectedCallback); ok {
+				callback.DisconnectedCallback()
+			}
+			// Release the value later, so it is kept when the element is moved
+			GetWindow().Dispatch(func() {
+				if !self.Get("isConnected").Bool() {
+					this.release(self)
+				}
+			})
+			return nil
+		}),
+		class.NewFunction("attributeChangedCallback", func(self jsutil.Value, args []jsutil.Value) any {
+			if callback, ok := this.instance(self).(dom.AttributeChangedCallback); ok {
+				callback.AttributeChangedCallback(args[0].String(), nullString(args[1]), nullString(args[2]))
+			}
+			return nil
+		}),
+	}
+	this.definitions[name] = definition
+	this.Call("define", name, class.Value())
+}
+
+func (this *customElementRegistry) IsDefined(name string) bool {
+	return !this.Call("get", name).IsUndefined()
+}
+
+func (this *customElementRegistry) Upgrade(root dom.Node) {
+	if root != nil {
+		this.Call("upgrade", toJSValue(root))
+	}
+}
+
+// Instance returns the value for an element with a Go definition, creating
+// it if there are no callbacks yet
+func (this *customElementRegistry) Instance(elem dom.Element) dom.CustomElement {
+	if elem == nil {
+		return nil
+	}
+	return this.instance(toJSValue(elem))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// instance returns the Go value for an element, creating it when the
+// element is an instance of a Go definition
+func (this *customElementRegistry) instance(v js.Value) dom.CustomElement {
+	if key := v.Get(customElementKey); !key.IsUndefined() {
+		return this.instances[key.Int()]
+	}
+	definition, exists := this.definitions[strings.ToLower(v.Get("localName").String())]
+	if !exists || !v.InstanceOf(definition.class.Value()) {
+		return nil
+	}
+	this.next++
+	key := this.next
+	v.Set(customElementKey, key)
+	this.instances[key] = definition.constructor(NewNode(v).(dom.Element))
+	return this.instances[key]
+}
+
+// release removes the Go value for an element, so it can be garbage
+// collected
+func (this *customElementRegistry) release(v js.Value) {
+	if key := v.Get(customElementKey); !key.IsUndefined() {
+		delete(this.instances, key.Int())
+		v.Delete(customElementKey)
+	}
+}
+
+// customElementClassName returns a class name for a custom element name,
+// so "employee-table" becomes "EmployeeTable"
+func customElementClassName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
@@ -0,0 +1,178 @@
+package dom
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// TYPES
+
+// dataset reads and writes the data-* attributes of an element, so it
+// is always up to date with the attributes
+type dataset struct {
+	elem dom.Element
+}
+
+var _ dom.StringMap = (*dataset)(nil)
+
+/////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	dataPrefix = "data-"
+)
+
+/////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (this *element) Dataset() dom.StringMap {
+	return &dataset{this}
+}
+
+func (this *dataset) Length() int {
+	return len(this.Keys())
+}
+
+// Keys returns the camelCase names of the data-* attributes, sorted
+func (this *dataset) Keys() []string {
+	var keys []string
+	for _, name := range this.elem.GetAttributeNames() {
+		if key, ok := datasetKey(name); ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+/////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (this *dataset) Get(name string) string {
+	return this.elem.GetAttribute(datasetAttr(name))
+}
+
+func (this *dataset) Set(name, value string) {
+	this.elem.SetAttribute(datasetAttr(name), value)
+}
+
+func (this *dataset) Has(name string) bool {
+	return this.elem.HasAttribute(datasetAttr(name))
+}
+
+func (this *dataset) Delete(name string) {
+	this.elem.RemoveAttribute(datasetAttr(name))
+}
+
+func (this *dataset) Int(name string) (int, error) {
+	value, err := this.value(name)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, dom.ErrBadParameter.Withf("%s: %q is not an integer", name, value)
+	}
+	return n, nil
+}
+
+func (this *dataset) SetInt(name string, value int) {
+	this.Set(name, strconv.Itoa(value))
+}
+
+func (this *dataset) Bool(name string) (bool, error) {
+	value, err := this.value(name)
+	if err != nil {
+		return false, err
+	}
+	b, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return false, dom.ErrBadParameter.Withf("%s: %q is not a boolean", name, value)
+	}
+	return b, nil
+}
+
+func (this *dataset) SetBool(name string, value bool) {
+	this.Set(name, strconv.FormatBool(value))
+}
+
+// JSON decodes the JSON-encoded value into v
+func (this *dataset) JSON(name string, v any) error {
+	value, err := this.value(name)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(value), v); err != nil {
+		return dom.ErrBadParameter.Withf("%s: %v", name, err)
+	}
+	return nil
+}
+
+// SetJSON sets the value to the JSON encoding of v
+func (this *dataset) SetJSON(name string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return dom.ErrBadParameter.Withf("%s: %v", name, err)
+	}
+	this.Set(name, string(data))
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// value returns the value of the attribute, or ErrNotFound
+func (this *dataset) value(name string) (string, error) {
+	attr := datasetAttr(name)
+	if !this.elem.HasAttribute(attr) {
+		return "", dom.ErrNotFound.With(name)
+	}
+	return this.elem.GetAttribute(attr), nil
+}
+
+// datasetAttr returns the attribute name for a camelCase name, where each
+// uppercase letter becomes a hyphen and the lowercase letter. It panics if
+// a hyphen is followed by a lowercase letter.
+func datasetAttr(name string) string {
+	var b strings.Builder
+	b.WriteString(dataPrefix)
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '-' && i+1 < len(name) && name[i+1] >= 'a' && name[i+1] <= 'z':
+			panic(dom.ErrBadParameter.Withf("invalid dataset name %q", name))
+		case c >= 'A' && c <= 'Z':
+			b.WriteByte('-')
+			b.WriteByte(c + 'a' - 'A')
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// datasetKey returns the camelCase name for a data-* attribute, where a
+// hyphen followed by a lowercase letter becomes the uppercase letter.
+// It returns false for other attributes.
+func datasetKey(attr string) (string, bool) {
+	name, ok := strings.CutPrefix(attr, dataPrefix)
+	if !ok || strings.ContainsFunc(name, func(r rune) bool { return r >= 'A' && r <= 'Z' }) {
+		return "", false
+	}
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		if c := name[i]; c == '-' && i+1 < len(name) && name[i+1] >= 'a' && name[i+1] <= 'z' {
+			b.WriteByte(name[i+1] - 'a' + 'A')
+			i++
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), true
+}
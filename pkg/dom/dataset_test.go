@@ -0,0 +1,75 @@
+package dom_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataset_Names(t *testing.T) {
+	elem := domPkg.GetWindow().Document().CreateElement("div")
+	dataset := elem.Dataset()
+
+	// Uppercase letters become a hyphen and the lowercase letter
+	dataset.Set("fooBar", "1")
+	assert.Equal(t, "1", elem.GetAttribute("data-foo-bar"))
+	assert.Equal(t, "1", dataset.Get("fooBar"))
+	assert.True(t, dataset.Has("fooBar"))
+
+	// Attributes are reflected as camelCase names
+	elem.SetAttribute("data-component", "button")
+	elem.SetAttribute("data-bs-toggle", "collapse")
+	elem.SetAttribute("id", "x")
+	assert.Equal(t, []string{"bsToggle", "component", "fooBar"}, dataset.Keys())
+	assert.Equal(t, 3, dataset.Length())
+	assert.Equal(t, "collapse", dataset.Get("bsToggle"))
+
+	dataset.Delete("fooBar")
+	assert.False(t, elem.HasAttribute("data-foo-bar"))
+	assert.False(t, dataset.Has("fooBar"))
+	assert.Equal(t, "", dataset.Get("fooBar"))
+
+	// A hyphen before a lowercase letter is not a valid name
+	assert.Panics(t, func() { dataset.Set("foo-bar", "1") })
+}
+
+func TestDataset_Typed(t *testing.T) {
+	elem := domPkg.GetWindow().Document().CreateElement("div")
+	dataset := elem.Dataset()
+
+	_, err := dataset.Int("count")
+	assert.ErrorIs(t, err, dom.ErrNotFound)
+
+	dataset.SetInt("count", 42)
+	assert.Equal(t, "42", elem.GetAttribute("data-count"))
+	n, err := dataset.Int("count")
+	assert.NoError(t, err)
+	assert.Equal(t, 42, n)
+
+	dataset.SetBool("open", true)
+	assert.Equal(t, "true", elem.GetAttribute("data-open"))
+	b, err := dataset.Bool("open")
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	dataset.Set("open", "maybe")
+	_, err = dataset.Bool("open")
+	assert.ErrorIs(t, err, dom.ErrBadParameter)
+	_, err = dataset.Int("open")
+	assert.ErrorIs(t, err, dom.ErrBadParameter)
+
+	type state struct {
+		Page  int      `json:"page"`
+		Items []string `json:"items"`
+	}
+	assert.NoError(t, dataset.SetJSON("tableState", state{2, []string{"a", "b"}}))
+	assert.Equal(t, `{"page":2,"items":["a","b"]}`, elem.GetAttribute("data-table-state"))
+
+	var s state
+	assert.NoError(t, dataset.JSON("tableState", &s))
+	assert.Equal(t, state{2, []string{"a", "b"}}, s)
+	assert.ErrorIs(t, dataset.JSON("open", &s), dom.ErrBadParameter)
+}
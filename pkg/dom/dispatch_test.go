@@ -0,0 +1,178 @@
+//go:build !js
+
+package dom_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	"github.com/stretchr/testify/assert"
+)
+
+// onLoop calls a function on the event loop of a window, and waits for it
+// to return
+func onLoop(window dom.Window, fn func()) {
+	done := make(chan struct{})
+	window.Dispatch(func() {
+		defer close(done)
+		fn()
+	})
+	<-done
+}
+
+func TestDispatch_Goroutines(t *testing.T) {
+	window := newFakeWindow(t)
+	var list dom.Element
+	onLoop(window, func() {
+		list = window.Document().CreateElement("ul")
+		window.Document().Body().AppendChild(list)
+	})
+
+	// Mutate the tree from several goroutines, which queue the mutations
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 25 {
+				window.Dispatch(func() {
+					item := window.Document().CreateElement("li")
+					item.SetAttribute("data-index", fmt.Sprint(g*25+i))
+					list.AppendChild(item)
+					if i%5 == 0 {
+						list.FirstElementChild().Remove()
+					}
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The functions are called by the event loop without advancing the
+	// clock, and in order, so they have all been called before this one
+	onLoop(window, func() {
+		assert.Equal(t, 160, list.ChildElementCount())
+		assert.Len(t, window.Document().QuerySelectorAll("li[data-index]"), 160)
+	})
+}
+
+func TestDispatch_Timers(t *testing.T) {
+	window := newFakeWindow(t)
+	var list dom.Element
+	onLoop(window, func() {
+		list = window.Document().CreateElement("ul")
+		window.SetInterval(func() {
+			list.AppendChild(window.Document().CreateElement("li"))
+		}, time.Millisecond)
+	})
+
+	// Timers which are called while the clock is advanced in one goroutine
+	// are not called at the same time as functions dispatched by others
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 50 {
+			window.Advance(time.Millisecond)
+		}
+	}()
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				window.Dispatch(func() {
+					list.AppendChild(window.Document().CreateElement("li"))
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	onLoop(window, func() {
+		assert.Equal(t, 250, list.ChildElementCount())
+	})
+}
+
+func TestDispatch_Order(t *testing.T) {
+	window := newFakeWindow(t)
+	var calls []string
+	window.SetTimeout(func() {
+		calls = append(calls, "timeout")
+		window.Dispatch(func() { calls = append(calls, "dispatched by timeout") })
+	}, time.Millisecond)
+	window.SetTimeout(func() { calls = append(calls, "later timeout") }, 2*time.Millisecond)
+	window.Dispatch(func() {
+		calls = append(calls, "first")
+		window.Dispatch(func() { calls = append(calls, "dispatched by first") })
+	})
+	window.Dispatch(func() { calls = append(calls, "second") })
+
+	// Functions are called in order before the timers, and functions which
+	// are dispatched by a timer are called before the next timer
+	window.Advance(time.Second)
+	assert.Equal(t, []string{"first", "second", "dispatched by first", "timeout", "dispatched by timeout", "later timeout"}, calls)
+	assert.Panics(t, func() { window.Dispatch(nil) })
+}
+
+func TestDispatch_Mutations(t *testing.T) {
+	window := newFakeWindow(t)
+	div := window.Document().CreateElement("div")
+	var records []int
+	window.NewMutationObserver(func(mutations []dom.MutationRecord) {
+		records = append(records, len(mutations))
+	}).Observe(div, map[string]interface{}{"childList": true})
+
+	// Mutation records are delivered after each function
+	go func() {
+		window.Dispatch(func() {
+			div.AppendChild(window.Document().CreateTextNode("a"))
+			div.AppendChild(window.Document().CreateTextNode("b"))
+		})
+		window.Dispatch(func() {
+			div.AppendChild(window.Document().CreateTextNode("c"))
+		})
+	}()
+	assert.Eventually(t, func() bool {
+		var n int
+		onLoop(window, func() { n = len(records) })
+		return n == 2
+	}, time.Second, time.Millisecond)
+	onLoop(window, func() {
+		assert.Equal(t, []int{2, 1}, records)
+	})
+}
+
+func TestDispatch_OffLoop(t *testing.T) {
+	window := newFakeWindow(t)
+	var div dom.Element
+	onLoop(window, func() {
+		div = window.Document().CreateElement("div")
+	})
+
+	// Modifying the DOM from another goroutine while a function is running
+	// on the event loop panics, rather than racing with the function
+	running, release := make(chan struct{}), make(chan struct{})
+	window.Dispatch(func() {
+		div.AppendChild(window.Document().CreateTextNode("a"))
+		close(running)
+		<-release
+		div.SetAttribute("class", "loop")
+	})
+	<-running
+	assert.Panics(t, func() { div.AppendChild(window.Document().CreateTextNode("b")) })
+	assert.Panics(t, func() { div.SetAttribute("id", "off-loop") })
+	assert.Panics(t, func() { div.FirstChild().(dom.Text).SetData("c") })
+	close(release)
+
+	// The DOM is only modified by the event loop
+	onLoop(window, func() {
+		assert.Equal(t, "a", div.TextContent())
+		assert.Equal(t, "loop", div.GetAttribute("class"))
+		assert.False(t, div.HasAttribute("id"))
+	})
+}
@@ -4,8 +4,6 @@ package dom
 
 import (
 	"fmt"
-	"io"
-	"strconv"
 	"strings"
 
 	dom "github.com/djthorpe/go-wasmbuild"
@@ -20,14 +18,6 @@ type doctype struct {
 	systemid string
 }
 
-/////////////////////////////////////////////////////////////////////
-// GLOBALS
-
-var (
-	startdoctype = []byte("<!DOCTYPE ")
-	enddoctype   = []byte(">\n")
-)
-
 ///////////////////////////////////////////////////////////////////////////////
 // STRINGIFY
 
@@ -102,43 +92,3 @@ func (this *doctype) ReplaceChild(dom.Node, dom.Node) {
 func (this *doctype) v() *node {
 	return this.node
 }
-
-func (this *doctype) write(w io.Writer) (int, error) {
-	s := 0
-	if n, err := w.Write(startdoctype); err != nil {
-		return 0, err
-	} else {
-		s += n
-	}
-	if n, err := w.Write([]byte(this.name)); err != nil {
-		return 0, err
-	} else {
-		s += n
-	}
-	if this.publicid != "" {
-		if n, err := w.Write([]byte(" PUBLIC " + strconv.Quote(this.publicid))); err != nil {
-			return 0, err
-		} else {
-			s += n
-		}
-		if this.systemid != "" {
-			if n, err := w.Write([]byte(" " + strconv.Quote(this.systemid))); err != nil {
-				return 0, err
-			} else {
-				s += n
-			}
-		}
-	} else if this.systemid != "" {
-		if n, err := w.Write([]byte(" SYSTEM " + strconv.Quote(this.systemid))); err != nil {
-			return 0, err
-		} else {
-			s += n
-		}
-	}
-	if n, err := w.Write(enddoctype); err != nil {
-		return 0, err
-	} else {
-		s += n
-	}
-	return s, nil
-}
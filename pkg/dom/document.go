@@ -4,8 +4,9 @@ package dom
 
 import (
 	"fmt"
-	"io"
+	"slices"
 	"strings"
+	"weak"
 
 	// Packages
 	dom "github.com/djthorpe/go-wasmbuild"
@@ -18,11 +19,21 @@ type document struct {
 	*node
 
 	doctype dom.DocumentType
-	head    dom.Element
-	body    dom.Element
-	charset dom.Element
+	active  *element
+
+	// The window of the document, which is nil for documents which are
+	// not displayed in a window
+	window *window
+
+	// Connected elements with an id attribute
+	ids map[string][]*element
+
+	// Node iterators which are updated when nodes are removed
+	iterators []weak.Pointer[nodeIterator]
 }
 
+var _ dom.Document = (*document)(nil)
+
 ///////////////////////////////////////////////////////////////////////////////
 // LIFECYCLE
 
@@ -36,13 +47,12 @@ func NewHTMLDocument(title string) *document {
 	// Set doctype, root, head, body, charset and title to document
 	doc.doctype = NewNode(doc, "html", dom.DOCUMENT_TYPE_NODE, "").(dom.DocumentType)
 	doc.AppendChild(doc.CreateElement("html"))
-	doc.head = doc.FirstChild().AppendChild(doc.CreateElement("head")).(dom.Element)
-	doc.charset = doc.head.AppendChild(doc.CreateElement("meta")).(dom.Element)
+	head := doc.FirstChild().AppendChild(doc.CreateElement("head")).(dom.Element)
+	head.AppendChild(doc.CreateElement("meta")).(dom.Element).SetAttribute("charset", "utf-8")
 	if title != "" {
-		titlenode := doc.head.AppendChild(doc.CreateElement("title")).(dom.Element)
-		titlenode.AppendChild(doc.CreateTextNode(title))
+		doc.SetTitle(title)
 	}
-	doc.body = doc.FirstChild().AppendChild(doc.CreateElement("body")).(dom.Element)
+	doc.FirstChild().AppendChild(doc.CreateElement("body"))
 
 	// Return the document
 	return doc
@@ -61,36 +71,87 @@ func (this *document) PreviousSibling() dom.Node {
 	return nil
 }
 
+// Body returns the body element, or nil if there is no body element
 func (this *document) Body() dom.Element {
-	return this.body
+	return this.rootChild("BODY")
+}
+
+// Head returns the head element, or nil if there is no head element
+func (this *document) Head() dom.Element {
+	return this.rootChild("HEAD")
 }
 
 func (this *document) Doctype() dom.DocumentType {
 	return this.doctype
 }
 
+// DocumentElement returns the root element of the document
 func (this *document) DocumentElement() dom.Element {
-	return this.FirstChild().(dom.Element)
+	for _, child := range this.children {
+		if child.NodeType() == dom.ELEMENT_NODE {
+			return child.(dom.Element)
+		}
+	}
+	return nil
 }
 
-func (doc *document) Title() string {
-	if doc.head == nil {
-		return ""
-	}
-	// Find the title element in the head
-	for child := doc.head.FirstChild(); child != nil; child = child.NextSibling() {
-		if child.NodeType() == dom.ELEMENT_NODE && child.NodeName() == "title" {
-			return child.TextContent()
+// CharacterSet returns the charset declared in the head, or UTF-8
+func (this *document) CharacterSet() string {
+	if head := this.Head(); head != nil {
+		for _, child := range head.Children() {
+			if child.TagName() == "META" && child.HasAttribute("charset") {
+				return strings.ToUpper(child.GetAttribute("charset"))
+			}
 		}
 	}
+	return "UTF-8"
+}
+
+func (this *document) ContentType() string {
+	return mimetypeHTML
+}
+
+func (this *document) DocumentURI() string {
+	return "about:blank"
+}
+
+func (this *document) Title() string {
+	if title := this.titleElement(); title != nil {
+		return strings.Join(strings.Fields(title.TextContent()), " ")
+	}
 	return ""
 }
 
+// SetTitle sets the text of the title element, which is added to the head
+// if it does not exist
+func (this *document) SetTitle(value string) {
+	title := this.titleElement()
+	if title == nil {
+		head := this.Head()
+		if head == nil {
+			return
+		}
+		title = elementOf(head.AppendChild(this.CreateElement("title")))
+	}
+	title.replaceAll([]dom.Node{this.CreateTextNode(value)})
+}
+
+// ActiveElement returns the focused element, or the body element if no
+// element has focus
+func (this *document) ActiveElement() dom.Element {
+	if this.active != nil && rangeRoot(this.active) == dom.Node(this) {
+		return this.active.domElement()
+	}
+	return this.Body()
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // PUBLIC METHODS
 
+// AppendChild appends an element or comment to the document, and ignores
+// other nodes
 func (this *document) AppendChild(child dom.Node) dom.Node {
-	if child.NodeType() != dom.ELEMENT_NODE {
+	if child.NodeType() != dom.ELEMENT_NODE && child.NodeType() != dom.COMMENT_NODE {
 		return nil
 	}
 	return this.node.AppendChild(child)
@@ -101,10 +162,11 @@ func (this *document) CloneNode(deep bool) dom.Node {
 	if this.doctype != nil {
 		clone.doctype = this.doctype.CloneNode(deep).(dom.DocumentType)
 	}
-	if root := this.FirstChild(); root != nil {
-		clone.AppendChild(root.CloneNode(deep).(dom.Element))
+	if deep {
+		for _, child := range this.children {
+			clone.AppendChild(clone.ImportNode(child, true))
+		}
 	}
-	// TODO: set head, body, charset from this.root
 	return clone
 }
 
@@ -125,6 +187,13 @@ func (this *document) CreateElement(name string) dom.Element {
 	return NewNode(this, name, dom.ELEMENT_NODE, "").(dom.Element)
 }
 
+// CreateElementNS returns an element with a namespace and qualified name,
+// and panics if the name is not valid for the namespace
+func (this *document) CreateElementNS(namespace, name string) dom.Element {
+	validateName(namespace, name)
+	return newNode(this, namespace, name, dom.ELEMENT_NODE, "").(dom.Element)
+}
+
 func (this *document) CreateComment(cdata string) dom.Comment {
 	return NewNode(this, "#comment", dom.COMMENT_NODE, cdata).(dom.Comment)
 }
@@ -137,9 +206,52 @@ func (this *document) CreateAttribute(name string) dom.Attr {
 	return NewNode(this, name, dom.ATTRIBUTE_NODE, "").(dom.Attr)
 }
 
-func (this *document) ActiveElement() dom.Element {
-	// Not supported in non-WASM builds
-	return nil
+func (this *document) CreateDocumentFragment() dom.DocumentFragment {
+	return NewNode(this, "#document-fragment", dom.DOCUMENT_FRAGMENT_NODE, "").(dom.DocumentFragment)
+}
+
+func (this *document) CreateRange() dom.Range {
+	return newRange(this)
+}
+
+// GetElementById returns the first element in tree order with the id, or
+// nil if there is no such element
+func (this *document) GetElementById(id string) dom.Element {
+	elems := this.ids[id]
+	switch len(elems) {
+	case 0:
+		return nil
+	case 1:
+		return elems[0].domElement()
+	default:
+		return slices.MinFunc(elems, func(a, b *element) int {
+			return slices.Compare(nodePath(a), nodePath(b))
+		}).domElement()
+	}
+}
+
+// ImportNode returns a copy of a node from another document, which is
+// owned by this document
+func (this *document) ImportNode(node dom.Node, deep bool) dom.Node {
+	if node == nil || node.NodeType() == dom.DOCUMENT_NODE {
+		panic(dom.ErrBadParameter.With("cannot import a document"))
+	}
+	clone := node.CloneNode(deep)
+	this.adopt(clone)
+	return clone
+}
+
+// AdoptNode removes a node from its parent, and changes the owner document
+// of the node and its descendants to this document
+func (this *document) AdoptNode(node dom.Node) dom.Node {
+	if node == nil || node.NodeType() == dom.DOCUMENT_NODE {
+		panic(dom.ErrBadParameter.With("cannot adopt a document"))
+	}
+	if parent := node.ParentNode(); parent != nil {
+		parent.RemoveChild(node)
+	}
+	this.adopt(node)
+	return node
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -149,34 +261,74 @@ func (this *document) v() *node {
 	return this.node
 }
 
-func (this *document) write(w io.Writer) (int, error) {
-	s := 0
-
-	// Write doctype if present
-	if this.doctype != nil {
-		if n, err := writeNode(w, this.doctype); err != nil {
-			return 0, err
-		} else {
-			s += n
+// rootChild returns the first child of the root element with the tag name
+func (this *document) rootChild(tagName string) dom.Element {
+	if root := this.DocumentElement(); root != nil {
+		for _, child := range root.Children() {
+			if child.TagName() == tagName {
+				return child
+			}
 		}
-		// Add newline after doctype
-		if n, err := w.Write([]byte("\n")); err != nil {
-			return 0, err
-		} else {
-			s += n
+	}
+	return nil
+}
+
+// titleElement returns the first title element in the document
+func (this *document) titleElement() *element {
+	return elementOf(this.QuerySelector("title"))
+}
+
+// adopt sets the owner document of the node, its attributes and its
+// descendants
+func (this *document) adopt(node dom.Node) {
+	n := getNode(node)
+	n.document = this
+	if elem := elementOf(node); elem != nil {
+		for _, attr := range elem.attrs {
+			getNode(attr).document = this
 		}
 	}
+	for _, child := range n.children {
+		this.adopt(child)
+	}
+}
 
-	// Write root element (usually <html>)
-	if root := this.FirstChild(); root != nil {
-		if n, err := writeNode(w, root); err != nil {
-			return 0, err
-		} else {
-			s += n
+// indexNodes adds or removes the elements in the subtrees of nodes to the
+// id index of the document which contains parent
+func indexNodes(parent dom.Node, nodes []dom.Node, add bool) {
+	doc, ok := rangeRoot(parent).(*document)
+	if !ok {
+		return
+	}
+	for _, n := range nodes {
+		if elem := elementOf(n); elem != nil {
+			if id := elem.GetAttribute("id"); id != "" && add {
+				doc.indexID(elem, "", id)
+			} else if id != "" {
+				doc.indexID(elem, id, "")
+			}
 		}
+		indexNodes(n, getNode(n).children, add)
 	}
+}
 
-	return s, nil
+// indexID moves an element in the id index from the old id to the new id,
+// where an empty id is not indexed
+func (this *document) indexID(elem *element, old, id string) {
+	if old != "" {
+		this.ids[old] = slices.DeleteFunc(this.ids[old], func(other *element) bool {
+			return other == elem
+		})
+		if len(this.ids[old]) == 0 {
+			delete(this.ids, old)
+		}
+	}
+	if id != "" && !slices.Contains(this.ids[id], elem) {
+		if this.ids == nil {
+			this.ids = make(map[string][]*element)
+		}
+		this.ids[id] = append(this.ids[id], elem)
+	}
 }
 
 ///////////////////////////////////////////////////////////////////////////////
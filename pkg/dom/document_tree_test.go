@@ -0,0 +1,138 @@
+package dom_test
+
+import (
+	"strings"
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+// readDocument returns a new document parsed from html
+func readDocument(t *testing.T, html string) dom.Document {
+	t.Helper()
+	doc, err := domPkg.GetWindow().Read(strings.NewReader(html), "text/html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestDocument_Structure(t *testing.T) {
+	doc := readDocument(t, "<!DOCTYPE html><html><head><meta charset=\"iso-8859-1\"><title> A\n title </title></head><body></body></html>")
+	assert.Equal(t, "HTML", doc.DocumentElement().TagName())
+	assert.Equal(t, "HEAD", doc.Head().TagName())
+	assert.Equal(t, "BODY", doc.Body().TagName())
+	assert.Equal(t, "ISO-8859-1", doc.CharacterSet())
+	assert.Equal(t, "text/html", doc.ContentType())
+	assert.NotEmpty(t, doc.DocumentURI())
+	assert.Equal(t, "A title", doc.Title())
+
+	// Setting the title replaces the text of the title element
+	doc.SetTitle("New title")
+	assert.Equal(t, "New title", doc.Title())
+	assert.Len(t, doc.QuerySelectorAll("title"), 1)
+
+	// A cloned document has its own head and body
+	clone := doc.CloneNode(true).(dom.Document)
+	if assert.NotNil(t, clone.Head()) && assert.NotNil(t, clone.Body()) {
+		assert.False(t, doc.Body().Equals(clone.Body()))
+		assert.True(t, clone.Equals(clone.Body().OwnerDocument()))
+		assert.Equal(t, "New title", clone.Title())
+	}
+}
+
+func TestDocument_SetTitle(t *testing.T) {
+	doc := readDocument(t, "<html><head></head><body></body></html>")
+	assert.Equal(t, "", doc.Title())
+
+	// A title element is added to the head
+	doc.SetTitle("Hello")
+	assert.Equal(t, "Hello", doc.Title())
+	assert.NotNil(t, doc.Head().QuerySelector("title"))
+}
+
+func TestDocument_GetElementById(t *testing.T) {
+	doc := readDocument(t, `<html><body><div id="a"><p id="b"></p></div></body></html>`)
+	a, b := doc.GetElementById("a"), doc.GetElementById("b")
+	if !assert.NotNil(t, a) || !assert.NotNil(t, b) {
+		return
+	}
+	assert.Nil(t, doc.GetElementById("c"))
+
+	// Changing and removing the id attribute
+	b.SetAttribute("id", "c")
+	assert.Nil(t, doc.GetElementById("b"))
+	assert.True(t, b.Equals(doc.GetElementById("c")))
+	b.GetAttributeNode("id").SetValue("d")
+	assert.True(t, b.Equals(doc.GetElementById("d")))
+	b.RemoveAttribute("id")
+	assert.Nil(t, doc.GetElementById("d"))
+
+	// Elements which are not connected are not found
+	span := doc.CreateElement("span")
+	span.SetAttribute("id", "e")
+	assert.Nil(t, doc.GetElementById("e"))
+	a.AppendChild(span)
+	assert.True(t, span.Equals(doc.GetElementById("e")))
+
+	// Removing an ancestor removes its descendants
+	doc.Body().RemoveChild(a)
+	assert.Nil(t, doc.GetElementById("a"))
+	assert.Nil(t, doc.GetElementById("e"))
+
+	// The first element in tree order is returned for duplicate ids
+	doc.Body().SetInnerHTML(`<p id="x">1</p><p id="x">2</p>`)
+	doc.Body().InsertBefore(a, doc.Body().FirstChild())
+	a.SetAttribute("id", "x")
+	assert.True(t, a.Equals(doc.GetElementById("x")))
+	a.Remove()
+	assert.Equal(t, "1", doc.GetElementById("x").TextContent())
+
+	// Replacing the contents removes the old elements
+	doc.Body().SetInnerHTML(`<p id="y"></p>`)
+	assert.Nil(t, doc.GetElementById("x"))
+	assert.NotNil(t, doc.GetElementById("y"))
+}
+
+func TestDocument_ImportNode(t *testing.T) {
+	doc := readDocument(t, "<html><body></body></html>")
+	other := readDocument(t, `<html><body><div id="a"><p>hello</p></div></body></html>`)
+	div := other.GetElementById("a")
+
+	// Importing copies the node
+	imported := doc.ImportNode(div, true).(dom.Element)
+	assert.True(t, doc.Equals(imported.OwnerDocument()))
+	assert.Equal(t, "<p>hello</p>", imported.InnerHTML())
+	assert.True(t, other.Equals(div.OwnerDocument()))
+	assert.Empty(t, doc.ImportNode(div, false).ChildNodes())
+
+	// Adopting moves the node
+	adopted := doc.AdoptNode(div)
+	assert.True(t, doc.Equals(adopted.OwnerDocument()))
+	assert.True(t, doc.Equals(adopted.FirstChild().OwnerDocument()))
+	assert.Nil(t, adopted.ParentNode())
+	assert.Nil(t, other.GetElementById("a"))
+
+	assert.Panics(t, func() { doc.AdoptNode(other) })
+}
+
+func TestDocument_ActiveElement(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	input := doc.CreateElement("input")
+	doc.Body().AppendChild(input)
+	defer input.Remove()
+
+	var events []string
+	for _, eventType := range []string{"focus", "blur"} {
+		input.AddEventListener(eventType, func(dom.Node) { events = append(events, eventType) })
+	}
+
+	input.Focus()
+	assert.True(t, input.Equals(doc.ActiveElement()))
+	input.Blur()
+	assert.True(t, doc.Body().Equals(doc.ActiveElement()))
+	assert.Equal(t, []string{"focus", "blur"}, events)
+}
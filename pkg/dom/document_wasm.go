@@ -30,7 +30,27 @@ func (this *document) String() string {
 // PROPERTIES
 
 func (this *document) Body() dom.Element {
-	return NewNode(this.Get("body")).(dom.Element)
+	return this.element("body")
+}
+
+func (this *document) Head() dom.Element {
+	return this.element("head")
+}
+
+func (this *document) DocumentElement() dom.Element {
+	return this.element("documentElement")
+}
+
+func (this *document) CharacterSet() string {
+	return this.Get("characterSet").String()
+}
+
+func (this *document) ContentType() string {
+	return this.Get("contentType").String()
+}
+
+func (this *document) DocumentURI() string {
+	return this.Get("documentURI").String()
 }
 
 func (this *document) Doctype() dom.DocumentType {
@@ -51,6 +71,10 @@ func (doc *document) Title() string {
 	}
 }
 
+func (doc *document) SetTitle(title string) {
+	doc.v().Set("title", title)
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // PUBLIC METHODS
 
@@ -58,6 +82,11 @@ func (this *document) CreateElement(name string) dom.Element {
 	return NewNode(this.Call("createElement", name)).(dom.Element)
 }
 
+func (this *document) CreateElementNS(namespace, name string) dom.Element {
+	validateName(namespace, name)
+	return NewNode(this.Call("createElementNS", nullNamespace(namespace), name)).(dom.Element)
+}
+
 func (this *document) CreateComment(data string) dom.Comment {
 	return NewNode(this.Call("createComment", data)).(dom.Comment)
 }
@@ -70,12 +99,62 @@ func (this *document) CreateAttribute(name string) dom.Attr {
 	return NewNode(this.Call("createAttribute", name)).(dom.Attr)
 }
 
+func (this *document) CreateDocumentFragment() dom.DocumentFragment {
+	return NewNode(this.Call("createDocumentFragment")).(dom.DocumentFragment)
+}
+
+func (this *document) CreateRange() dom.Range {
+	return &domRange{this.Call("createRange")}
+}
+
+func (this *document) QuerySelector(selector string) dom.Element {
+	result := this.Call("querySelector", selector)
+	if result.IsNull() {
+		return nil
+	}
+	return NewNode(result).(dom.Element)
+}
+
+func (this *document) QuerySelectorAll(selector string) []dom.Element {
+	nodeList := this.Call("querySelectorAll", selector)
+	length := nodeList.Get("length").Int()
+	result := make([]dom.Element, 0, length)
+	for i := 0; i < length; i++ {
+		result = append(result, NewNode(nodeList.Index(i)).(dom.Element))
+	}
+	return result
+}
+
 func (this *document) ActiveElement() dom.Element {
-	activeEl := this.Get("activeElement")
-	if activeEl.IsNull() || activeEl.IsUndefined() {
+	return this.element("activeElement")
+}
+
+func (this *document) GetElementById(id string) dom.Element {
+	result := this.Call("getElementById", id)
+	if result.IsNull() {
 		return nil
 	}
-	return NewNode(activeEl).(dom.Element)
+	return NewNode(result).(dom.Element)
+}
+
+func (this *document) ImportNode(node dom.Node, deep bool) dom.Node {
+	if node == nil || node.NodeType() == dom.DOCUMENT_NODE {
+		panic(dom.ErrBadParameter.With("cannot import a document"))
+	}
+	return NewNode(this.Call("importNode", toJSValue(node), deep))
+}
+
+func (this *document) AdoptNode(node dom.Node) dom.Node {
+	if node == nil || node.NodeType() == dom.DOCUMENT_NODE {
+		panic(dom.ErrBadParameter.With("cannot adopt a document"))
+	}
+	return NewNode(this.Call("adoptNode", toJSValue(node)))
+}
+
+// AddEventHandler adds a listener for events dispatched to the document or
+// bubbling from its descendants
+func (this *document) AddEventHandler(eventType string, callback func(dom.Event), options ...dom.EventListenerOption) dom.EventListener {
+	return addListener(this.Value, eventType, callback, options...)
 }
 
 /////////////////////////////////////////////////////////////////////
@@ -84,3 +163,12 @@ func (this *document) ActiveElement() dom.Element {
 func (this *document) v() js.Value {
 	return this.node.v()
 }
+
+// element returns the element property, or nil if it is not set
+func (this *document) element(name string) dom.Element {
+	value := this.Get(name)
+	if value.IsNull() || value.IsUndefined() {
+		return nil
+	}
+	return NewNode(value).(dom.Element)
+}
@@ -4,9 +4,7 @@ package dom
 
 import (
 	"bytes"
-	"fmt"
-	"io"
-	"sort"
+	"slices"
 	"strings"
 
 	// Packages
@@ -19,7 +17,10 @@ import (
 type element struct {
 	*node
 	classlist *tokenlist
-	attrs     map[string]dom.Attr
+	style     *style
+	attrs     []dom.Attr
+	shadow    *shadowRoot
+	custom    dom.CustomElement
 }
 
 var _ dom.Element = (*element)(nil)
@@ -36,37 +37,49 @@ func (this *element) String() string {
 
 func (this *element) InnerHTML() string {
 	buf := new(bytes.Buffer)
-	for child := this.FirstChild(); child != nil; child = child.NextSibling() {
+	for child := contentOf(this.domElement()).FirstChild(); child != nil; child = child.NextSibling() {
 		writeNode(buf, child)
 	}
 	return buf.String()
 }
 
-func (this *element) SetInnerHTML(html string) {
-	// Clear existing children
-	this.node.children = []dom.Node{}
-}
-
 func (this *element) OuterHTML() string {
 	buf := new(bytes.Buffer)
-	this.write(buf)
+	writeNode(buf, this.domElement())
 	return buf.String()
 }
 
+// TagName returns the qualified name, which is uppercase for HTML elements
 func (this *element) TagName() string {
-	if name := this.NodeName(); strings.HasPrefix(name, "#") {
-		return name
-	} else {
-		return strings.ToUpper(name)
+	if this.namespace == dom.NS_HTML {
+		return strings.ToUpper(this.name)
 	}
+	return this.name
 }
 
-func (this *element) Attributes() []dom.Attr {
-	result := make([]dom.Attr, 0, len(this.attrs))
-	for _, attr := range this.attrs {
-		result = append(result, attr)
+func (this *element) NamespaceURI() string {
+	return this.namespace
+}
+
+func (this *element) Prefix() string {
+	prefix, _ := splitName(this.name)
+	return prefix
+}
+
+// LocalName returns the name without a prefix, which is lowercase for HTML
+// elements
+func (this *element) LocalName() string {
+	_, local := splitName(this.name)
+	if this.namespace == dom.NS_HTML {
+		return strings.ToLower(local)
 	}
-	return result
+	return local
+}
+
+// Attributes returns the attributes of the element in the order they were
+// added
+func (this *element) Attributes() []dom.Attr {
+	return slices.Clone(this.attrs)
 }
 
 func (this *element) HasAttributes() bool {
@@ -74,46 +87,41 @@ func (this *element) HasAttributes() bool {
 }
 
 func (this *element) Style() dom.Style {
-	// Not implemented for non-WASM builds
-	return nil
+	return this.style
 }
 
 func (this *element) SetAttribute(name, value string) dom.Attr {
-	attr := this.document.CreateAttribute(name)
-	attr.SetValue(value)
-	getNode(attr).parent = this
-	this.attrs[name] = attr
-
-	// Sync classlist when class attribute is set
-	if name == "class" && value != "" {
-		classes := strings.Fields(value)
-		this.classlist = NewTokenList(classes...)
+	// Update an existing attribute
+	if attr := this.attribute(name); attr != nil {
+		attr.SetValue(value)
+		return attr
 	}
 
+	// Add a new attribute
+	attr := this.document.CreateAttribute(name)
+	attr.SetValue(value)
+	this.setAttributeNode(attr)
 	return attr
 }
 
 func (this *element) GetAttribute(name string) string {
-	attr := this.attrs[name]
-	if attr == nil {
-		return ""
+	if attr := this.attribute(name); attr != nil {
+		return attr.Value()
 	}
-	return attr.Value()
+	return ""
 }
 
 func (this *element) GetAttributeNode(name string) dom.Attr {
-	return this.attrs[name]
+	return this.attribute(name)
 }
 
 func (this *element) HasAttribute(name string) bool {
-	_, exists := this.attrs[name]
-	return exists
+	return this.attribute(name) != nil
 }
 
 func (this *element) RemoveAttribute(name string) {
-	if attr, exists := this.attrs[name]; exists {
-		getNode(attr).parent = nil
-		delete(this.attrs, name)
+	if attr := this.attribute(name); attr != nil {
+		this.removeAttributeNode(attr)
 	}
 }
 
@@ -121,10 +129,8 @@ func (this *element) RemoveAttributeNode(attr dom.Attr) {
 	if attr == nil {
 		return
 	}
-	name := attr.Name()
-	if existing, exists := this.attrs[name]; exists && existing == attr {
-		getNode(attr).parent = nil
-		delete(this.attrs, name)
+	if this.attribute(attr.Name()) == attr {
+		this.removeAttributeNode(attr)
 	}
 }
 
@@ -132,20 +138,52 @@ func (this *element) SetAttributeNode(attr dom.Attr) dom.Attr {
 	if attr == nil {
 		return nil
 	}
-	name := attr.Name()
-	oldAttr := this.attrs[name]
+	oldAttr := this.attribute(attr.Name())
+	if oldAttr == attr {
+		return nil
+	}
 	if oldAttr != nil {
 		getNode(oldAttr).parent = nil
 	}
-	getNode(attr).parent = this
-	this.attrs[name] = attr
+	this.setAttributeNode(attr)
 	return oldAttr
 }
 
+// SetAttributeNS sets an attribute with a namespace and qualified name,
+// and panics if the name is not valid for the namespace
+func (this *element) SetAttributeNS(namespace, name, value string) dom.Attr {
+	validateName(namespace, name)
+	_, local := splitName(name)
+	if attr := this.attributeNS(namespace, local); attr != nil {
+		attr.SetValue(value)
+		return attr
+	}
+	attr := newNode(this.document, namespace, name, dom.ATTRIBUTE_NODE, value).(dom.Attr)
+	this.setAttributeNode(attr)
+	return attr
+}
+
+func (this *element) GetAttributeNS(namespace, name string) string {
+	if attr := this.attributeNS(namespace, name); attr != nil {
+		return attr.Value()
+	}
+	return ""
+}
+
+func (this *element) HasAttributeNS(namespace, name string) bool {
+	return this.attributeNS(namespace, name) != nil
+}
+
+func (this *element) RemoveAttributeNS(namespace, name string) {
+	if attr := this.attributeNS(namespace, name); attr != nil {
+		this.removeAttributeNode(attr)
+	}
+}
+
 func (this *element) GetAttributeNames() []string {
 	names := make([]string, 0, len(this.attrs))
-	for name := range this.attrs {
-		names = append(names, name)
+	for _, attr := range this.attrs {
+		names = append(names, attr.Name())
 	}
 	return names
 }
@@ -156,62 +194,43 @@ func (this *element) ClassList() dom.TokenList {
 	return this.classlist
 }
 
+// GetElementsByClassName returns the descendant elements with the class
 func (this *element) GetElementsByClassName(className string) []dom.Element {
 	var result []dom.Element
-	this.getElementsByClassName(className, &result)
-	return result
-}
-
-func (this *element) getElementsByClassName(className string, result *[]dom.Element) {
-	// Check if this element has the class by directly checking the attribute
-	// This is more efficient than ClassList().Contains() which parses the entire class list
-	classAttr := this.GetAttribute("class")
-	if classAttr != "" {
-		// Split and check for exact match
-		for _, cls := range strings.Fields(classAttr) {
-			if cls == className {
-				*result = append(*result, this)
-				break
-			}
-		}
-	}
-	// Recursively check children
-	for _, child := range this.node.children {
-		if elem, ok := child.(dom.Element); ok {
-			if e, ok := elem.(*element); ok {
-				e.getElementsByClassName(className, result)
-			}
+	for n := range this.Descendants() {
+		if elem, ok := n.(dom.Element); ok && slices.Contains(strings.Fields(elem.GetAttribute("class")), className) {
+			result = append(result, elem)
 		}
 	}
+	return result
 }
 
+// GetElementsByTagName returns the descendant elements with the tag name,
+// which is case insensitive for HTML elements
 func (this *element) GetElementsByTagName(tagName string) []dom.Element {
 	var result []dom.Element
-	// Normalize tagName to uppercase for case-insensitive comparison
-	tagName = strings.ToUpper(tagName)
-	this.getElementsByTagName(tagName, &result)
+	for n := range this.Descendants() {
+		if elem, ok := n.(dom.Element); ok && matchTagName(elem, tagName) {
+			result = append(result, elem)
+		}
+	}
 	return result
 }
 
-func (this *element) getElementsByTagName(tagName string, result *[]dom.Element) {
-	// Recursively check children only (not this element itself)
-	for _, child := range this.node.children {
-		if elem, ok := child.(dom.Element); ok {
-			// Check if this child matches (case-insensitive comparison via TagName which returns uppercase)
-			if elem.TagName() == tagName {
-				*result = append(*result, elem)
-			}
-			// Recursively check child's descendants
-			if e, ok := elem.(*element); ok {
-				e.getElementsByTagName(tagName, result)
-			}
-		}
+func (this *element) CloneNode(deep bool) dom.Node {
+	clone := this.node.CloneNode(deep)
+	for _, attr := range this.attrs {
+		elementOf(clone).setAttributeNode(attr.CloneNode(false).(dom.Attr))
 	}
+	if c, ok := this.self.(interface{ copyTo(dom.Node, bool) }); ok {
+		c.copyTo(clone, deep)
+	}
+	return clone
 }
 
 func (this *element) Remove() {
 	if this.node.parent != nil {
-		this.node.parent.RemoveChild(this)
+		this.node.parent.RemoveChild(this.self)
 	}
 }
 
@@ -221,13 +240,20 @@ func (this *element) ReplaceWith(nodes ...dom.Node) {
 		return
 	}
 
-	// Insert all new nodes before this element
-	for _, node := range nodes {
-		parent.InsertBefore(node, this)
+	// Find the sibling to insert before, which is not one of the new nodes
+	next := this.NextSibling()
+	for next != nil && slices.Contains(nodes, next) {
+		next = next.NextSibling()
 	}
 
-	// Remove this element
-	parent.RemoveChild(this)
+	// Remove this element, unless it is one of the new nodes, and insert
+	// the new nodes in its place
+	if !slices.Contains(nodes, this.self) {
+		parent.RemoveChild(this.self)
+	}
+	for _, node := range nodes {
+		parent.InsertBefore(node, next)
+	}
 }
 
 func (this *element) InsertAdjacentElement(position string, element dom.Element) dom.Element {
@@ -239,7 +265,7 @@ func (this *element) InsertAdjacentElement(position string, element dom.Element)
 	case "beforebegin":
 		// Insert before this element
 		if this.node.parent != nil {
-			this.node.parent.InsertBefore(element, this)
+			this.node.parent.InsertBefore(element, this.self)
 			return element
 		}
 	case "afterbegin":
@@ -283,8 +309,6 @@ func (this *element) ClassName() string {
 
 func (this *element) SetClassName(className string) {
 	this.SetAttribute("class", className)
-	// Invalidate cached classlist
-	this.classlist = nil
 }
 
 func (this *element) Children() []dom.Element {
@@ -343,7 +367,7 @@ func (this *element) NextElementSibling() dom.Element {
 				return elem
 			}
 		}
-		if child == this {
+		if child == this.self {
 			found = true
 		}
 	}
@@ -363,7 +387,7 @@ func (this *element) PreviousElementSibling() dom.Element {
 
 	var prevElement dom.Element
 	for _, child := range parent.children {
-		if child == this {
+		if child == this.self {
 			return prevElement
 		}
 		if elem, ok := child.(dom.Element); ok {
@@ -373,18 +397,29 @@ func (this *element) PreviousElementSibling() dom.Element {
 	return nil
 }
 
-func (this *element) AddEventListener(eventType string, callback func(dom.Node)) dom.Element {
-	// Event listeners are not supported in non-WASM builds
-	// This is a no-op since there's no event loop outside the browser
-	return this
-}
-
+// Blur removes focus from the element, if it is the active element
 func (this *element) Blur() {
-	// Not supported in non-WASM builds
+	if doc, ok := rangeRoot(this).(*document); ok && doc.active == this {
+		doc.active = nil
+		this.DispatchEvent(newEvent("blur", false, false))
+		this.DispatchEvent(newEvent("focusout", true, false))
+	}
 }
 
+// Focus makes the element the active element of its document, and removes
+// focus from the previous active element. Disabled form controls cannot be
+// focused.
 func (this *element) Focus() {
-	// Not supported in non-WASM builds
+	doc, ok := rangeRoot(this).(*document)
+	if !ok || doc.active == this || this.disabled() {
+		return
+	}
+	if doc.active != nil {
+		doc.active.Blur()
+	}
+	doc.active = this
+	this.DispatchEvent(newEvent("focus", false, false))
+	this.DispatchEvent(newEvent("focusin", true, false))
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -394,56 +429,115 @@ func (this *element) v() *node {
 	return this.node
 }
 
-func (this *element) write(w io.Writer) (int, error) {
-	s := 0
-
-	// Sync classlist to class attribute before writing
-	if this.classlist != nil && this.classlist.Length() > 0 {
-		this.SetAttribute("class", this.classlist.Value())
+// attribute returns the attribute with a qualified name, or nil
+func (this *element) attribute(name string) dom.Attr {
+	if i := this.indexOfAttribute(name); i >= 0 {
+		return this.attrs[i]
 	}
+	return nil
+}
 
-	// Write opening tag with attributes (lowercase to match browser behavior)
-	tagName := strings.ToLower(this.node.name)
-	tag := "<" + tagName
-
-	// Add attributes in sorted order for consistent output
-	if len(this.attrs) > 0 {
-		// Get sorted attribute names
-		names := make([]string, 0, len(this.attrs))
-		for name := range this.attrs {
-			names = append(names, name)
-		}
-		sort.Strings(names)
+// indexOfAttribute returns the position of the attribute with a qualified
+// name, or -1
+func (this *element) indexOfAttribute(name string) int {
+	return slices.IndexFunc(this.attrs, func(attr dom.Attr) bool {
+		return attr.Name() == name
+	})
+}
 
-		// Write attributes in sorted order
-		for _, name := range names {
-			attr := this.attrs[name]
-			tag += fmt.Sprintf(" %s=%q", attr.Name(), attr.Value())
+// attributeNS returns the attribute with a namespace and local name, or nil
+func (this *element) attributeNS(namespace, name string) dom.Attr {
+	for _, attr := range this.attrs {
+		if attr.NamespaceURI() == namespace && attr.LocalName() == name {
+			return attr
 		}
 	}
+	return nil
+}
 
-	tag += ">"
-	if n, err := w.Write([]byte(tag)); err != nil {
-		return 0, err
+// setAttributeNode adds an attribute after the existing attributes, or
+// replaces one in place, and syncs the class list, style or id index
+func (this *element) setAttributeNode(attr dom.Attr) {
+	checkEventLoop()
+	name := attr.Name()
+	var old string
+	if i := this.indexOfAttribute(name); i >= 0 {
+		old = this.attrs[i].Value()
+		this.attrs[i] = attr
 	} else {
-		s += n
+		this.attrs = append(this.attrs, attr)
 	}
+	getNode(attr).parent = this.self
+	this.syncAttribute(name, old, attr.Value())
+	queueMutation(mutationRecord{kind: mutationAttributes, target: this.self, attributeName: name, oldValue: old})
+}
 
-	// Write children
-	for _, child := range this.node.children {
-		if n, err := writeNode(w, child); err != nil {
-			return 0, err
-		} else {
-			s += n
+// removeAttributeNode removes an attribute, and syncs the class list, style
+// or id index
+func (this *element) removeAttributeNode(attr dom.Attr) {
+	checkEventLoop()
+	name := attr.Name()
+	getNode(attr).parent = nil
+	this.attrs = slices.DeleteFunc(this.attrs, func(other dom.Attr) bool {
+		return other == attr
+	})
+	this.syncAttribute(name, attr.Value(), "")
+	queueMutation(mutationRecord{kind: mutationAttributes, target: this.self, attributeName: name, oldValue: attr.Value()})
+}
+
+// elem returns the element embedded in a form control or other element type
+func (this *element) elem() *element {
+	return this
+}
+
+// domElement returns the element as it appears in the tree, which may be a
+// form control or other element type which embeds the element
+func (this *element) domElement() dom.Element {
+	return this.self.(dom.Element)
+}
+
+// elementOf returns the element for a node, or nil if the node is not an
+// element
+func elementOf(node dom.Node) *element {
+	if elem, ok := node.(interface{ elem() *element }); ok {
+		return elem.elem()
+	}
+	return nil
+}
+
+// syncAttribute updates the class list, style or id index when the class,
+// style or id attribute is changed
+func (this *element) syncAttribute(name, old, value string) {
+	switch name {
+	case "class":
+		this.classlist.set(value)
+	case "style":
+		this.style.set(value)
+	case "id":
+		if doc, ok := rangeRoot(this).(*document); ok {
+			doc.indexID(this, old, value)
 		}
 	}
+	if this.custom != nil {
+		customElementsOf(this.document).attributeChanged(this, name, old, value)
+	}
+}
 
-	// Write closing tag (lowercase to match browser behavior)
-	if n, err := w.Write([]byte("</" + tagName + ">")); err != nil {
-		return 0, err
+// styleChanged updates the style attribute when the style is modified
+func (this *element) styleChanged(value string) {
+	if attr := this.attribute("style"); attr != nil {
+		attr.SetValue(value)
 	} else {
-		s += n
+		this.SetAttribute("style", value)
 	}
+}
 
-	return s, nil
+// classListChanged updates the class attribute when the class list is
+// modified
+func (this *element) classListChanged(value string) {
+	if attr := this.attribute("class"); attr != nil {
+		attr.SetValue(value)
+	} else {
+		this.SetAttribute("class", value)
+	}
 }
@@ -3,6 +3,7 @@
 package dom
 
 import (
+	"strings"
 	"syscall/js"
 
 	// Packages
@@ -14,7 +15,7 @@ import (
 
 type element struct {
 	*node
-	eventListeners map[string][]js.Func // Store event listeners to prevent GC
+	eventListeners map[string][]*jsListener // Store event listeners to prevent GC
 }
 
 type style struct {
@@ -49,6 +50,18 @@ func (e *element) TagName() string {
 	return e.Get("tagName").String()
 }
 
+func (e *element) NamespaceURI() string {
+	return nullString(e.Get("namespaceURI"))
+}
+
+func (e *element) Prefix() string {
+	return nullString(e.Get("prefix"))
+}
+
+func (e *element) LocalName() string {
+	return e.Get("localName").String()
+}
+
 func (e *element) Attributes() []dom.Attr {
 	attrs := e.Get("attributes")
 	length := attrs.Get("length").Int()
@@ -106,6 +119,28 @@ func (e *element) RemoveAttribute(name string) {
 	e.Call("removeAttribute", name)
 }
 
+func (e *element) SetAttributeNS(namespace, name, value string) dom.Attr {
+	validateName(namespace, name)
+	e.Call("setAttributeNS", nullNamespace(namespace), name, value)
+	_, local, found := strings.Cut(name, ":")
+	if !found {
+		local = name
+	}
+	return NewNode(e.Call("getAttributeNodeNS", nullNamespace(namespace), local)).(dom.Attr)
+}
+
+func (e *element) GetAttributeNS(namespace, name string) string {
+	return nullString(e.Call("getAttributeNS", nullNamespace(namespace), name))
+}
+
+func (e *element) HasAttributeNS(namespace, name string) bool {
+	return e.Call("hasAttributeNS", nullNamespace(namespace), name).Bool()
+}
+
+func (e *element) RemoveAttributeNS(namespace, name string) {
+	e.Call("removeAttributeNS", nullNamespace(namespace), name)
+}
+
 func (e *element) RemoveAttributeNode(attr dom.Attr) {
 	if attr == nil {
 		return
@@ -165,6 +200,36 @@ func (e *element) GetElementsByTagName(tagName string) []dom.Element {
 	return result
 }
 
+func (e *element) QuerySelector(selector string) dom.Element {
+	result := e.Call("querySelector", selector)
+	if result.IsNull() {
+		return nil
+	}
+	return NewNode(result).(dom.Element)
+}
+
+func (e *element) QuerySelectorAll(selector string) []dom.Element {
+	nodeList := e.Call("querySelectorAll", selector)
+	length := nodeList.Get("length").Int()
+	result := make([]dom.Element, 0, length)
+	for i := 0; i < length; i++ {
+		result = append(result, NewNode(nodeList.Index(i)).(dom.Element))
+	}
+	return result
+}
+
+func (e *element) Matches(selector string) bool {
+	return e.Call("matches", selector).Bool()
+}
+
+func (e *element) Closest(selector string) dom.Element {
+	result := e.Call("closest", selector)
+	if result.IsNull() {
+		return nil
+	}
+	return NewNode(result).(dom.Element)
+}
+
 func (e *element) Remove() {
 	e.Call("remove")
 }
@@ -258,35 +323,32 @@ func (e *element) PreviousElementSibling() dom.Element {
 	return NewNode(sibling).(dom.Element)
 }
 
-func (e *element) AddEventListener(eventType string, callback func(dom.Node)) dom.Element {
+func (e *element) AddEventListener(eventType string, callback func(dom.Node), options ...dom.EventListenerOption) dom.Element {
+	e.AddEventHandler(eventType, func(evt dom.Event) {
+		// Wrap the target as an Element if possible, so Component() can find
+		// components on the clicked element
+		if target := evt.Target(); target != nil {
+			callback(target)
+		}
+	}, options...)
+	return e
+}
+
+// AddEventHandler calls the callback with the event when the event is
+// dispatched to the element or its descendants, and returns a handle to
+// remove the listener
+func (e *element) AddEventHandler(eventType string, callback func(dom.Event), options ...dom.EventListenerOption) dom.EventListener {
 	// Initialize event listeners map if needed
 	if e.eventListeners == nil {
-		e.eventListeners = make(map[string][]js.Func)
+		e.eventListeners = make(map[string][]*jsListener)
 	}
 
-	// Create a JS function wrapper
-	jsCallback := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		if len(args) > 0 {
-			// Create a Node from the event target
-			target := args[0].Get("target")
-			if !target.IsUndefined() && !target.IsNull() {
-				// Wrap as Element first if possible, then fall back to Node
-				// This ensures Component() can find components on the clicked element
-				if targetNode := NewNode(target); targetNode != nil {
-					callback(targetNode)
-				}
-			}
-		}
-		return nil
-	})
+	// Store the listener to prevent garbage collection
+	listener := addListener(e.Value, eventType, callback, options...)
+	listener.owner = e
+	e.eventListeners[eventType] = append(e.eventListeners[eventType], listener)
 
-	// Store the callback to prevent garbage collection
-	e.eventListeners[eventType] = append(e.eventListeners[eventType], jsCallback)
-
-	// Add event listener
-	e.Call("addEventListener", eventType, jsCallback)
-
-	return e
+	return listener
 }
 
 // RemoveEventListener removes all event listeners of the specified type and releases their resources
@@ -295,16 +357,9 @@ func (e *element) RemoveEventListener(eventType string) {
 		return
 	}
 
-	// Get all listeners for this event type
-	listeners := e.eventListeners[eventType]
-	if listeners == nil {
-		return
-	}
-
 	// Remove each listener from the DOM and release the js.Func
-	for _, jsCallback := range listeners {
-		e.Call("removeEventListener", eventType, jsCallback)
-		jsCallback.Release()
+	for _, listener := range e.eventListeners[eventType] {
+		listener.release()
 	}
 
 	// Remove from the map
@@ -319,10 +374,9 @@ func (e *element) ReleaseEventListeners() {
 	}
 
 	// Remove and release all listeners
-	for eventType, listeners := range e.eventListeners {
-		for _, jsCallback := range listeners {
-			e.Call("removeEventListener", eventType, jsCallback)
-			jsCallback.Release()
+	for _, listeners := range e.eventListeners {
+		for _, listener := range listeners {
+			listener.release()
 		}
 	}
 
@@ -341,10 +395,46 @@ func (e *element) Focus() {
 ///////////////////////////////////////////////////////////////////////////////
 // STYLE METHODS
 
+func (s *style) Length() int {
+	return s.Value.Get("length").Int()
+}
+
+func (s *style) CSSText() string {
+	return s.Value.Get("cssText").String()
+}
+
+func (s *style) SetCSSText(text string) {
+	s.Value.Set("cssText", text)
+}
+
+func (s *style) Item(index int) string {
+	return s.Call("item", index).String()
+}
+
+func (s *style) Names() []string {
+	names := make([]string, s.Length())
+	for i := range names {
+		names[i] = s.Item(i)
+	}
+	return names
+}
+
 func (s *style) Get(name string) string {
-	return s.Value.Get(name).String()
+	return s.Call("getPropertyValue", name).String()
 }
 
 func (s *style) Set(name string, value string) {
-	s.Value.Set(name, value)
+	s.Call("setProperty", name, value)
+}
+
+func (s *style) SetProperty(name, value, priority string) {
+	s.Call("setProperty", name, value, priority)
+}
+
+func (s *style) RemoveProperty(name string) string {
+	return s.Call("removeProperty", name).String()
+}
+
+func (s *style) GetPropertyPriority(name string) string {
+	return s.Call("getPropertyPriority", name).String()
 }
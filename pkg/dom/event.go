@@ -0,0 +1,604 @@
+//go:build !js
+
+package dom
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type event struct {
+	kind          string
+	bubbles       bool
+	cancelable    bool
+	composed      bool
+	target        dom.Node
+	currentTarget dom.Node
+	phase         dom.EventPhase
+	self          dom.Event
+
+	// Dispatch state
+	dispatching bool
+	canceled    bool
+	stopped     bool
+	immediate   bool
+	passive     bool
+}
+
+type keyboardEvent struct {
+	*event
+	modifiers
+	key, code string
+	repeat    bool
+}
+
+type mouseEvent struct {
+	*event
+	modifiers
+	clientX, clientY float64
+	button, buttons  int
+}
+
+type inputEvent struct {
+	*event
+	data, inputType string
+}
+
+type modifiers struct {
+	altKey, ctrlKey, metaKey, shiftKey bool
+}
+
+// listener is an event listener registered on a node
+type listener struct {
+	callback func(dom.Event)
+	capture  bool
+	once     bool
+	passive  bool
+	removed  bool
+}
+
+// eventListener is the handle returned when a listener is added
+type eventListener struct {
+	node     *node
+	kind     string
+	listener *listener
+}
+
+// eventPathEntry is a node in the path of an event, and the target of the
+// event for its listeners
+type eventPathEntry struct {
+	node, target dom.Node
+}
+
+// baseEvent is implemented by all events which can be dispatched
+type baseEvent interface {
+	base() *event
+}
+
+var _ dom.Event = (*event)(nil)
+var _ dom.KeyboardEvent = (*keyboardEvent)(nil)
+var _ dom.MouseEvent = (*mouseEvent)(nil)
+var _ dom.InputEvent = (*inputEvent)(nil)
+var _ dom.EventListener = (*eventListener)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+func newListener(callback func(dom.Event), options ...dom.EventListenerOption) *listener {
+	l := &listener{callback: callback}
+	for _, option := range options {
+		l.capture = l.capture || option&dom.EVENT_CAPTURE != 0
+		l.once = l.once || option&dom.EVENT_ONCE != 0
+		l.passive = l.passive || option&dom.EVENT_PASSIVE != 0
+	}
+	return l
+}
+
+func newEvent(eventType string, bubbles, cancelable bool) *event {
+	e := &event{kind: eventType, bubbles: bubbles, cancelable: cancelable}
+	e.self = e
+	return e
+}
+
+func newKeyboardEvent(eventType string, init map[string]interface{}) *keyboardEvent {
+	e := &keyboardEvent{event: newEvent(eventType, false, false)}
+	e.self = e
+	parseEventInit(init, e.event.fields(), e.modifiers.fields(), map[string]any{
+		"key":    &e.key,
+		"code":   &e.code,
+		"repeat": &e.repeat,
+	})
+	return e
+}
+
+func newMouseEvent(eventType string, init map[string]interface{}) *mouseEvent {
+	e := &mouseEvent{event: newEvent(eventType, false, false)}
+	e.self = e
+	parseEventInit(init, e.event.fields(), e.modifiers.fields(), map[string]any{
+		"clientX": &e.clientX,
+		"clientY": &e.clientY,
+		"button":  &e.button,
+		"buttons": &e.buttons,
+	})
+	return e
+}
+
+func newInputEvent(eventType string, init map[string]interface{}) *inputEvent {
+	e := &inputEvent{event: newEvent(eventType, false, false)}
+	e.self = e
+	parseEventInit(init, e.event.fields(), map[string]any{
+		"data":      &e.data,
+		"inputType": &e.inputType,
+	})
+	return e
+}
+
+// NewEvent returns a new event, which can be dispatched to a node
+func (this *window) NewEvent(eventType string, bubbles, cancelable bool) dom.Event {
+	return newEvent(eventType, bubbles, cancelable)
+}
+
+// NewKeyboardEvent returns a new keyboard event, and panics if the init
+// options are invalid
+func (this *window) NewKeyboardEvent(eventType string, init map[string]interface{}) dom.KeyboardEvent {
+	return newKeyboardEvent(eventType, init)
+}
+
+// NewMouseEvent returns a new mouse event, and panics if the init options
+// are invalid
+func (this *window) NewMouseEvent(eventType string, init map[string]interface{}) dom.MouseEvent {
+	return newMouseEvent(eventType, init)
+}
+
+// NewInputEvent returns a new input event, and panics if the init options
+// are invalid
+func (this *window) NewInputEvent(eventType string, init map[string]interface{}) dom.InputEvent {
+	return newInputEvent(eventType, init)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (e *event) String() string {
+	var b strings.Builder
+	b.WriteString("<DOMEvent")
+	fmt.Fprintf(&b, " type=%q phase=%v", e.kind, e.phase)
+	if e.bubbles {
+		b.WriteString(" bubbles")
+	}
+	if e.cancelable {
+		b.WriteString(" cancelable")
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (e *event) Type() string {
+	return e.kind
+}
+
+func (e *event) Target() dom.Node {
+	return e.target
+}
+
+func (e *event) CurrentTarget() dom.Node {
+	return e.currentTarget
+}
+
+func (e *event) EventPhase() dom.EventPhase {
+	return e.phase
+}
+
+func (e *event) Bubbles() bool {
+	return e.bubbles
+}
+
+func (e *event) Cancelable() bool {
+	return e.cancelable
+}
+
+// Composed returns true if the event propagates from a shadow tree to the
+// host and its ancestors
+func (e *event) Composed() bool {
+	return e.composed
+}
+
+func (e *event) DefaultPrevented() bool {
+	return e.canceled
+}
+
+func (e *keyboardEvent) Key() string {
+	return e.key
+}
+
+func (e *keyboardEvent) Code() string {
+	return e.code
+}
+
+func (e *keyboardEvent) Repeat() bool {
+	return e.repeat
+}
+
+func (e *mouseEvent) ClientX() float64 {
+	return e.clientX
+}
+
+func (e *mouseEvent) ClientY() float64 {
+	return e.clientY
+}
+
+func (e *mouseEvent) Button() int {
+	return e.button
+}
+
+func (e *mouseEvent) Buttons() int {
+	return e.buttons
+}
+
+func (e *inputEvent) Data() string {
+	return e.data
+}
+
+func (e *inputEvent) InputType() string {
+	return e.inputType
+}
+
+func (m modifiers) AltKey() bool {
+	return m.altKey
+}
+
+func (m modifiers) CtrlKey() bool {
+	return m.ctrlKey
+}
+
+func (m modifiers) MetaKey() bool {
+	return m.metaKey
+}
+
+func (m modifiers) ShiftKey() bool {
+	return m.shiftKey
+}
+
+func (l *eventListener) Type() string {
+	return l.kind
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// METHODS
+
+// PreventDefault cancels the event, unless it is not cancelable or the
+// listener is passive
+func (e *event) PreventDefault() {
+	if e.cancelable && !e.passive {
+		e.canceled = true
+	}
+}
+
+func (e *event) StopPropagation() {
+	e.stopped = true
+}
+
+func (e *event) StopImmediatePropagation() {
+	e.stopped = true
+	e.immediate = true
+}
+
+// Remove removes the listener from the node
+func (l *eventListener) Remove() {
+	if !l.listener.removed {
+		l.node.removeListener(l.kind, l.listener)
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// NODE METHODS
+
+// DispatchEvent dispatches the event to the node through the capture,
+// target and bubble phases, and returns false if the event was cancelled.
+// It panics if the event is already being dispatched.
+func (this *node) DispatchEvent(e dom.Event) bool {
+	b, ok := e.(baseEvent)
+	if !ok {
+		panic(dom.ErrBadParameter.Withf("unsupported event %T", e))
+	}
+	evt := b.base()
+	if evt.dispatching {
+		panic(dom.ErrBadParameter.Withf("event %q is already being dispatched", evt.kind))
+	}
+
+	// The event path is the node and its ancestors, followed by the window
+	// when the root is a document in a window. Composed events continue
+	// from a shadow root to its host, and are retargeted to the host for
+	// listeners outside the shadow tree.
+	var path []eventPathEntry
+	for n, target := this.self, this.self; n != nil; n = n.ParentNode() {
+		path = append(path, eventPathEntry{n, target})
+		if shadow, ok := n.(*shadowRoot); ok && evt.composed {
+			n, target = shadow.host.domElement(), shadow.host.domElement()
+			path = append(path, eventPathEntry{n, target})
+		}
+	}
+	var win *window
+	if doc, ok := path[len(path)-1].node.(*document); ok {
+		win = doc.window
+	}
+
+	// Listeners are called in the capture phase from the window to the
+	// node, and in the bubble phase from the node to the window, where a
+	// node which is its own target is called in the target phase
+	evt.dispatching, evt.stopped, evt.immediate = true, false, false
+	evt.target = path[len(path)-1].target
+	if win != nil {
+		evt.call(win.events, nil, dom.CAPTURING_PHASE, true)
+	}
+	for i := len(path) - 1; i >= 0 && !evt.stopped; i-- {
+		evt.target = path[i].target
+		if path[i].node == path[i].target {
+			evt.invoke(path[i].node, dom.AT_TARGET, true)
+		} else {
+			evt.invoke(path[i].node, dom.CAPTURING_PHASE, true)
+		}
+	}
+	for i := 0; i < len(path) && !evt.stopped; i++ {
+		evt.target = path[i].target
+		if path[i].node == path[i].target {
+			evt.invoke(path[i].node, dom.AT_TARGET, false)
+		} else if evt.bubbles {
+			evt.invoke(path[i].node, dom.BUBBLING_PHASE, false)
+		}
+	}
+	evt.target = path[len(path)-1].target
+	if win != nil && evt.bubbles && !evt.stopped {
+		evt.call(win.events, nil, dom.BUBBLING_PHASE, false)
+	}
+	evt.dispatching, evt.phase, evt.currentTarget = false, dom.EVENT_NONE, nil
+
+	// Return false if the event was cancelled
+	return !evt.canceled
+}
+
+// addListener registers a listener for an event type
+func (this *node) addListener(eventType string, l *listener) {
+	if this.listeners == nil {
+		this.listeners = make(map[string][]*listener)
+	}
+	this.listeners[eventType] = append(this.listeners[eventType], l)
+}
+
+// removeListener removes a listener for an event type
+func (this *node) removeListener(eventType string, l *listener) {
+	l.removed = true
+	this.listeners[eventType] = slices.DeleteFunc(this.listeners[eventType], func(other *listener) bool {
+		return other == l
+	})
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// DOCUMENT METHODS
+
+// AddEventHandler calls the callback with the event when the event is
+// dispatched to the document or its descendants, and returns a handle to
+// remove the listener
+func (this *document) AddEventHandler(eventType string, callback func(dom.Event), options ...dom.EventListenerOption) dom.EventListener {
+	l := newListener(callback, options...)
+	this.addListener(eventType, l)
+	return &eventListener{node: this.node, kind: eventType, listener: l}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// ELEMENT METHODS
+
+// AddEventListener calls the callback with the event target when the
+// event is dispatched to the element or its descendants
+func (this *element) AddEventListener(eventType string, callback func(dom.Node), options ...dom.EventListenerOption) dom.Element {
+	this.AddEventHandler(eventType, func(e dom.Event) {
+		callback(e.Target())
+	}, options...)
+	return this.domElement()
+}
+
+// AddEventHandler calls the callback with the event when the event is
+// dispatched to the element or its descendants, and returns a handle to
+// remove the listener
+func (this *element) AddEventHandler(eventType string, callback func(dom.Event), options ...dom.EventListenerOption) dom.EventListener {
+	l := newListener(callback, options...)
+	this.addListener(eventType, l)
+	return &eventListener{node: this.node, kind: eventType, listener: l}
+}
+
+// Click dispatches a click event. Checkboxes and radio buttons are checked
+// before the event is dispatched, and restored if the event is cancelled.
+// Submit buttons submit their form when the event is not cancelled.
+func (this *element) Click() {
+	if this.disabled() {
+		return
+	}
+
+	// Check a checkbox or radio button, and remember the previous state
+	input, toggle := this.self.(*inputElement)
+	toggle = toggle && (input.Type() == "checkbox" || input.Type() == "radio")
+	var checked []*inputElement
+	if toggle {
+		checked = input.check()
+	}
+
+	// Dispatch the click event, and restore the checked state if cancelled
+	if !this.DispatchEvent(newMouseEvent("click", map[string]interface{}{"bubbles": true, "cancelable": true, "composed": true})) {
+		for _, other := range checked {
+			other.checked = !other.checked
+		}
+		return
+	}
+
+	// Activation behaviour
+	switch {
+	case toggle && len(checked) > 0:
+		this.DispatchEvent(newEvent("input", true, false))
+		this.DispatchEvent(newEvent("change", true, false))
+	case this.submitButton():
+		if c, ok := this.self.(dom.FormControl); ok && c.Form() != nil {
+			if !this.HasAttribute("formnovalidate") && !c.Form().HasAttribute("novalidate") && !c.Form().CheckValidity() {
+				return
+			}
+			c.Form().Submit()
+		}
+	}
+}
+
+// Submit dispatches a cancelable submit event to a form element
+func (this *element) Submit() {
+	if this.TagName() == "FORM" {
+		this.DispatchEvent(newEvent("submit", true, true))
+	}
+}
+
+// Input sets the value of a form control, and dispatches input and change
+// events
+func (this *element) Input(value string) {
+	if this.disabled() {
+		return
+	}
+	if c, ok := this.self.(dom.FormControl); ok {
+		c.SetValue(value)
+	} else {
+		this.SetAttribute("value", value)
+	}
+	this.DispatchEvent(newInputEvent("input", map[string]interface{}{"bubbles": true, "composed": true, "data": value, "inputType": "insertReplacementText"}))
+	this.DispatchEvent(newEvent("change", true, false))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func (e *event) base() *event {
+	return e
+}
+
+// fields returns the init options of an event
+func (e *event) fields() map[string]any {
+	return map[string]any{"bubbles": &e.bubbles, "cancelable": &e.cancelable, "composed": &e.composed}
+}
+
+// fields returns the init options of the modifier keys
+func (m *modifiers) fields() map[string]any {
+	return map[string]any{"altKey": &m.altKey, "ctrlKey": &m.ctrlKey, "metaKey": &m.metaKey, "shiftKey": &m.shiftKey}
+}
+
+// parseEventInit sets the fields of an event from the init options, and
+// panics on an unknown option or a value of the wrong type
+func parseEventInit(init map[string]interface{}, fields ...map[string]any) {
+	for key, value := range init {
+		var field any
+		for _, f := range fields {
+			if v, exists := f[key]; exists {
+				field = v
+			}
+		}
+		ok := false
+		switch field := field.(type) {
+		case nil:
+			panic(dom.ErrBadParameter.Withf("unknown option %q", key))
+		case *bool:
+			*field, ok = value.(bool)
+		case *string:
+			*field, ok = value.(string)
+		case *int:
+			*field, ok = value.(int)
+		case *float64:
+			switch v := value.(type) {
+			case float64:
+				*field, ok = v, true
+			case int:
+				*field, ok = float64(v), true
+			}
+		}
+		if !ok {
+			panic(dom.ErrBadParameter.Withf("option %q: unexpected value %T", key, value))
+		}
+	}
+}
+
+// invoke calls the listeners on a node for a phase of dispatch
+func (e *event) invoke(target dom.Node, phase dom.EventPhase, capture bool) {
+	e.call(getNode(target), target, phase, capture)
+}
+
+// call calls the listeners registered on n, with the current target of
+// the event set to target
+func (e *event) call(n *node, target dom.Node, phase dom.EventPhase, capture bool) {
+	listeners := slices.Clone(n.listeners[e.kind])
+	e.currentTarget, e.phase = target, phase
+	for _, l := range listeners {
+		if l.removed || l.capture != capture {
+			continue
+		}
+		if l.once {
+			n.removeListener(e.kind, l)
+		}
+		e.passive = l.passive
+		l.callback(e.self)
+		e.passive = false
+		if e.immediate {
+			return
+		}
+	}
+}
+
+// disabled returns true for a disabled form control or option
+func (this *element) disabled() bool {
+	if c, ok := this.self.(interface{ Disabled() bool }); ok {
+		return c.Disabled()
+	}
+	return false
+}
+
+// inputType returns the lowercase type of an input element
+func (this *element) inputType() string {
+	if t := strings.ToLower(this.GetAttribute("type")); t != "" {
+		return t
+	}
+	return "text"
+}
+
+// submitButton returns true for a button or input which submits a form
+func (this *element) submitButton() bool {
+	switch this.TagName() {
+	case "BUTTON":
+		t := strings.ToLower(this.GetAttribute("type"))
+		return t == "" || t == "submit"
+	case "INPUT":
+		return this.inputType() == "submit" || this.inputType() == "image"
+	default:
+		return false
+	}
+}
+
+// check toggles a checkbox, or checks a radio button and unchecks the other
+// radio buttons in the group, and returns the inputs which changed
+func (i *inputElement) check() []*inputElement {
+	if i.Type() == "checkbox" {
+		i.SetChecked(!i.Checked())
+		return []*inputElement{i}
+	}
+	if i.Checked() {
+		return nil
+	}
+	changed := []*inputElement{i}
+	for _, other := range i.radioGroup() {
+		if other != i && other.Checked() {
+			changed = append(changed, other)
+		}
+	}
+	i.SetChecked(true)
+	return changed
+}
@@ -0,0 +1,27 @@
+//go:build !js
+
+package dom_test
+
+import (
+	"testing"
+
+	// Packages
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_InvalidInit(t *testing.T) {
+	window := domPkg.GetWindow()
+	assert.Panics(t, func() {
+		window.NewKeyboardEvent("keydown", map[string]interface{}{"clientX": 1})
+	})
+	assert.Panics(t, func() {
+		window.NewKeyboardEvent("keydown", map[string]interface{}{"key": 13})
+	})
+	assert.Panics(t, func() {
+		window.NewMouseEvent("click", map[string]interface{}{"button": 1.5})
+	})
+	assert.NotPanics(t, func() {
+		window.NewInputEvent("input", nil)
+	})
+}
@@ -0,0 +1,344 @@
+package dom_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_Dispatch(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	div := doc.CreateElement("div")
+	span := div.AppendChild(doc.CreateElement("span")).(dom.Element)
+
+	// Capture listeners on ancestors are called before bubble listeners
+	var order []string
+	div.AddEventListener("ping", func(dom.Node) { order = append(order, "div-bubble") })
+	div.AddEventListener("ping", func(dom.Node) { order = append(order, "div-capture") }, dom.EVENT_CAPTURE)
+	span.AddEventListener("ping", func(target dom.Node) {
+		assert.True(t, span.Equals(target))
+		order = append(order, "span")
+	})
+
+	assert.True(t, span.DispatchEvent(domPkg.GetWindow().NewEvent("ping", true, false)))
+	assert.Equal(t, []string{"div-capture", "span", "div-bubble"}, order)
+
+	// Events which do not bubble are not seen by bubble listeners
+	order = nil
+	span.DispatchEvent(domPkg.GetWindow().NewEvent("ping", false, false))
+	assert.Equal(t, []string{"div-capture", "span"}, order)
+}
+
+func TestEvent_DocumentWindow(t *testing.T) {
+	window := domPkg.GetWindowWithTitle("events")
+	doc := window.Document()
+	button := doc.Body().AppendChild(doc.CreateElement("button")).(dom.Element)
+
+	// Events bubble from the element to the document and then the window,
+	// and are captured in the reverse order
+	var order []string
+	window.AddEventHandler("click", func(e dom.Event) {
+		assert.True(t, button.Equals(e.Target()))
+		order = append(order, "window-bubble")
+	})
+	window.AddEventHandler("click", func(dom.Event) { order = append(order, "window-capture") }, dom.EVENT_CAPTURE)
+	doc.AddEventHandler("click", func(e dom.Event) {
+		assert.True(t, button.Equals(e.Target()))
+		assert.True(t, doc.Equals(e.CurrentTarget()))
+		order = append(order, "document-bubble")
+	})
+	doc.AddEventHandler("click", func(dom.Event) { order = append(order, "document-capture") }, dom.EVENT_CAPTURE)
+	button.AddEventListener("click", func(dom.Node) { order = append(order, "button") })
+
+	button.Click()
+	assert.Equal(t, []string{"window-capture", "document-capture", "button", "document-bubble", "window-bubble"}, order)
+
+	// Stopping propagation at the document keeps the event from the window
+	order = nil
+	stop := doc.AddEventHandler("click", func(e dom.Event) { e.StopPropagation() })
+	button.Click()
+	assert.Equal(t, []string{"window-capture", "document-capture", "button", "document-bubble"}, order)
+	stop.Remove()
+
+	// Elements which are not in the document do not reach the window
+	order = nil
+	doc.CreateElement("button").Click()
+	assert.Empty(t, order)
+}
+
+func TestEvent_Properties(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	div := doc.CreateElement("div")
+	span := div.AppendChild(doc.CreateElement("span")).(dom.Element)
+
+	evt := domPkg.GetWindow().NewEvent("ping", true, true)
+	assert.Equal(t, "ping", evt.Type())
+	assert.True(t, evt.Bubbles())
+	assert.True(t, evt.Cancelable())
+	assert.Equal(t, dom.EVENT_NONE, evt.EventPhase())
+
+	var phases []dom.EventPhase
+	div.AddEventHandler("ping", func(evt dom.Event) { phases = append(phases, evt.EventPhase()) }, dom.EVENT_CAPTURE)
+	div.AddEventHandler("ping", func(evt dom.Event) { phases = append(phases, evt.EventPhase()) })
+	span.AddEventHandler("ping", func(evt dom.Event) {
+		phases = append(phases, evt.EventPhase())
+		assert.True(t, span.Equals(evt.CurrentTarget()))
+	})
+	span.DispatchEvent(evt)
+	assert.Equal(t, []dom.EventPhase{dom.CAPTURING_PHASE, dom.AT_TARGET, dom.BUBBLING_PHASE}, phases)
+	assert.True(t, span.Equals(evt.Target()))
+	assert.Equal(t, dom.EVENT_NONE, evt.EventPhase())
+}
+
+func TestEvent_PreventDefault(t *testing.T) {
+	div := domPkg.GetWindow().Document().CreateElement("div")
+
+	listener := div.AddEventHandler("ping", func(evt dom.Event) { evt.PreventDefault() })
+
+	// Only cancelable events can be cancelled
+	evt := domPkg.GetWindow().NewEvent("ping", false, true)
+	assert.False(t, div.DispatchEvent(evt))
+	assert.True(t, evt.DefaultPrevented())
+
+	evt = domPkg.GetWindow().NewEvent("ping", false, false)
+	assert.True(t, div.DispatchEvent(evt))
+	assert.False(t, evt.DefaultPrevented())
+
+	// Passive listeners cannot cancel the event
+	listener.Remove()
+	div.AddEventHandler("ping", func(evt dom.Event) { evt.PreventDefault() }, dom.EVENT_PASSIVE)
+	assert.True(t, div.DispatchEvent(domPkg.GetWindow().NewEvent("ping", false, true)))
+}
+
+func TestEvent_StopPropagation(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	div := doc.CreateElement("div")
+	span := div.AppendChild(doc.CreateElement("span")).(dom.Element)
+
+	var calls []string
+	div.AddEventListener("ping", func(dom.Node) { calls = append(calls, "div") })
+	first := span.AddEventHandler("ping", func(evt dom.Event) {
+		calls = append(calls, "first")
+		evt.StopPropagation()
+	})
+	second := span.AddEventHandler("ping", func(dom.Event) { calls = append(calls, "second") })
+
+	// Listeners on the current target are still called
+	span.DispatchEvent(domPkg.GetWindow().NewEvent("ping", true, false))
+	assert.Equal(t, []string{"first", "second"}, calls)
+
+	// Remaining listeners are not called after StopImmediatePropagation
+	first.Remove()
+	second.Remove()
+	span.AddEventHandler("ping", func(evt dom.Event) {
+		calls = append(calls, "first")
+		evt.StopImmediatePropagation()
+	})
+	span.AddEventListener("ping", func(dom.Node) { calls = append(calls, "second") })
+	calls = nil
+	span.DispatchEvent(domPkg.GetWindow().NewEvent("ping", true, false))
+	assert.Equal(t, []string{"first"}, calls)
+}
+
+func TestEvent_Once(t *testing.T) {
+	div := domPkg.GetWindow().Document().CreateElement("div")
+
+	var once, always int
+	div.AddEventListener("ping", func(dom.Node) { once++ }, dom.EVENT_ONCE)
+	listener := div.AddEventHandler("ping", func(dom.Event) { always++ })
+	for i := 0; i < 3; i++ {
+		div.DispatchEvent(domPkg.GetWindow().NewEvent("ping", false, false))
+	}
+	assert.Equal(t, 1, once)
+	assert.Equal(t, 3, always)
+
+	// Removed listeners are not called
+	listener.Remove()
+	div.DispatchEvent(domPkg.GetWindow().NewEvent("ping", false, false))
+	assert.Equal(t, 3, always)
+}
+
+func TestEvent_ClickCheckbox(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	input := doc.CreateElement("input").(dom.HTMLInputElement)
+	input.SetAttribute("type", "checkbox")
+
+	var events []string
+	for _, eventType := range []string{"click", "input", "change"} {
+		input.AddEventListener(eventType, func(dom.Node) { events = append(events, eventType) })
+	}
+
+	// The checked state changes, but not the checked attribute
+	input.Click()
+	assert.True(t, input.Checked())
+	assert.False(t, input.HasAttribute("checked"))
+	assert.Equal(t, []string{"click", "input", "change"}, events)
+
+	input.Click()
+	assert.False(t, input.Checked())
+}
+
+func TestEvent_ClickCancel(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	div := doc.CreateElement("div")
+	input := div.AppendChild(doc.CreateElement("input")).(dom.HTMLInputElement)
+	input.SetAttribute("type", "checkbox")
+
+	// The checkbox is checked while the click is dispatched, and restored
+	// when a listener on the parent cancels the click
+	var changed bool
+	input.AddEventListener("change", func(dom.Node) { changed = true })
+	div.AddEventHandler("click", func(evt dom.Event) {
+		assert.True(t, input.Checked())
+		evt.PreventDefault()
+	})
+	input.Click()
+	assert.False(t, input.Checked())
+	assert.False(t, changed)
+}
+
+func TestEvent_ClickRadio(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	form := doc.CreateElement("form")
+	form.SetInnerHTML(`<input type="radio" name="a" value="1" checked><input type="radio" name="a" value="2"><input type="radio" name="b" checked>`)
+	radios := form.QuerySelectorAll("input")
+
+	radios[1].Click()
+	assert.False(t, radios[0].(dom.HTMLInputElement).Checked())
+	assert.True(t, radios[1].(dom.HTMLInputElement).Checked())
+	assert.True(t, radios[2].(dom.HTMLInputElement).Checked())
+}
+
+func TestEvent_ClickDisabled(t *testing.T) {
+	button := domPkg.GetWindow().Document().CreateElement("button")
+	button.SetAttribute("disabled", "")
+
+	var clicked bool
+	button.AddEventListener("click", func(dom.Node) { clicked = true })
+	button.Click()
+	assert.False(t, clicked)
+}
+
+func TestEvent_Submit(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	form := doc.CreateElement("form")
+	form.SetInnerHTML(`<input name="q"><button>Go</button><button type="button">Other</button>`)
+
+	var submits int
+	form.AddEventListener("submit", func(target dom.Node) {
+		assert.True(t, form.Equals(target))
+		submits++
+	})
+
+	// A submit button submits the form
+	form.QuerySelectorAll("button")[0].Click()
+	assert.Equal(t, 1, submits)
+
+	// Other buttons do not submit the form
+	form.QuerySelectorAll("button")[1].Click()
+	assert.Equal(t, 1, submits)
+}
+
+func TestEvent_Input(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	div := doc.CreateElement("div")
+	input := div.AppendChild(doc.CreateElement("input")).(dom.HTMLInputElement)
+
+	var events []string
+	div.AddEventListener("input", func(target dom.Node) {
+		assert.True(t, input.Equals(target))
+		events = append(events, "input")
+	})
+	div.AddEventListener("change", func(dom.Node) { events = append(events, "change") })
+
+	input.Input("hello")
+	assert.Equal(t, "hello", input.Value())
+	assert.Equal(t, []string{"input", "change"}, events)
+}
+
+func TestEvent_Handle(t *testing.T) {
+	div := domPkg.GetWindow().Document().CreateElement("div")
+
+	var calls int
+	handle := div.AddEventHandler("ping", func(dom.Event) { calls++ })
+	assert.Equal(t, "ping", handle.Type())
+	div.DispatchEvent(domPkg.GetWindow().NewEvent("ping", false, false))
+	assert.Equal(t, 1, calls)
+
+	// Removing the handle more than once has no effect
+	handle.Remove()
+	handle.Remove()
+	div.DispatchEvent(domPkg.GetWindow().NewEvent("ping", false, false))
+	assert.Equal(t, 1, calls)
+}
+
+func TestEvent_KeyboardEvent(t *testing.T) {
+	input := domPkg.GetWindow().Document().CreateElement("input")
+
+	var key dom.KeyboardEvent
+	input.AddEventHandler("keydown", func(evt dom.Event) {
+		key, _ = evt.(dom.KeyboardEvent)
+	})
+	input.DispatchEvent(domPkg.GetWindow().NewKeyboardEvent("keydown", map[string]interface{}{
+		"bubbles":  true,
+		"key":      "Enter",
+		"code":     "Enter",
+		"shiftKey": true,
+	}))
+	if assert.NotNil(t, key) {
+		assert.Equal(t, "Enter", key.Key())
+		assert.Equal(t, "Enter", key.Code())
+		assert.True(t, key.ShiftKey())
+		assert.False(t, key.CtrlKey())
+		assert.False(t, key.Repeat())
+	}
+}
+
+func TestEvent_MouseEvent(t *testing.T) {
+	button := domPkg.GetWindow().Document().CreateElement("button")
+	button.SetAttribute("type", "button")
+
+	// Click dispatches a mouse event
+	var click dom.MouseEvent
+	button.AddEventHandler("click", func(evt dom.Event) {
+		click, _ = evt.(dom.MouseEvent)
+	})
+	button.Click()
+	if assert.NotNil(t, click) {
+		assert.Equal(t, "click", click.Type())
+		assert.Equal(t, 0, click.Button())
+	}
+
+	evt := domPkg.GetWindow().NewMouseEvent("mousemove", map[string]interface{}{
+		"clientX": 10,
+		"clientY": 20.5,
+		"buttons": 1,
+		"altKey":  true,
+	})
+	assert.Equal(t, float64(10), evt.ClientX())
+	assert.Equal(t, 20.5, evt.ClientY())
+	assert.Equal(t, 1, evt.Buttons())
+	assert.True(t, evt.AltKey())
+}
+
+func TestEvent_InputEvent(t *testing.T) {
+	input := domPkg.GetWindow().Document().CreateElement("input")
+
+	// Input dispatches an input event with the value
+	var data string
+	input.AddEventHandler("input", func(evt dom.Event) {
+		if evt, ok := evt.(dom.InputEvent); ok {
+			data = evt.Data()
+		}
+	})
+	input.Input("hello")
+	assert.Equal(t, "hello", data)
+
+	evt := domPkg.GetWindow().NewInputEvent("beforeinput", map[string]interface{}{"data": "a", "inputType": "insertText"})
+	assert.Equal(t, "a", evt.Data())
+	assert.Equal(t, "insertText", evt.InputType())
+	assert.False(t, evt.Bubbles())
+}
@@ -0,0 +1,321 @@
+//go:build js
+
+package dom
+
+import (
+	"fmt"
+	"syscall/js"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	jsutil "github.com/djthorpe/go-wasmbuild/pkg/js"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type event struct {
+	*jsutil.Event
+}
+
+type keyboardEvent struct {
+	*event
+	modifiers
+}
+
+type mouseEvent struct {
+	*event
+	modifiers
+}
+
+type inputEvent struct {
+	*event
+}
+
+type popStateEvent struct {
+	*event
+}
+
+type modifiers struct {
+	value js.Value
+}
+
+// jsListener is an event listener added to an event target, and the handle
+// returned when the listener is added. The owner is set for listeners which
+// are added to an element.
+type jsListener struct {
+	owner   *element
+	target  js.Value
+	kind    string
+	fn      js.Func
+	capture bool
+	once    bool
+	removed bool
+}
+
+var _ dom.Event = (*event)(nil)
+var _ dom.KeyboardEvent = (*keyboardEvent)(nil)
+var _ dom.MouseEvent = (*mouseEvent)(nil)
+var _ dom.InputEvent = (*inputEvent)(nil)
+var _ dom.PopStateEvent = (*popStateEvent)(nil)
+var _ dom.EventListener = (*jsListener)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+var (
+	// Constructors
+	cEvent         = js.Global().Get("Event")
+	cKeyboardEvent = js.Global().Get("KeyboardEvent")
+	cMouseEvent    = js.Global().Get("MouseEvent")
+	cInputEvent    = js.Global().Get("InputEvent")
+	cPopStateEvent = js.Global().Get("PopStateEvent")
+	cStorageEvent  = js.Global().Get("StorageEvent")
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// newEvent returns an event wrapping js.Value, with the type determined by
+// the constructor of the event
+func newEvent(v js.Value) dom.Event {
+	e := &event{jsutil.NewEventFromValue(v)}
+	switch {
+	case v.InstanceOf(cKeyboardEvent):
+		return &keyboardEvent{e, modifiers{v}}
+	case v.InstanceOf(cMouseEvent):
+		return &mouseEvent{e, modifiers{v}}
+	case v.InstanceOf(cInputEvent):
+		return &inputEvent{e}
+	case v.InstanceOf(cPopStateEvent):
+		return &popStateEvent{e}
+	case v.InstanceOf(cStorageEvent):
+		return &storageEvent{e}
+	default:
+		return e
+	}
+}
+
+// NewEvent returns a new event, which can be dispatched to a node
+func (this *window) NewEvent(eventType string, bubbles, cancelable bool) dom.Event {
+	return newEvent(cEvent.New(eventType, map[string]interface{}{
+		"bubbles":    bubbles,
+		"cancelable": cancelable,
+	}))
+}
+
+// NewKeyboardEvent returns a new keyboard event
+func (this *window) NewKeyboardEvent(eventType string, init map[string]interface{}) dom.KeyboardEvent {
+	return newEvent(cKeyboardEvent.New(eventType, init)).(dom.KeyboardEvent)
+}
+
+// NewMouseEvent returns a new mouse event
+func (this *window) NewMouseEvent(eventType string, init map[string]interface{}) dom.MouseEvent {
+	return newEvent(cMouseEvent.New(eventType, init)).(dom.MouseEvent)
+}
+
+// NewInputEvent returns a new input event
+func (this *window) NewInputEvent(eventType string, init map[string]interface{}) dom.InputEvent {
+	return newEvent(cInputEvent.New(eventType, init)).(dom.InputEvent)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (e *event) String() string {
+	return fmt.Sprintf("<DOMEvent type=%q phase=%v>", e.Type(), e.EventPhase())
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+// Target returns the target of the event, or nil if the target is not a
+// node, such as the window
+func (e *event) Target() dom.Node {
+	return eventNode(e.JSValue().Get("target"))
+}
+
+func (e *event) CurrentTarget() dom.Node {
+	return eventNode(e.JSValue().Get("currentTarget"))
+}
+
+func (e *event) EventPhase() dom.EventPhase {
+	return dom.EventPhase(e.Event.EventPhase())
+}
+
+func (e *keyboardEvent) Key() string {
+	return e.JSValue().Get("key").String()
+}
+
+func (e *keyboardEvent) Code() string {
+	return e.JSValue().Get("code").String()
+}
+
+func (e *keyboardEvent) Repeat() bool {
+	return e.JSValue().Get("repeat").Bool()
+}
+
+func (e *mouseEvent) ClientX() float64 {
+	return e.JSValue().Get("clientX").Float()
+}
+
+func (e *mouseEvent) ClientY() float64 {
+	return e.JSValue().Get("clientY").Float()
+}
+
+func (e *mouseEvent) Button() int {
+	return e.JSValue().Get("button").Int()
+}
+
+func (e *mouseEvent) Buttons() int {
+	return e.JSValue().Get("buttons").Int()
+}
+
+func (e *inputEvent) Data() string {
+	if data := e.JSValue().Get("data"); data.Type() == js.TypeString {
+		return data.String()
+	}
+	return ""
+}
+
+func (e *inputEvent) InputType() string {
+	return e.JSValue().Get("inputType").String()
+}
+
+func (e *popStateEvent) State() any {
+	return decodeJSState(e.JSValue().Get("state"))
+}
+
+func (m modifiers) AltKey() bool {
+	return m.value.Get("altKey").Bool()
+}
+
+func (m modifiers) CtrlKey() bool {
+	return m.value.Get("ctrlKey").Bool()
+}
+
+func (m modifiers) MetaKey() bool {
+	return m.value.Get("metaKey").Bool()
+}
+
+func (m modifiers) ShiftKey() bool {
+	return m.value.Get("shiftKey").Bool()
+}
+
+func (l *jsListener) Type() string {
+	return l.kind
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// METHODS
+
+// Remove removes the listener from the element and releases the function
+func (l *jsListener) Remove() {
+	if l.removed {
+		return
+	}
+	l.release()
+	if l.owner == nil {
+		return
+	}
+	listeners := l.owner.eventListeners[l.kind]
+	for i, other := range listeners {
+		if other == l {
+			l.owner.eventListeners[l.kind] = append(listeners[:i], listeners[i+1:]...)
+			break
+		}
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// NODE METHODS
+
+func (this *node) DispatchEvent(e dom.Event) bool {
+	evt, ok := e.(interface{ JSValue() jsutil.Value })
+	if !ok {
+		panic(dom.ErrBadParameter.Withf("unsupported event %T", e))
+	}
+	return this.Call("dispatchEvent", evt.JSValue()).Bool()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// ELEMENT METHODS
+
+func (this *element) Click() {
+	this.Call("click")
+}
+
+// Submit requests that a form element is submitted, which dispatches a
+// cancelable submit event
+func (this *element) Submit() {
+	if this.TagName() == "FORM" {
+		this.Call("requestSubmit")
+	}
+}
+
+// Input sets the value of a form control, and dispatches input and change
+// events
+func (this *element) Input(value string) {
+	if this.Get("disabled").Truthy() {
+		return
+	}
+	this.Set("value", value)
+	this.DispatchEvent(GetWindow().NewInputEvent("input", map[string]interface{}{
+		"bubbles":   true,
+		"data":      value,
+		"inputType": "insertReplacementText",
+	}))
+	this.DispatchEvent(GetWindow().NewEvent("change", true, false))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// listenerOptions returns the options object for addEventListener
+func listenerOptions(options ...dom.EventListenerOption) js.Value {
+	var opts jsutil.EventListenerOptions
+	for _, option := range options {
+		opts.Capture = opts.Capture || option&dom.EVENT_CAPTURE != 0
+		opts.Once = opts.Once || option&dom.EVENT_ONCE != 0
+		opts.Passive = opts.Passive || option&dom.EVENT_PASSIVE != 0
+	}
+	return js.ValueOf(map[string]interface{}{
+		"capture": opts.Capture,
+		"once":    opts.Once,
+		"passive": opts.Passive,
+	})
+}
+
+// eventNode returns the node for an event target, or nil if it is not a node
+func eventNode(v js.Value) dom.Node {
+	if !v.Truthy() || !v.InstanceOf(cNode) {
+		return nil
+	}
+	return NewNode(v)
+}
+
+// addListener adds a listener for an event type to a target, which calls
+// the callback with the event. A listener which is called once is removed,
+// and its function released, when it is called.
+func addListener(target js.Value, eventType string, callback func(dom.Event), options ...dom.EventListenerOption) *jsListener {
+	opts := listenerOptions(options...)
+	l := &jsListener{target: target, kind: eventType, capture: opts.Get("capture").Bool(), once: opts.Get("once").Bool()}
+	l.fn = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if l.once {
+			l.Remove()
+		}
+		if len(args) > 0 {
+			callback(newEvent(args[0]))
+		}
+		return nil
+	})
+	target.Call("addEventListener", eventType, l.fn, opts)
+	return l
+}
+
+// release removes the listener from the target and releases the function
+func (l *jsListener) release() {
+	l.removed = true
+	l.target.Call("removeEventListener", l.kind, l.fn, map[string]interface{}{"capture": l.capture})
+	l.fn.Release()
+}
@@ -0,0 +1,712 @@
+//go:build !js
+
+package dom
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// TYPES
+
+// control is the state shared by form controls
+type control struct {
+	*element
+	customValidity string
+}
+
+type inputElement struct {
+	control
+	value        string
+	dirtyValue   bool
+	checked      bool
+	dirtyChecked bool
+}
+
+type textAreaElement struct {
+	control
+	value      string
+	dirtyValue bool
+}
+
+type selectElement struct {
+	control
+}
+
+type buttonElement struct {
+	control
+}
+
+type optionElement struct {
+	*element
+	selected      bool
+	dirtySelected bool
+}
+
+type formElement struct {
+	*element
+}
+
+// constraint is implemented by form controls to validate their value
+type constraint interface {
+	// Return true if the control is barred from constraint validation
+	barred() bool
+
+	// Return a message for the first constraint which is not satisfied
+	validationMessage() string
+}
+
+var _ dom.HTMLInputElement = (*inputElement)(nil)
+var _ dom.HTMLTextAreaElement = (*textAreaElement)(nil)
+var _ dom.HTMLSelectElement = (*selectElement)(nil)
+var _ dom.HTMLButtonElement = (*buttonElement)(nil)
+var _ dom.HTMLOptionElement = (*optionElement)(nil)
+var _ dom.HTMLFormElement = (*formElement)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+var (
+	// Input types, where other types are treated as text
+	inputTypes = []string{
+		"button", "checkbox", "color", "date", "datetime-local", "email", "file",
+		"hidden", "image", "month", "number", "password", "radio", "range",
+		"reset", "search", "submit", "tel", "text", "time", "url", "week",
+	}
+
+	// Input types where the value is the value attribute
+	inputDefaultTypes = []string{"button", "hidden", "image", "reset", "submit"}
+)
+
+/////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// newHTMLElement returns the element type for the tag name of an element
+func newHTMLElement(elem *element) dom.Element {
+	switch strings.ToLower(elem.name) {
+	case "input":
+		return &inputElement{control: control{element: elem}}
+	case "textarea":
+		return &textAreaElement{control: control{element: elem}}
+	case "select":
+		return &selectElement{control: control{element: elem}}
+	case "button":
+		return &buttonElement{control: control{element: elem}}
+	case "option":
+		return &optionElement{element: elem}
+	case "form":
+		return &formElement{element: elem}
+	case "template":
+		return newTemplateElement(elem)
+	case "slot":
+		return &slotElement{elem}
+	default:
+		return elem
+	}
+}
+
+/////////////////////////////////////////////////////////////////////
+// CONTROL PROPERTIES
+
+func (c *control) Name() string {
+	return c.GetAttribute("name")
+}
+
+// Disabled returns true if the control has a disabled attribute, or is in
+// a disabled fieldset and not in the first legend of the fieldset
+func (c *control) Disabled() bool {
+	if c.HasAttribute("disabled") {
+		return true
+	}
+	var child dom.Element = c.domElement()
+	for parent := c.ParentElement(); parent != nil; child, parent = parent, parent.ParentElement() {
+		if parent.TagName() != "FIELDSET" || !parent.HasAttribute("disabled") {
+			continue
+		}
+		if legend := parent.FirstElementChild(); legend == nil || legend.TagName() != "LEGEND" || !isInclusiveAncestor(legend, child) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *control) SetDisabled(disabled bool) {
+	setBoolAttribute(c.element, "disabled", disabled)
+}
+
+// Form returns the form with the id in the form attribute, or the nearest
+// form ancestor when there is no form attribute
+func (c *control) Form() dom.HTMLFormElement {
+	if c.HasAttribute("form") {
+		if doc, ok := rangeRoot(c).(*document); ok {
+			form, _ := doc.GetElementById(c.GetAttribute("form")).(dom.HTMLFormElement)
+			return form
+		}
+		return nil
+	}
+	form, _ := c.Closest("form").(dom.HTMLFormElement)
+	return form
+}
+
+/////////////////////////////////////////////////////////////////////
+// CONTROL METHODS
+
+// WillValidate returns true if the control is validated when the form is
+// submitted
+func (c *control) WillValidate() bool {
+	return !c.Disabled() && !c.self.(constraint).barred()
+}
+
+// ValidationMessage returns the custom validity message, or a message for
+// the first constraint which is not satisfied, or an empty string if the
+// control is valid
+func (c *control) ValidationMessage() string {
+	if !c.WillValidate() {
+		return ""
+	}
+	if c.customValidity != "" {
+		return c.customValidity
+	}
+	return c.self.(constraint).validationMessage()
+}
+
+// CheckValidity returns false and dispatches an invalid event if the
+// control is not valid
+func (c *control) CheckValidity() bool {
+	if c.ValidationMessage() == "" {
+		return true
+	}
+	c.DispatchEvent(newEvent("invalid", false, true))
+	return false
+}
+
+// SetCustomValidity sets a custom validity message, which makes the
+// control invalid unless the message is empty
+func (c *control) SetCustomValidity(message string) {
+	c.customValidity = message
+}
+
+/////////////////////////////////////////////////////////////////////
+// INPUT
+
+// Type returns the type of the input in lowercase, or "text" if the type
+// is missing or unknown
+func (i *inputElement) Type() string {
+	if t := strings.ToLower(i.GetAttribute("type")); slices.Contains(inputTypes, t) {
+		return t
+	}
+	return "text"
+}
+
+func (i *inputElement) DefaultValue() string {
+	return i.GetAttribute("value")
+}
+
+// Value returns the value of the input, which is the value attribute until
+// the value is set
+func (i *inputElement) Value() string {
+	switch t := i.Type(); {
+	case slices.Contains(inputDefaultTypes, t):
+		return i.GetAttribute("value")
+	case t == "checkbox" || t == "radio":
+		if i.HasAttribute("value") {
+			return i.GetAttribute("value")
+		}
+		return "on"
+	case i.dirtyValue:
+		return i.value
+	default:
+		return strings.NewReplacer("\r", "", "\n", "").Replace(i.GetAttribute("value"))
+	}
+}
+
+// SetValue sets the value of the input, or the value attribute for inputs
+// where the value is the value attribute
+func (i *inputElement) SetValue(value string) {
+	switch t := i.Type(); {
+	case slices.Contains(inputDefaultTypes, t), t == "checkbox", t == "radio":
+		i.SetAttribute("value", value)
+	default:
+		i.value, i.dirtyValue = value, true
+	}
+}
+
+// Checked returns true if a checkbox or radio button is checked, which is
+// the checked attribute until the checked state is set
+func (i *inputElement) Checked() bool {
+	if i.dirtyChecked {
+		return i.checked
+	}
+	return i.HasAttribute("checked")
+}
+
+// SetChecked sets the checked state, and unchecks the other radio buttons
+// in the group when a radio button is checked
+func (i *inputElement) SetChecked(checked bool) {
+	i.checked, i.dirtyChecked = checked, true
+	if checked && i.Type() == "radio" {
+		for _, other := range i.radioGroup() {
+			if other != i {
+				other.checked, other.dirtyChecked = false, true
+			}
+		}
+	}
+}
+
+func (i *inputElement) Required() bool {
+	return i.HasAttribute("required")
+}
+
+func (i *inputElement) barred() bool {
+	switch i.Type() {
+	case "hidden", "reset", "button":
+		return true
+	}
+	return i.HasAttribute("readonly")
+}
+
+func (i *inputElement) validationMessage() string {
+	value := i.Value()
+	switch t := i.Type(); {
+	case t == "checkbox":
+		if i.Required() && !i.Checked() {
+			return "Please check this box if you want to proceed."
+		}
+	case t == "radio":
+		required, checked := false, false
+		for _, other := range i.radioGroup() {
+			required = required || other.Required()
+			checked = checked || other.Checked()
+		}
+		if required && !checked {
+			return "Please select one of these options."
+		}
+	case value == "":
+		if i.Required() {
+			return "Please fill out this field."
+		}
+	case t == "email":
+		for _, address := range strings.Split(value, ",") {
+			if _, err := mail.ParseAddress(strings.TrimSpace(address)); err != nil || strings.ContainsAny(address, "<> ") {
+				return "Please enter an email address."
+			}
+			if !i.HasAttribute("multiple") {
+				break
+			}
+		}
+	case t == "url":
+		if u, err := url.Parse(value); err != nil || u.Scheme == "" {
+			return "Please enter a URL."
+		}
+	case t == "number" || t == "range":
+		return rangeMessage(i.element, value)
+	}
+	if value != "" {
+		if message := lengthMessage(i.element, value, i.dirtyValue); message != "" {
+			return message
+		}
+		if pattern := i.GetAttribute("pattern"); i.HasAttribute("pattern") {
+			if re, err := regexp.Compile("^(?:" + pattern + ")$"); err == nil && !re.MatchString(value) {
+				return "Please match the requested format."
+			}
+		}
+	}
+	return ""
+}
+
+// radioGroup returns the radio buttons with the same name in the same
+// form, including this radio button
+func (i *inputElement) radioGroup() []*inputElement {
+	name := i.Name()
+	if name == "" {
+		return []*inputElement{i}
+	}
+	form := i.Form()
+	var result []*inputElement
+	for _, elem := range querySelectorAll(getNode(rangeRoot(i)), nil, mustParseSelector("input")) {
+		if other, ok := elem.(*inputElement); ok && other.Type() == "radio" && other.Name() == name && other.Form() == form {
+			result = append(result, other)
+		}
+	}
+	return result
+}
+
+func (i *inputElement) reset() {
+	i.value, i.dirtyValue = "", false
+	i.checked, i.dirtyChecked = false, false
+}
+
+/////////////////////////////////////////////////////////////////////
+// TEXTAREA
+
+func (t *textAreaElement) DefaultValue() string {
+	return t.TextContent()
+}
+
+// Value returns the value of the textarea, which is the text content until
+// the value is set
+func (t *textAreaElement) Value() string {
+	if t.dirtyValue {
+		return t.value
+	}
+	return t.DefaultValue()
+}
+
+func (t *textAreaElement) SetValue(value string) {
+	t.value, t.dirtyValue = value, true
+}
+
+func (t *textAreaElement) Required() bool {
+	return t.HasAttribute("required")
+}
+
+func (t *textAreaElement) barred() bool {
+	return t.HasAttribute("readonly")
+}
+
+func (t *textAreaElement) validationMessage() string {
+	value := t.Value()
+	if value == "" {
+		if t.Required() {
+			return "Please fill out this field."
+		}
+		return ""
+	}
+	return lengthMessage(t.element, value, t.dirtyValue)
+}
+
+func (t *textAreaElement) reset() {
+	t.value, t.dirtyValue = "", false
+}
+
+/////////////////////////////////////////////////////////////////////
+// SELECT
+
+func (s *selectElement) Multiple() bool {
+	return s.HasAttribute("multiple")
+}
+
+func (s *selectElement) Required() bool {
+	return s.HasAttribute("required")
+}
+
+// Options returns the option elements of the select, in tree order
+func (s *selectElement) Options() []dom.HTMLOptionElement {
+	var result []dom.HTMLOptionElement
+	for _, elem := range s.QuerySelectorAll("option") {
+		if option, ok := elem.(*optionElement); ok && option.selectElement() == s {
+			result = append(result, option)
+		}
+	}
+	return result
+}
+
+// SelectedIndex returns the index of the first selected option, or -1 if
+// no option is selected
+func (s *selectElement) SelectedIndex() int {
+	for i, option := range s.Options() {
+		if option.Selected() {
+			return i
+		}
+	}
+	return -1
+}
+
+// SetSelectedIndex selects the option at the index, and deselects the
+// other options. An index of -1 deselects all options.
+func (s *selectElement) SetSelectedIndex(index int) {
+	for i, option := range s.Options() {
+		o := option.(*optionElement)
+		o.selected, o.dirtySelected = i == index, true
+	}
+}
+
+// Value returns the value of the first selected option, or an empty string
+func (s *selectElement) Value() string {
+	if i := s.SelectedIndex(); i >= 0 {
+		return s.Options()[i].Value()
+	}
+	return ""
+}
+
+// SetValue selects the first option with the value, and deselects the
+// other options
+func (s *selectElement) SetValue(value string) {
+	s.SetSelectedIndex(slices.IndexFunc(s.Options(), func(option dom.HTMLOptionElement) bool {
+		return option.Value() == value
+	}))
+}
+
+func (s *selectElement) barred() bool {
+	return false
+}
+
+// validationMessage returns a message when the select is required, and
+// no option is selected or the selected option is a placeholder
+func (s *selectElement) validationMessage() string {
+	if !s.Required() {
+		return ""
+	}
+	switch i := s.SelectedIndex(); {
+	case i < 0:
+		return "Please select an item in the list."
+	case i == 0 && !s.Multiple() && s.Options()[0].Value() == "" && s.displaySize() == 1:
+		return "Please select an item in the list."
+	}
+	return ""
+}
+
+// selectedness returns the index of the selected option in a select which
+// is not multiple, where the last option with a selected state wins. The
+// first enabled option is selected when the display size is one, unless
+// the selected state of an option has been set.
+func (s *selectElement) selectedness() int {
+	options := s.Options()
+	dirty := false
+	for i := len(options) - 1; i >= 0; i-- {
+		option := options[i].(*optionElement)
+		if option.rawSelected() {
+			return i
+		}
+		dirty = dirty || option.dirtySelected
+	}
+	if !dirty && s.displaySize() == 1 {
+		return slices.IndexFunc(options, func(option dom.HTMLOptionElement) bool {
+			return !option.Disabled()
+		})
+	}
+	return -1
+}
+
+func (s *selectElement) displaySize() int {
+	if size, err := strconv.Atoi(s.GetAttribute("size")); err == nil && size > 0 {
+		return size
+	} else if s.Multiple() {
+		return 4
+	}
+	return 1
+}
+
+func (s *selectElement) reset() {
+	for _, option := range s.Options() {
+		option.(*optionElement).dirtySelected = false
+	}
+}
+
+/////////////////////////////////////////////////////////////////////
+// BUTTON
+
+// Type returns the type of the button in lowercase, which is "submit"
+// if the type is missing or unknown
+func (b *buttonElement) Type() string {
+	switch t := strings.ToLower(b.GetAttribute("type")); t {
+	case "reset", "button":
+		return t
+	default:
+		return "submit"
+	}
+}
+
+func (b *buttonElement) Value() string {
+	return b.GetAttribute("value")
+}
+
+func (b *buttonElement) SetValue(value string) {
+	b.SetAttribute("value", value)
+}
+
+// barred returns true for buttons which do not submit a form
+func (b *buttonElement) barred() bool {
+	return b.Type() != "submit"
+}
+
+func (b *buttonElement) validationMessage() string {
+	return ""
+}
+
+/////////////////////////////////////////////////////////////////////
+// OPTION
+
+// Value returns the value attribute, or the text when there is no value
+// attribute
+func (o *optionElement) Value() string {
+	if o.HasAttribute("value") {
+		return o.GetAttribute("value")
+	}
+	return o.Text()
+}
+
+// Text returns the text content with whitespace collapsed
+func (o *optionElement) Text() string {
+	return strings.Join(strings.Fields(o.TextContent()), " ")
+}
+
+// Index returns the index of the option in its select, or zero
+func (o *optionElement) Index() int {
+	if s := o.selectElement(); s != nil {
+		for i, option := range s.Options() {
+			if option == dom.HTMLOptionElement(o) {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+// Disabled returns true if the option or its optgroup is disabled
+func (o *optionElement) Disabled() bool {
+	if o.HasAttribute("disabled") {
+		return true
+	}
+	parent := o.ParentElement()
+	return parent != nil && parent.TagName() == "OPTGROUP" && parent.HasAttribute("disabled")
+}
+
+// Selected returns true if the option is selected, where only one option
+// can be selected in a select which is not multiple
+func (o *optionElement) Selected() bool {
+	if s := o.selectElement(); s != nil && !s.Multiple() {
+		return s.selectedness() == o.Index()
+	}
+	return o.rawSelected()
+}
+
+// SetSelected selects or deselects the option, and deselects the other
+// options in a select which is not multiple
+func (o *optionElement) SetSelected(selected bool) {
+	if s := o.selectElement(); s != nil && !s.Multiple() && selected {
+		s.SetSelectedIndex(o.Index())
+		return
+	}
+	o.selected, o.dirtySelected = selected, true
+}
+
+// rawSelected returns the selected state of the option, which is the
+// selected attribute until the selected state is set
+func (o *optionElement) rawSelected() bool {
+	if o.dirtySelected {
+		return o.selected
+	}
+	return o.HasAttribute("selected")
+}
+
+// selectElement returns the select which contains the option, or nil
+func (o *optionElement) selectElement() *selectElement {
+	parent := o.ParentElement()
+	if parent != nil && parent.TagName() == "OPTGROUP" {
+		parent = parent.ParentElement()
+	}
+	s, _ := parent.(*selectElement)
+	return s
+}
+
+/////////////////////////////////////////////////////////////////////
+// FORM
+
+// Elements returns the form controls which belong to the form, in tree
+// order
+func (f *formElement) Elements() []dom.FormControl {
+	var result []dom.FormControl
+	for _, elem := range querySelectorAll(getNode(rangeRoot(f)), nil, mustParseSelector("button, input, select, textarea")) {
+		if input, ok := elem.(*inputElement); ok && input.Type() == "image" {
+			continue
+		}
+		if c, ok := elem.(dom.FormControl); ok && c.Form() == dom.HTMLFormElement(f) {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// CheckValidity returns false if any of the form controls are invalid,
+// and dispatches an invalid event to each invalid control
+func (f *formElement) CheckValidity() bool {
+	valid := true
+	for _, c := range f.Elements() {
+		if !c.CheckValidity() {
+			valid = false
+		}
+	}
+	return valid
+}
+
+// Reset dispatches a cancelable reset event, and restores the form
+// controls to their default values
+func (f *formElement) Reset() {
+	if !f.DispatchEvent(newEvent("reset", true, true)) {
+		return
+	}
+	for _, c := range f.Elements() {
+		if r, ok := c.(interface{ reset() }); ok {
+			r.reset()
+		}
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func (i *inputElement) copyTo(clone dom.Node, _ bool) {
+	if other, ok := clone.(*inputElement); ok {
+		other.value, other.dirtyValue = i.value, i.dirtyValue
+		other.checked, other.dirtyChecked = i.checked, i.dirtyChecked
+	}
+}
+
+func (t *textAreaElement) copyTo(clone dom.Node, _ bool) {
+	if other, ok := clone.(*textAreaElement); ok {
+		other.value, other.dirtyValue = t.value, t.dirtyValue
+	}
+}
+
+// setBoolAttribute sets or removes a boolean attribute
+func setBoolAttribute(elem *element, name string, value bool) {
+	if value {
+		elem.SetAttribute(name, "")
+	} else {
+		elem.RemoveAttribute(name)
+	}
+}
+
+// lengthMessage returns a message when the value is shorter than the
+// minlength attribute or longer than the maxlength attribute, which only
+// applies when the value has been set
+func lengthMessage(elem *element, value string, dirty bool) string {
+	if !dirty {
+		return ""
+	}
+	length := utf8.RuneCountInString(value)
+	if min, err := strconv.Atoi(elem.GetAttribute("minlength")); err == nil && length < min {
+		return fmt.Sprintf("Please lengthen this text to %d characters or more (you are currently using %d characters).", min, length)
+	}
+	if max, err := strconv.Atoi(elem.GetAttribute("maxlength")); err == nil && length > max {
+		return fmt.Sprintf("Please shorten this text to %d characters or less (you are currently using %d characters).", max, length)
+	}
+	return ""
+}
+
+// rangeMessage returns a message when a number is not valid, or is outside
+// the min and max attributes
+func rangeMessage(elem *element, value string) string {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return "Please enter a number."
+	}
+	if min, err := strconv.ParseFloat(elem.GetAttribute("min"), 64); err == nil && number < min {
+		return fmt.Sprintf("Value must be greater than or equal to %s.", elem.GetAttribute("min"))
+	}
+	if max, err := strconv.ParseFloat(elem.GetAttribute("max"), 64); err == nil && number > max {
+		return fmt.Sprintf("Value must be less than or equal to %s.", elem.GetAttribute("max"))
+	}
+	return ""
+}
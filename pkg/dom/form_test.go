@@ -0,0 +1,196 @@
+package dom_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func newForm(t *testing.T, html string) dom.HTMLFormElement {
+	t.Helper()
+	form, ok := domPkg.GetWindow().Document().CreateElement("form").(dom.HTMLFormElement)
+	if !assert.True(t, ok) {
+		t.FailNow()
+	}
+	form.SetInnerHTML(html)
+	return form
+}
+
+func TestForm_InputValue(t *testing.T) {
+	form := newForm(t, `<input name="q" value="default">`)
+	input := form.QuerySelector("input").(dom.HTMLInputElement)
+	assert.Equal(t, "text", input.Type())
+	assert.Equal(t, "q", input.Name())
+	assert.Equal(t, "default", input.Value())
+
+	// The value is independent of the attribute once it is set
+	input.SetValue("hello")
+	assert.Equal(t, "hello", input.Value())
+	assert.Equal(t, "default", input.DefaultValue())
+	input.SetAttribute("value", "other")
+	assert.Equal(t, "hello", input.Value())
+
+	// Reset restores the default value
+	form.Reset()
+	assert.Equal(t, "other", input.Value())
+}
+
+func TestForm_InputChecked(t *testing.T) {
+	form := newForm(t, `<input type="checkbox" checked>`)
+	input := form.QuerySelector("input").(dom.HTMLInputElement)
+	assert.True(t, input.Checked())
+	assert.Equal(t, "on", input.Value())
+
+	input.SetChecked(false)
+	assert.False(t, input.Checked())
+	assert.True(t, input.HasAttribute("checked"))
+
+	// The cloned input has the same checked state
+	clone := input.CloneNode(true).(dom.HTMLInputElement)
+	assert.False(t, clone.Checked())
+
+	form.Reset()
+	assert.True(t, input.Checked())
+}
+
+func TestForm_Radio(t *testing.T) {
+	form := newForm(t, `<input type="radio" name="a" checked><input type="radio" name="a"><input type="radio" name="b" checked>`)
+	radios := form.QuerySelectorAll("input")
+
+	radios[1].(dom.HTMLInputElement).SetChecked(true)
+	assert.False(t, radios[0].(dom.HTMLInputElement).Checked())
+	assert.True(t, radios[1].(dom.HTMLInputElement).Checked())
+	assert.True(t, radios[2].(dom.HTMLInputElement).Checked())
+}
+
+func TestForm_TextArea(t *testing.T) {
+	form := newForm(t, `<textarea>default</textarea>`)
+	textarea := form.QuerySelector("textarea").(dom.HTMLTextAreaElement)
+	assert.Equal(t, "default", textarea.Value())
+
+	textarea.SetValue("hello")
+	assert.Equal(t, "hello", textarea.Value())
+	assert.Equal(t, "default", textarea.DefaultValue())
+	assert.Equal(t, "default", textarea.TextContent())
+}
+
+func TestForm_Select(t *testing.T) {
+	form := newForm(t, `<select name="s"><option>a</option><option value="2" selected>b</option><option disabled>c</option></select>`)
+	sel := form.QuerySelector("select").(dom.HTMLSelectElement)
+	options := sel.Options()
+	if assert.Len(t, options, 3) {
+		assert.Equal(t, "a", options[0].Value())
+		assert.Equal(t, "b", options[1].Text())
+		assert.Equal(t, 2, options[2].Index())
+		assert.True(t, options[2].Disabled())
+	}
+	assert.Equal(t, 1, sel.SelectedIndex())
+	assert.Equal(t, "2", sel.Value())
+
+	// Selecting an option deselects the other options
+	options[0].SetSelected(true)
+	assert.Equal(t, 0, sel.SelectedIndex())
+	assert.False(t, options[1].Selected())
+	assert.True(t, options[1].HasAttribute("selected"))
+
+	sel.SetValue("2")
+	assert.True(t, options[1].Selected())
+	sel.SetSelectedIndex(-1)
+	assert.Equal(t, -1, sel.SelectedIndex())
+	assert.Equal(t, "", sel.Value())
+}
+
+func TestForm_SelectDefault(t *testing.T) {
+	// The first option is selected when no option is selected
+	form := newForm(t, `<select><option>a</option><option>b</option></select>`)
+	sel := form.QuerySelector("select").(dom.HTMLSelectElement)
+	assert.False(t, sel.Multiple())
+	assert.Equal(t, 0, sel.SelectedIndex())
+	assert.True(t, sel.Options()[0].Selected())
+}
+
+func TestForm_Disabled(t *testing.T) {
+	form := newForm(t, `<fieldset disabled><legend><input id="a"></legend><input id="b"></fieldset><input id="c">`)
+	a := form.QuerySelector("#a").(dom.FormControl)
+	b := form.QuerySelector("#b").(dom.FormControl)
+	c := form.QuerySelector("#c").(dom.FormControl)
+
+	// Controls in the first legend of a disabled fieldset are not disabled
+	assert.False(t, a.Disabled())
+	assert.True(t, b.Disabled())
+	assert.False(t, c.Disabled())
+
+	c.SetDisabled(true)
+	assert.True(t, c.Disabled())
+	assert.True(t, c.HasAttribute("disabled"))
+	assert.False(t, c.WillValidate())
+}
+
+func TestForm_Elements(t *testing.T) {
+	form := newForm(t, `<input name="a"><div><select name="b"></select></div><textarea name="c"></textarea><button name="d"></button><output></output>`)
+	var names []string
+	for _, c := range form.Elements() {
+		names = append(names, c.Name())
+		assert.True(t, form.Equals(c.Form()))
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d"}, names)
+}
+
+func TestForm_Validity(t *testing.T) {
+	form := newForm(t, `<input name="a" required><input name="b" type="email" value="nobody"><input name="c" value="ok">`)
+	controls := form.Elements()
+
+	var invalid []string
+	for _, c := range controls {
+		c.AddEventListener("invalid", func(target dom.Node) {
+			invalid = append(invalid, target.(dom.FormControl).Name())
+		})
+	}
+	assert.False(t, form.CheckValidity())
+	assert.Equal(t, []string{"a", "b"}, invalid)
+	assert.NotEmpty(t, controls[0].ValidationMessage())
+	assert.NotEmpty(t, controls[1].ValidationMessage())
+	assert.Empty(t, controls[2].ValidationMessage())
+
+	// Fix the values, and set a custom error
+	controls[0].SetValue("value")
+	controls[1].SetValue("somebody@example.com")
+	controls[2].SetCustomValidity("Custom error")
+	assert.True(t, controls[0].CheckValidity())
+	assert.True(t, controls[1].CheckValidity())
+	assert.False(t, controls[2].CheckValidity())
+	assert.Equal(t, "Custom error", controls[2].ValidationMessage())
+
+	controls[2].SetCustomValidity("")
+	assert.True(t, form.CheckValidity())
+}
+
+func TestForm_SubmitInvalid(t *testing.T) {
+	form := newForm(t, `<input name="a" required><button>Go</button>`)
+
+	var submits int
+	form.AddEventListener("submit", func(dom.Node) { submits++ })
+
+	// An invalid form is not submitted
+	button := form.QuerySelector("button").(dom.HTMLButtonElement)
+	assert.Equal(t, "submit", button.Type())
+	button.Click()
+	assert.Equal(t, 0, submits)
+
+	form.QuerySelector("input").(dom.HTMLInputElement).SetValue("value")
+	button.Click()
+	assert.Equal(t, 1, submits)
+}
+
+func TestForm_ResetCancel(t *testing.T) {
+	form := newForm(t, `<input value="default">`)
+	input := form.QuerySelector("input").(dom.HTMLInputElement)
+	input.SetValue("hello")
+
+	form.AddEventHandler("reset", func(evt dom.Event) { evt.PreventDefault() })
+	form.Reset()
+	assert.Equal(t, "hello", input.Value())
+}
@@ -0,0 +1,50 @@
+//go:build !js
+
+package dom_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForm_ValidationMessage(t *testing.T) {
+	tests := []struct {
+		html, value, message string
+	}{
+		{`<input required>`, "", "Please fill out this field."},
+		{`<input type="checkbox" required>`, "", "Please check this box if you want to proceed."},
+		{`<input type="url">`, "example", "Please enter a URL."},
+		{`<input pattern="[a-z]+">`, "ABC", "Please match the requested format."},
+		{`<input pattern="[a-z]+">`, "abc", ""},
+		{`<input type="number" min="1" max="10">`, "0", "Value must be greater than or equal to 1."},
+		{`<input type="number" min="1" max="10">`, "11", "Value must be less than or equal to 10."},
+		{`<input type="number">`, "x", "Please enter a number."},
+		{`<input minlength="3">`, "ab", "Please lengthen this text to 3 characters or more (you are currently using 2 characters)."},
+		{`<textarea maxlength="2"></textarea>`, "abc", "Please shorten this text to 2 characters or less (you are currently using 3 characters)."},
+		{`<select required><option value="">Choose</option><option>a</option></select>`, "", "Please select an item in the list."},
+		{`<input required readonly>`, "", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.html, func(t *testing.T) {
+			control := newForm(t, test.html).Elements()[0]
+			if test.value != "" {
+				control.SetValue(test.value)
+			}
+			assert.Equal(t, test.message, control.ValidationMessage())
+		})
+	}
+}
+
+func TestForm_FormAttribute(t *testing.T) {
+	doc := readDocument(t, `<form id="f"></form><input name="a" form="f"><form><input name="b" form="f"></form>`)
+	form := doc.GetElementById("f").(dom.HTMLFormElement)
+
+	var names []string
+	for _, c := range form.Elements() {
+		names = append(names, c.Name())
+	}
+	assert.Equal(t, []string{"a", "b"}, names)
+}
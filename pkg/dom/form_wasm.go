@@ -0,0 +1,237 @@
+//go:build js
+
+package dom
+
+import (
+	"syscall/js"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// TYPES
+
+// control implements the properties shared by form controls
+type control struct {
+	*element
+}
+
+type inputElement struct {
+	control
+}
+
+type textAreaElement struct {
+	control
+}
+
+type selectElement struct {
+	control
+}
+
+type buttonElement struct {
+	control
+}
+
+type optionElement struct {
+	*element
+}
+
+type formElement struct {
+	*element
+}
+
+var _ dom.HTMLInputElement = (*inputElement)(nil)
+var _ dom.HTMLTextAreaElement = (*textAreaElement)(nil)
+var _ dom.HTMLSelectElement = (*selectElement)(nil)
+var _ dom.HTMLButtonElement = (*buttonElement)(nil)
+var _ dom.HTMLOptionElement = (*optionElement)(nil)
+var _ dom.HTMLFormElement = (*formElement)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+var (
+	cHTMLInputElement    = js.Global().Get("HTMLInputElement")
+	cHTMLTextAreaElement = js.Global().Get("HTMLTextAreaElement")
+	cHTMLSelectElement   = js.Global().Get("HTMLSelectElement")
+	cHTMLButtonElement   = js.Global().Get("HTMLButtonElement")
+	cHTMLOptionElement   = js.Global().Get("HTMLOptionElement")
+	cHTMLFormElement     = js.Global().Get("HTMLFormElement")
+)
+
+/////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// newHTMLElement returns the element type for a prototype, or nil if the
+// prototype is not a form, template or slot element
+func newHTMLElement(proto, v js.Value) dom.Element {
+	elem := func() *element { return &element{node: &node{v}} }
+	switch {
+	case proto.Equal(cHTMLInputElement.Get("prototype")):
+		return &inputElement{control{elem()}}
+	case proto.Equal(cHTMLTextAreaElement.Get("prototype")):
+		return &textAreaElement{control{elem()}}
+	case proto.Equal(cHTMLSelectElement.Get("prototype")):
+		return &selectElement{control{elem()}}
+	case proto.Equal(cHTMLButtonElement.Get("prototype")):
+		return &buttonElement{control{elem()}}
+	case proto.Equal(cHTMLOptionElement.Get("prototype")):
+		return &optionElement{elem()}
+	case proto.Equal(cHTMLFormElement.Get("prototype")):
+		return &formElement{elem()}
+	case proto.Equal(cHTMLTemplateElement.Get("prototype")):
+		return &templateElement{elem()}
+	case proto.Equal(cHTMLSlotElement.Get("prototype")):
+		return &slotElement{elem()}
+	default:
+		return nil
+	}
+}
+
+/////////////////////////////////////////////////////////////////////
+// CONTROL PROPERTIES
+
+func (c *control) Name() string {
+	return c.Get("name").String()
+}
+
+func (c *control) Value() string {
+	return c.Get("value").String()
+}
+
+func (c *control) SetValue(value string) {
+	c.Set("value", value)
+}
+
+func (c *control) Disabled() bool {
+	return c.Get("disabled").Bool() || c.Call("matches", ":disabled").Bool()
+}
+
+func (c *control) SetDisabled(disabled bool) {
+	c.Set("disabled", disabled)
+}
+
+func (c *control) Form() dom.HTMLFormElement {
+	form, _ := NewNode(c.Get("form")).(dom.HTMLFormElement)
+	return form
+}
+
+func (c *control) Required() bool {
+	return c.Get("required").Bool()
+}
+
+func (c *control) Type() string {
+	return c.Get("type").String()
+}
+
+func (c *control) DefaultValue() string {
+	return c.Get("defaultValue").String()
+}
+
+/////////////////////////////////////////////////////////////////////
+// CONTROL METHODS
+
+func (c *control) WillValidate() bool {
+	return c.Get("willValidate").Bool()
+}
+
+func (c *control) CheckValidity() bool {
+	return c.Call("checkValidity").Bool()
+}
+
+func (c *control) ValidationMessage() string {
+	return c.Get("validationMessage").String()
+}
+
+func (c *control) SetCustomValidity(message string) {
+	c.Call("setCustomValidity", message)
+}
+
+/////////////////////////////////////////////////////////////////////
+// INPUT
+
+func (i *inputElement) Checked() bool {
+	return i.Get("checked").Bool()
+}
+
+func (i *inputElement) SetChecked(checked bool) {
+	i.Set("checked", checked)
+}
+
+/////////////////////////////////////////////////////////////////////
+// SELECT
+
+func (s *selectElement) Multiple() bool {
+	return s.Get("multiple").Bool()
+}
+
+func (s *selectElement) Options() []dom.HTMLOptionElement {
+	options := s.Get("options")
+	length := options.Get("length").Int()
+	result := make([]dom.HTMLOptionElement, 0, length)
+	for i := 0; i < length; i++ {
+		result = append(result, NewNode(options.Call("item", i)).(dom.HTMLOptionElement))
+	}
+	return result
+}
+
+func (s *selectElement) SelectedIndex() int {
+	return s.Get("selectedIndex").Int()
+}
+
+func (s *selectElement) SetSelectedIndex(index int) {
+	s.Set("selectedIndex", index)
+}
+
+/////////////////////////////////////////////////////////////////////
+// OPTION
+
+func (o *optionElement) Value() string {
+	return o.Get("value").String()
+}
+
+func (o *optionElement) Text() string {
+	return o.Get("text").String()
+}
+
+func (o *optionElement) Index() int {
+	return o.Get("index").Int()
+}
+
+func (o *optionElement) Disabled() bool {
+	return o.Call("matches", ":disabled").Bool()
+}
+
+func (o *optionElement) Selected() bool {
+	return o.Get("selected").Bool()
+}
+
+func (o *optionElement) SetSelected(selected bool) {
+	o.Set("selected", selected)
+}
+
+/////////////////////////////////////////////////////////////////////
+// FORM
+
+// Elements returns the form controls which belong to the form, in tree
+// order
+func (f *formElement) Elements() []dom.FormControl {
+	elements := f.Get("elements")
+	length := elements.Get("length").Int()
+	result := make([]dom.FormControl, 0, length)
+	for i := 0; i < length; i++ {
+		if c, ok := NewNode(elements.Call("item", i)).(dom.FormControl); ok {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func (f *formElement) CheckValidity() bool {
+	return f.Call("checkValidity").Bool()
+}
+
+func (f *formElement) Reset() {
+	f.Call("reset")
+}
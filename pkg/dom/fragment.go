@@ -0,0 +1,86 @@
+//go:build !js
+
+package dom
+
+import (
+	"fmt"
+	"strings"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// TYPES
+
+type fragment struct {
+	*node
+}
+
+var _ dom.DocumentFragment = (*fragment)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (this *fragment) String() string {
+	var b strings.Builder
+	b.WriteString("<DOMDocumentFragment")
+	for c := this.FirstChild(); c != nil; c = c.NextSibling() {
+		fmt.Fprint(&b, " child=", c)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+/////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (this *fragment) Children() []dom.Element {
+	var result []dom.Element
+	for _, child := range this.node.children {
+		if elem, ok := child.(dom.Element); ok {
+			result = append(result, elem)
+		}
+	}
+	return result
+}
+
+func (this *fragment) ChildElementCount() int {
+	return len(this.Children())
+}
+
+func (this *fragment) FirstElementChild() dom.Element {
+	for _, child := range this.node.children {
+		if elem, ok := child.(dom.Element); ok {
+			return elem
+		}
+	}
+	return nil
+}
+
+func (this *fragment) LastElementChild() dom.Element {
+	for i := len(this.node.children) - 1; i >= 0; i-- {
+		if elem, ok := this.node.children[i].(dom.Element); ok {
+			return elem
+		}
+	}
+	return nil
+}
+
+/////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (this *fragment) QuerySelector(sel string) dom.Element {
+	return querySelector(this.node, nil, mustParseSelector(sel))
+}
+
+func (this *fragment) QuerySelectorAll(sel string) []dom.Element {
+	return querySelectorAll(this.node, nil, mustParseSelector(sel))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func (this *fragment) v() *node {
+	return this.node
+}
@@ -0,0 +1,95 @@
+package dom_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFragment_Create(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	fragment := doc.CreateDocumentFragment()
+	if !assert.NotNil(t, fragment) {
+		t.FailNow()
+	}
+	assert.Equal(t, dom.DOCUMENT_FRAGMENT_NODE, fragment.NodeType())
+	assert.Equal(t, "#document-fragment", fragment.NodeName())
+	assert.Nil(t, fragment.ParentNode())
+	assert.False(t, fragment.HasChildNodes())
+	assert.Nil(t, fragment.FirstElementChild())
+}
+
+func TestFragment_Children(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	fragment := doc.CreateDocumentFragment()
+	fragment.AppendChild(doc.CreateTextNode("text"))
+	a := fragment.AppendChild(doc.CreateElement("a"))
+	b := fragment.AppendChild(doc.CreateElement("b")).(dom.Element)
+	b.SetAttribute("class", "bold")
+
+	assert.Len(t, fragment.ChildNodes(), 3)
+	assert.Len(t, fragment.Children(), 2)
+	assert.Equal(t, 2, fragment.ChildElementCount())
+	assert.True(t, a.Equals(fragment.FirstElementChild()))
+	assert.True(t, b.Equals(fragment.LastElementChild()))
+	assert.True(t, b.Equals(fragment.QuerySelector(".bold")))
+	assert.Len(t, fragment.QuerySelectorAll("a, b"), 2)
+
+	// Children of a fragment are not connected
+	assert.False(t, a.IsConnected())
+	assert.Nil(t, a.ParentElement())
+}
+
+func TestFragment_AppendChild(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	fragment := doc.CreateDocumentFragment()
+	for _, text := range []string{"One", "Two", "Three"} {
+		li := fragment.AppendChild(doc.CreateElement("li"))
+		li.AppendChild(doc.CreateTextNode(text))
+	}
+
+	// Appending the fragment moves the children
+	ul := doc.CreateElement("ul")
+	ul.AppendChild(fragment)
+	assert.Equal(t, "<ul><li>One</li><li>Two</li><li>Three</li></ul>", ul.OuterHTML())
+	assert.False(t, fragment.HasChildNodes())
+	for _, child := range ul.ChildNodes() {
+		assert.True(t, ul.Equals(child.ParentNode()))
+	}
+
+	// Appending an empty fragment has no effect
+	ul.AppendChild(fragment)
+	assert.Equal(t, 3, ul.ChildElementCount())
+}
+
+func TestFragment_InsertBefore(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	p := doc.CreateElement("p")
+	p.AppendChild(doc.CreateTextNode("a"))
+	ref := p.AppendChild(doc.CreateTextNode("d"))
+
+	fragment := doc.CreateDocumentFragment()
+	fragment.AppendChild(doc.CreateTextNode("b"))
+	fragment.AppendChild(doc.CreateElement("br"))
+	fragment.AppendChild(doc.CreateTextNode("c"))
+
+	p.InsertBefore(fragment, ref)
+	assert.Equal(t, "<p>ab<br>cd</p>", p.OuterHTML())
+	assert.False(t, fragment.HasChildNodes())
+}
+
+func TestFragment_Connected(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	fragment := doc.CreateDocumentFragment()
+	div := fragment.AppendChild(doc.CreateElement("div"))
+	assert.False(t, div.IsConnected())
+
+	body := doc.Body()
+	body.AppendChild(fragment)
+	defer body.RemoveChild(div)
+	assert.True(t, div.IsConnected())
+	assert.True(t, body.Equals(div.ParentNode()))
+}
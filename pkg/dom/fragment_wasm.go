@@ -0,0 +1,92 @@
+//go:build js
+
+package dom
+
+import (
+	"fmt"
+	"syscall/js"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// TYPES
+
+type fragment struct {
+	*node
+}
+
+var _ dom.DocumentFragment = (*fragment)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (this *fragment) String() string {
+	str := "<DOMDocumentFragment"
+	for c := this.FirstChild(); c != nil; c = c.NextSibling() {
+		str += fmt.Sprint(" child=", c)
+	}
+	return str + ">"
+}
+
+/////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (this *fragment) Children() []dom.Element {
+	children := this.Get("children")
+	length := children.Get("length").Int()
+	result := make([]dom.Element, 0, length)
+	for i := 0; i < length; i++ {
+		result = append(result, NewNode(children.Index(i)).(dom.Element))
+	}
+	return result
+}
+
+func (this *fragment) ChildElementCount() int {
+	return this.Get("childElementCount").Int()
+}
+
+func (this *fragment) FirstElementChild() dom.Element {
+	child := this.Get("firstElementChild")
+	if child.IsNull() {
+		return nil
+	}
+	return NewNode(child).(dom.Element)
+}
+
+func (this *fragment) LastElementChild() dom.Element {
+	child := this.Get("lastElementChild")
+	if child.IsNull() {
+		return nil
+	}
+	return NewNode(child).(dom.Element)
+}
+
+/////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (this *fragment) QuerySelector(selector string) dom.Element {
+	result := this.Call("querySelector", selector)
+	if result.IsNull() {
+		return nil
+	}
+	return NewNode(result).(dom.Element)
+}
+
+func (this *fragment) QuerySelectorAll(selector string) []dom.Element {
+	nodeList := this.Call("querySelectorAll", selector)
+	length := nodeList.Get("length").Int()
+	result := make([]dom.Element, 0, length)
+	for i := 0; i < length; i++ {
+		result = append(result, NewNode(nodeList.Index(i)).(dom.Element))
+	}
+	return result
+}
+
+/////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func (this *fragment) v() js.Value {
+	return this.node.v()
+}
@@ -0,0 +1,51 @@
+package dom
+
+import (
+	"fmt"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	// The dataset key which names a slot in template content
+	slotKey = "slot"
+)
+
+/////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Instantiate returns a deep clone of the content of a template, where the
+// children of each element with a data-slot attribute are replaced with the
+// value for the slot. A node value is inserted, and any other value is
+// inserted as text. Slots without a value are left unchanged.
+func Instantiate(template dom.HTMLTemplateElement, slots map[string]any) dom.DocumentFragment {
+	content := template.Content().CloneNode(true).(dom.DocumentFragment)
+	for _, elem := range content.QuerySelectorAll("[data-" + slotKey + "]") {
+		if value, exists := slots[elem.Dataset().Get(slotKey)]; exists {
+			bindSlot(elem, value)
+		}
+	}
+	return content
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// bindSlot replaces the children of an element with a value
+func bindSlot(elem dom.Element, value any) {
+	for child := elem.FirstChild(); child != nil; child = elem.FirstChild() {
+		elem.RemoveChild(child)
+	}
+	switch value := value.(type) {
+	case nil:
+		return
+	case dom.Node:
+		elem.AppendChild(value)
+	default:
+		elem.AppendChild(elem.OwnerDocument().CreateTextNode(fmt.Sprint(value)))
+	}
+}
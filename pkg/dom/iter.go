@@ -0,0 +1,76 @@
+package dom
+
+import (
+	"iter"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Descendants yields the descendants of the node in tree order. The next
+// node is determined after each node is yielded, so the tree can be
+// modified during iteration.
+func (this *node) Descendants() iter.Seq[dom.Node] {
+	return func(yield func(dom.Node) bool) {
+		for n := this.FirstChild(); n != nil; n = nextInTree(n, this, true) {
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// Ancestors yields the parent of the node, and its ancestors up to the root
+func (this *node) Ancestors() iter.Seq[dom.Node] {
+	return func(yield func(dom.Node) bool) {
+		for n := this.ParentNode(); n != nil; n = n.ParentNode() {
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// NextSiblings yields the siblings after the node
+func (this *node) NextSiblings() iter.Seq[dom.Node] {
+	return func(yield func(dom.Node) bool) {
+		for n := this.NextSibling(); n != nil; n = n.NextSibling() {
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// PreviousSiblings yields the siblings before the node, nearest first
+func (this *node) PreviousSiblings() iter.Seq[dom.Node] {
+	return func(yield func(dom.Node) bool) {
+		for n := this.PreviousSibling(); n != nil; n = n.PreviousSibling() {
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+/////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// nextInTree returns the node after n in tree order within root, or nil.
+// The children of n are skipped when children is false.
+func nextInTree(n, root dom.Node, children bool) dom.Node {
+	if children {
+		if child := n.FirstChild(); child != nil {
+			return child
+		}
+	}
+	for ; n != nil && !n.Equals(root); n = n.ParentNode() {
+		if next := n.NextSibling(); next != nil {
+			return next
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+//go:build !js
+
+package dom
+
+import (
+	"net/url"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// history is the session history of a window, where each entry has a URL
+// and a state encoded as JSON
+type history struct {
+	window  *window
+	entries []historyEntry
+	index   int
+}
+
+type historyEntry struct {
+	url   *url.URL
+	state string
+}
+
+// location reads and changes the URL of the current history entry
+type location struct {
+	history *history
+}
+
+type popStateEvent struct {
+	*event
+	state string
+}
+
+var _ dom.History = (*history)(nil)
+var _ dom.Location = (*location)(nil)
+var _ dom.PopStateEvent = (*popStateEvent)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+func newHistory(window *window, href string) *history {
+	u, err := url.Parse(href)
+	if err != nil {
+		panic(dom.ErrBadParameter.With(err))
+	}
+	return &history{window: window, entries: []historyEntry{{url: u}}}
+}
+
+func newPopStateEvent(state string) *popStateEvent {
+	e := &popStateEvent{event: newEvent("popstate", false, false), state: state}
+	e.self = e
+	return e
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+// Location returns the location of the window
+func (this *window) Location() dom.Location {
+	return &location{this.history}
+}
+
+// History returns the session history of the window
+func (this *window) History() dom.History {
+	return this.history
+}
+
+func (this *history) Length() int {
+	return len(this.entries)
+}
+
+func (this *history) State() any {
+	return decodeState(this.entries[this.index].state)
+}
+
+func (this *location) URL() *url.URL {
+	u := *this.url()
+	return &u
+}
+
+func (this *location) Href() string {
+	return this.url().String()
+}
+
+func (this *location) Origin() string {
+	return this.url().Scheme + "://" + this.url().Host
+}
+
+func (this *location) Protocol() string {
+	return this.url().Scheme + ":"
+}
+
+func (this *location) Host() string {
+	return this.url().Host
+}
+
+func (this *location) Hostname() string {
+	return this.url().Hostname()
+}
+
+func (this *location) Port() string {
+	return this.url().Port()
+}
+
+func (this *location) Pathname() string {
+	if path := this.url().EscapedPath(); path != "" {
+		return path
+	}
+	return "/"
+}
+
+func (this *location) Search() string {
+	if query := this.url().RawQuery; query != "" {
+		return "?" + query
+	}
+	return ""
+}
+
+func (this *location) Hash() string {
+	if fragment := this.url().EscapedFragment(); fragment != "" {
+		return "#" + fragment
+	}
+	return ""
+}
+
+func (e *popStateEvent) State() any {
+	return decodeState(e.state)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// PushState adds an entry to the history after the current entry, and
+// removes any entries after it. The URL must have the same origin as the
+// current URL, and an empty URL keeps the current URL.
+func (this *history) PushState(state any, url string) {
+	entry := historyEntry{url: this.resolve(url, true), state: encodeState(state)}
+	this.entries = append(this.entries[:this.index+1], entry)
+	this.index++
+}
+
+// ReplaceState replaces the state and URL of the current entry
+func (this *history) ReplaceState(state any, url string) {
+	this.entries[this.index] = historyEntry{url: this.resolve(url, true), state: encodeState(state)}
+}
+
+func (this *history) Back() {
+	this.Go(-1)
+}
+
+func (this *history) Forward() {
+	this.Go(1)
+}
+
+// Go moves through the history by delta entries, and dispatches a popstate
+// event to the window, and a hashchange event when only the fragment of the
+// URL has changed. It has no effect when delta is zero or out of range.
+func (this *history) Go(delta int) {
+	index := this.index + delta
+	if delta == 0 || index < 0 || index >= len(this.entries) {
+		return
+	}
+	from := this.entries[this.index].url
+	this.index = index
+	this.window.DispatchEvent(newPopStateEvent(this.entries[index].state))
+	if hashChanged(from, this.entries[index].url) {
+		this.window.DispatchEvent(newEvent("hashchange", false, false))
+	}
+}
+
+// Assign adds an entry for the URL to the history, and dispatches a
+// hashchange event to the window when only the fragment has changed
+func (this *location) Assign(url string) {
+	from := this.url()
+	this.history.entries = append(this.history.entries[:this.history.index+1], historyEntry{url: this.history.resolve(url, false)})
+	this.history.index++
+	if hashChanged(from, this.url()) {
+		this.history.window.DispatchEvent(newEvent("hashchange", false, false))
+	}
+}
+
+// Replace replaces the current entry with the URL, and clears the state
+func (this *location) Replace(url string) {
+	this.history.entries[this.history.index] = historyEntry{url: this.history.resolve(url, false)}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func (this *location) url() *url.URL {
+	return this.history.entries[this.history.index].url
+}
+
+// resolve returns a URL relative to the current URL, and panics if it
+// cannot be parsed, or if sameOrigin is true and it has a different origin
+func (this *history) resolve(ref string, sameOrigin bool) *url.URL {
+	current := this.entries[this.index].url
+	if ref == "" {
+		return current
+	}
+	u, err := current.Parse(ref)
+	if err != nil {
+		panic(dom.ErrBadParameter.With(err))
+	}
+	if sameOrigin && (u.Scheme != current.Scheme || u.Host != current.Host) {
+		panic(dom.ErrBadParameter.Withf("%q does not have the origin of %q", ref, current))
+	}
+	return u
+}
+
+// hashChanged returns true if two URLs only differ by their fragment
+func hashChanged(from, to *url.URL) bool {
+	if from.Fragment == to.Fragment {
+		return false
+	}
+	a, b := *from, *to
+	a.Fragment, a.RawFragment, b.Fragment, b.RawFragment = "", "", "", ""
+	return a.String() == b.String()
+}
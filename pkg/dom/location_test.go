@@ -0,0 +1,99 @@
+//go:build !js
+
+package dom_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocation_Properties(t *testing.T) {
+	location := domPkg.GetWindowWithTitle("").Location()
+	assert.Equal(t, "http://localhost/", location.Href())
+
+	location.Assign("https://example.com:8080/employees/list?page=2#top")
+	assert.Equal(t, "https://example.com:8080", location.Origin())
+	assert.Equal(t, "https:", location.Protocol())
+	assert.Equal(t, "example.com:8080", location.Host())
+	assert.Equal(t, "example.com", location.Hostname())
+	assert.Equal(t, "8080", location.Port())
+	assert.Equal(t, "/employees/list", location.Pathname())
+	assert.Equal(t, "?page=2", location.Search())
+	assert.Equal(t, "#top", location.Hash())
+	assert.Equal(t, "page=2", location.URL().RawQuery)
+
+	// URLs are resolved against the current URL
+	location.Replace("../departments")
+	assert.Equal(t, "https://example.com:8080/departments", location.Href())
+	assert.Equal(t, "", location.Search())
+	assert.Equal(t, "", location.Hash())
+	assert.Panics(t, func() { location.Assign("http://[::1") })
+}
+
+func TestHistory_PushState(t *testing.T) {
+	window := domPkg.GetWindowWithTitle("")
+	history := window.History()
+	assert.Equal(t, 1, history.Length())
+	assert.Nil(t, history.State())
+
+	// State is stored as JSON
+	history.PushState(map[string]any{"page": 1}, "/employees?page=1")
+	history.PushState(struct {
+		Page int `json:"page"`
+	}{2}, "?page=2")
+	assert.Equal(t, 3, history.Length())
+	assert.Equal(t, map[string]any{"page": float64(2)}, history.State())
+	assert.Equal(t, "http://localhost/employees?page=2", window.Location().Href())
+
+	// Replacing the state keeps the length, and an empty URL keeps the URL
+	history.ReplaceState("replaced", "")
+	assert.Equal(t, 3, history.Length())
+	assert.Equal(t, "replaced", history.State())
+	assert.Equal(t, "/employees", window.Location().Pathname())
+
+	// The URL must have the same origin, and the state must be encodable
+	assert.Panics(t, func() { history.PushState(nil, "https://example.com/") })
+	assert.Panics(t, func() { history.PushState(func() {}, "") })
+}
+
+func TestHistory_PopState(t *testing.T) {
+	window := domPkg.GetWindowWithTitle("")
+	history := window.History()
+	history.PushState(1, "/one")
+	history.PushState(2, "/two")
+
+	var events []string
+	var states []any
+	window.AddEventHandler("popstate", func(e dom.Event) {
+		assert.Nil(t, e.Target())
+		events = append(events, e.Type()+" "+window.Location().Pathname())
+		states = append(states, e.(dom.PopStateEvent).State())
+	})
+	window.AddEventHandler("hashchange", func(e dom.Event) {
+		events = append(events, e.Type()+" "+window.Location().Hash())
+	})
+
+	history.Back()
+	history.Go(-1)
+	history.Back()
+	history.Go(2)
+	assert.Equal(t, []string{"popstate /one", "popstate /", "popstate /two"}, events)
+	assert.Equal(t, []any{float64(1), nil, float64(2)}, states)
+
+	// Pushing a state removes the entries after the current entry
+	history.Back()
+	history.PushState(3, "/three")
+	history.Forward()
+	assert.Equal(t, 3, history.Length())
+	assert.Equal(t, float64(3), history.State())
+
+	// Changing only the fragment dispatches a hashchange event
+	events = nil
+	window.Location().Assign("#section")
+	history.Back()
+	assert.Equal(t, []string{"hashchange #section", "popstate /three", "hashchange "}, events)
+}
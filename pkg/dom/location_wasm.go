@@ -0,0 +1,171 @@
+//go:build js
+
+package dom
+
+import (
+	"net/url"
+	"syscall/js"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type location struct {
+	js.Value
+}
+
+type history struct {
+	js.Value
+}
+
+var _ dom.Location = (*location)(nil)
+var _ dom.History = (*history)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+var (
+	jsJSON = js.Global().Get("JSON")
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (this *window) Location() dom.Location {
+	return &location{this.Get("location")}
+}
+
+func (this *window) History() dom.History {
+	return &history{this.Get("history")}
+}
+
+// URL returns the parsed URL, or nil if it cannot be parsed
+func (this *location) URL() *url.URL {
+	u, err := url.Parse(this.Href())
+	if err != nil {
+		return nil
+	}
+	return u
+}
+
+func (this *location) Href() string {
+	return this.Get("href").String()
+}
+
+func (this *location) Origin() string {
+	return this.Get("origin").String()
+}
+
+func (this *location) Protocol() string {
+	return this.Get("protocol").String()
+}
+
+func (this *location) Host() string {
+	return this.Get("host").String()
+}
+
+func (this *location) Hostname() string {
+	return this.Get("hostname").String()
+}
+
+func (this *location) Port() string {
+	return this.Get("port").String()
+}
+
+func (this *location) Pathname() string {
+	return this.Get("pathname").String()
+}
+
+func (this *location) Search() string {
+	return this.Get("search").String()
+}
+
+func (this *location) Hash() string {
+	return this.Get("hash").String()
+}
+
+func (this *history) Length() int {
+	return this.Get("length").Int()
+}
+
+func (this *history) State() any {
+	return decodeJSState(this.Get("state"))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (this *location) Assign(url string) {
+	this.Call("assign", this.resolve(url))
+}
+
+func (this *location) Replace(url string) {
+	this.Call("replace", this.resolve(url))
+}
+
+func (this *history) PushState(state any, url string) {
+	this.Call("pushState", encodeJSState(state), "", historyURL(url))
+}
+
+func (this *history) ReplaceState(state any, url string) {
+	this.Call("replaceState", encodeJSState(state), "", historyURL(url))
+}
+
+func (this *history) Back() {
+	this.Call("back")
+}
+
+func (this *history) Forward() {
+	this.Call("forward")
+}
+
+func (this *history) Go(delta int) {
+	if delta != 0 {
+		this.Call("go", delta)
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// resolve returns a URL relative to the current URL, and panics if it
+// cannot be parsed
+func (this *location) resolve(ref string) string {
+	u, err := url.Parse(this.Href())
+	if err == nil {
+		u, err = u.Parse(ref)
+	}
+	if err != nil {
+		panic(dom.ErrBadParameter.With(err))
+	}
+	return u.String()
+}
+
+// historyURL returns the URL argument for pushState and replaceState, which
+// is undefined to keep the current URL
+func historyURL(url string) js.Value {
+	if url == "" {
+		return js.Undefined()
+	}
+	return js.ValueOf(url)
+}
+
+// encodeJSState returns a state as a value which can be cloned, by
+// encoding it as JSON
+func encodeJSState(state any) js.Value {
+	if json := encodeState(state); json != "" {
+		return jsJSON.Call("parse", json)
+	}
+	return js.Null()
+}
+
+// decodeJSState returns the value of a state which was encoded as JSON
+func decodeJSState(v js.Value) any {
+	if v.IsNull() || v.IsUndefined() {
+		return nil
+	}
+	return decodeState(jsJSON.Call("stringify", v).String())
+}
@@ -0,0 +1,280 @@
+//go:build !js
+
+package dom
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// mediaQueryList evaluates a media query against the viewport of a window.
+// Lists with change listeners are kept by the window, so they can be
+// evaluated when it is resized.
+type mediaQueryList struct {
+	window  *window
+	media   string
+	query   func(*window) bool
+	matches bool
+	events  *node
+}
+
+var _ dom.MediaQueryList = (*mediaQueryList)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+var (
+	reMediaAnd     = regexp.MustCompile(`\s+and\s+`)
+	reMediaFeature = regexp.MustCompile(`^\(\s*([a-z-]+)\s*(?::\s*([^)]*?)\s*)?\)$`)
+	reMediaLength  = regexp.MustCompile(`^([0-9]*\.?[0-9]+)(px|r?em)?$`)
+)
+
+const (
+	// The number of pixels in an em
+	emPixels = 16
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// MatchMedia returns a list for a media query, which does not match when
+// the query cannot be parsed. The viewport is a screen which can hover, with
+// a fine pointer and the light color scheme.
+func (this *window) MatchMedia(media string) dom.MediaQueryList {
+	mql := &mediaQueryList{window: this, media: media, query: parseMediaQueryList(media), events: &node{}}
+	mql.matches = mql.query(this)
+	return mql
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (this *window) InnerWidth() int {
+	return this.width
+}
+
+func (this *window) InnerHeight() int {
+	return this.height
+}
+
+func (this *mediaQueryList) Media() string {
+	return this.media
+}
+
+func (this *mediaQueryList) Matches() bool {
+	return this.query(this.window)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Resize sets the size of the viewport, dispatches a resize event to the
+// window, and then calls the listeners of media query lists which have
+// changed
+func (this *window) Resize(width, height int) {
+	if width < 0 || height < 0 {
+		panic(dom.ErrBadParameter.Withf("invalid size %dx%d", width, height))
+	}
+	if width == this.width && height == this.height {
+		return
+	}
+	this.width, this.height = width, height
+	this.DispatchEvent(newEvent("resize", false, false))
+	for _, mql := range slices.Clone(this.media) {
+		if matches := mql.query(this); matches != mql.matches {
+			mql.matches = matches
+			evt := newEvent("change", false, false)
+			evt.call(mql.events, nil, dom.AT_TARGET, false)
+		}
+	}
+}
+
+// AddChangeListener calls the function when the list changes between
+// matching and not matching
+func (this *mediaQueryList) AddChangeListener(callback func(bool)) dom.EventListener {
+	if callback == nil {
+		panic(dom.ErrBadParameter.With("callback is nil"))
+	}
+	if !slices.Contains(this.window.media, this) {
+		this.matches = this.query(this.window)
+		this.window.media = append(this.window.media, this)
+	}
+	l := newListener(func(dom.Event) {
+		callback(this.matches)
+	})
+	this.events.addListener("change", l)
+	return &eventListener{this.events, "change", l}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// parseMediaQueryList returns a function which evaluates a comma-separated
+// list of media queries, which matches when any of the queries match
+func parseMediaQueryList(media string) func(*window) bool {
+	media = strings.ToLower(strings.TrimSpace(media))
+	if media == "" {
+		return func(*window) bool { return true }
+	}
+	var queries []func(*window) bool
+	for _, query := range strings.Split(media, ",") {
+		if fn := parseMediaQuery(strings.TrimSpace(query)); fn != nil {
+			queries = append(queries, fn)
+		}
+	}
+	return func(w *window) bool {
+		return slices.ContainsFunc(queries, func(fn func(*window) bool) bool {
+			return fn(w)
+		})
+	}
+}
+
+// parseMediaQuery returns a function which evaluates a media query with an
+// optional media type and features joined by "and", or nil if the query
+// cannot be parsed
+func parseMediaQuery(query string) func(*window) bool {
+	not := false
+	if rest, ok := strings.CutPrefix(query, "not "); ok {
+		not, query = true, strings.TrimSpace(rest)
+	} else if rest, ok := strings.CutPrefix(query, "only "); ok {
+		query = strings.TrimSpace(rest)
+	}
+
+	var conditions []func(*window) bool
+	for i, part := range reMediaAnd.Split(query, -1) {
+		var condition func(*window) bool
+		if i == 0 && !strings.HasPrefix(part, "(") {
+			condition = parseMediaType(part)
+		} else {
+			condition = parseMediaFeature(part)
+		}
+		if condition == nil {
+			return nil
+		}
+		conditions = append(conditions, condition)
+	}
+	return func(w *window) bool {
+		for _, condition := range conditions {
+			if !condition(w) {
+				return not
+			}
+		}
+		return !not
+	}
+}
+
+// parseMediaType returns a function which matches the screen media type
+func parseMediaType(media string) func(*window) bool {
+	switch media {
+	case "all", "screen":
+		return func(*window) bool { return true }
+	case "print", "speech", "tty", "tv", "projection", "handheld", "braille", "embossed", "aural":
+		return func(*window) bool { return false }
+	default:
+		return nil
+	}
+}
+
+// parseMediaFeature returns a function which evaluates a media feature in
+// parentheses, or nil if the feature cannot be parsed
+func parseMediaFeature(feature string) func(*window) bool {
+	match := reMediaFeature.FindStringSubmatch(feature)
+	if match == nil {
+		return nil
+	}
+	name, value := match[1], match[2]
+
+	// Features which compare the size of the viewport
+	var size func(*window) int
+	switch strings.TrimPrefix(strings.TrimPrefix(name, "min-"), "max-") {
+	case "width":
+		size = func(w *window) int { return w.width }
+	case "height":
+		size = func(w *window) int { return w.height }
+	}
+	if size != nil {
+		if value == "" {
+			if name != "width" && name != "height" {
+				return nil
+			}
+			return func(w *window) bool { return size(w) > 0 }
+		}
+		length, ok := parseMediaLength(value)
+		if !ok {
+			return nil
+		}
+		switch {
+		case strings.HasPrefix(name, "min-"):
+			return func(w *window) bool { return float64(size(w)) >= length }
+		case strings.HasPrefix(name, "max-"):
+			return func(w *window) bool { return float64(size(w)) <= length }
+		default:
+			return func(w *window) bool { return float64(size(w)) == length }
+		}
+	}
+
+	// Features with keyword values
+	var keywords []string
+	var fn func(*window, string) bool
+	switch name {
+	case "orientation":
+		keywords = []string{"portrait", "landscape"}
+		fn = func(w *window, value string) bool {
+			return (value == "portrait") == (w.height >= w.width)
+		}
+	case "prefers-color-scheme":
+		keywords = []string{"light", "dark"}
+	case "prefers-reduced-motion":
+		keywords = []string{"no-preference", "reduce"}
+	case "hover", "any-hover":
+		keywords = []string{"hover", "none"}
+	case "pointer", "any-pointer":
+		keywords = []string{"fine", "coarse", "none"}
+	default:
+		return nil
+	}
+	if fn == nil {
+		// The viewport matches the first keyword
+		fn = func(_ *window, value string) bool { return value == keywords[0] }
+	}
+	switch {
+	case value == "":
+		// A feature without a value matches unless the viewport has no
+		// preference or capability
+		matches := keywords[0] != "none" && keywords[0] != "no-preference"
+		return func(*window) bool { return matches }
+	case slices.Contains(keywords, value):
+		return func(w *window) bool { return fn(w, value) }
+	default:
+		return nil
+	}
+}
+
+// parseMediaLength returns a length in pixels, where a length without a
+// unit must be zero
+func parseMediaLength(value string) (float64, bool) {
+	match := reMediaLength.FindStringSubmatch(value)
+	if match == nil {
+		return 0, false
+	}
+	length, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	switch match[2] {
+	case "px":
+		return length, true
+	case "em", "rem":
+		return length * emPixels, true
+	default:
+		return length, length == 0
+	}
+}
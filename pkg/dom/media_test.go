@@ -0,0 +1,79 @@
+//go:build !js
+
+package dom_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMedia_MatchMedia(t *testing.T) {
+	window := newFakeWindow(t)
+	assert.Equal(t, 1024, window.InnerWidth())
+	assert.Equal(t, 768, window.InnerHeight())
+
+	tests := []struct {
+		media   string
+		matches bool
+	}{
+		{"", true},
+		{"all", true},
+		{"screen", true},
+		{"print", false},
+		{"not print", true},
+		{"only screen and (min-width: 768px)", true},
+		{"(min-width: 1200px)", false},
+		{"(max-width: 64em)", true},
+		{"(min-width: 600px) and (max-height: 700px)", false},
+		{"(max-width: 600px), (orientation: landscape)", true},
+		{"(orientation: portrait)", false},
+		{"(prefers-color-scheme: dark)", false},
+		{"(prefers-color-scheme: light)", true},
+		{"(prefers-reduced-motion)", false},
+		{"(hover: hover) and (pointer: fine)", true},
+		{"(width)", true},
+		{"(min-width: 600)", false},
+		{"not (unknown: 1)", false},
+		{"screen and", false},
+	}
+	for _, test := range tests {
+		mql := window.MatchMedia(test.media)
+		assert.Equal(t, test.media, mql.Media())
+		assert.Equal(t, test.matches, mql.Matches(), test.media)
+	}
+}
+
+func TestMedia_Resize(t *testing.T) {
+	window := newFakeWindow(t)
+	mql := window.MatchMedia("(max-width: 600px)")
+	assert.False(t, mql.Matches())
+
+	var events []string
+	var changes []bool
+	window.AddEventHandler("resize", func(e dom.Event) {
+		events = append(events, e.Type())
+	})
+	listener := mql.AddChangeListener(func(matches bool) {
+		changes = append(changes, matches)
+	})
+	assert.Equal(t, "change", listener.Type())
+
+	// Listeners are called when the list changes between matching and not
+	// matching
+	window.Resize(800, 600)
+	window.Resize(480, 800)
+	window.Resize(320, 480)
+	window.Resize(1024, 768)
+	assert.Equal(t, []string{"resize", "resize", "resize", "resize"}, events)
+	assert.Equal(t, []bool{true, false}, changes)
+	assert.Equal(t, 1024, window.InnerWidth())
+
+	// Removed listeners are not called
+	listener.Remove()
+	window.Resize(480, 800)
+	assert.Equal(t, []bool{true, false}, changes)
+	assert.True(t, mql.Matches())
+}
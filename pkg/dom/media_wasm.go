@@ -0,0 +1,57 @@
+//go:build js
+
+package dom
+
+import (
+	"syscall/js"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type mediaQueryList struct {
+	js.Value
+}
+
+var _ dom.MediaQueryList = (*mediaQueryList)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+func (this *window) MatchMedia(media string) dom.MediaQueryList {
+	return &mediaQueryList{this.Call("matchMedia", media)}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (this *window) InnerWidth() int {
+	return this.Get("innerWidth").Int()
+}
+
+func (this *window) InnerHeight() int {
+	return this.Get("innerHeight").Int()
+}
+
+func (this *mediaQueryList) Media() string {
+	return this.Get("media").String()
+}
+
+func (this *mediaQueryList) Matches() bool {
+	return this.Get("matches").Bool()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (this *mediaQueryList) AddChangeListener(callback func(bool)) dom.EventListener {
+	if callback == nil {
+		panic(dom.ErrBadParameter.With("callback is nil"))
+	}
+	return addListener(this.Value, "change", func(e dom.Event) {
+		callback(e.(*event).JSValue().Get("matches").Bool())
+	})
+}
@@ -0,0 +1,46 @@
+//go:build !js
+
+package dom
+
+import (
+	"strings"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+// Namespaces of elements and attributes, keyed by the name used by the
+// HTML parser
+var parserNamespaces = map[string]string{
+	"svg":   dom.NS_SVG,
+	"math":  dom.NS_MATHML,
+	"xlink": dom.NS_XLINK,
+	"xml":   dom.NS_XML,
+	"xmlns": dom.NS_XMLNS,
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// parserNamespace returns the name used by the HTML parser for a namespace,
+// which is empty for the HTML namespace
+func parserNamespace(namespace string) string {
+	for key, value := range parserNamespaces {
+		if value == namespace {
+			return key
+		}
+	}
+	return ""
+}
+
+// matchTagName returns true if an element has a tag name, which is case
+// insensitive for HTML elements
+func matchTagName(elem dom.Element, name string) bool {
+	if elem.NamespaceURI() == dom.NS_HTML {
+		return strings.EqualFold(elem.TagName(), name)
+	}
+	return elem.TagName() == name
+}
@@ -0,0 +1,131 @@
+package dom_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespace_CreateElementNS(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+
+	svg := doc.CreateElementNS(dom.NS_SVG, "svg")
+	assert.Equal(t, "svg", svg.TagName())
+	assert.Equal(t, dom.NS_SVG, svg.NamespaceURI())
+	assert.Equal(t, "svg", svg.LocalName())
+	assert.Equal(t, "", svg.Prefix())
+
+	// Names of foreign elements and attributes keep their case
+	svg.SetAttribute("viewBox", "0 0 10 10")
+	svg.AppendChild(doc.CreateElementNS(dom.NS_SVG, "foreignObject"))
+	assert.Equal(t, `<svg viewBox="0 0 10 10"><foreignObject></foreignObject></svg>`, svg.OuterHTML())
+
+	// HTML elements have uppercase tag names and lowercase local names
+	div := doc.CreateElement("div")
+	assert.Equal(t, dom.NS_HTML, div.NamespaceURI())
+	assert.Equal(t, "DIV", div.TagName())
+	assert.Equal(t, "div", div.LocalName())
+
+	// Prefixed names
+	elem := doc.CreateElementNS("urn:test", "t:item")
+	assert.Equal(t, "t", elem.Prefix())
+	assert.Equal(t, "item", elem.LocalName())
+	assert.Equal(t, "t:item", elem.TagName())
+}
+
+func TestNamespace_InvalidName(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	assert.Panics(t, func() { doc.CreateElementNS("", "t:item") })
+	assert.Panics(t, func() { doc.CreateElementNS("urn:test", "xml:item") })
+	assert.Panics(t, func() { doc.CreateElementNS(dom.NS_SVG, "") })
+
+	// The prefix and local name must be XML names without a colon
+	for _, name := range []string{"1bad", "-bad", ".bad", "a b", "a<b", "a/b", ":a", "a:", "1a:b", "a:1b", "a:b:c", "\xff"} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				err, _ := recover().(error)
+				assert.ErrorIs(t, err, dom.ErrBadParameter)
+			}()
+			doc.CreateElementNS(dom.NS_SVG, name)
+		})
+	}
+	for _, name := range []string{"a", "_a", "a1", "a-b.c", "feDropShadow", "t:item", "\u00e9l\u00e9ment"} {
+		assert.NotPanics(t, func() { doc.CreateElementNS("urn:test", name) }, name)
+	}
+
+	// The parser accepts names in foreign content which are not XML names
+	div := doc.CreateElement("div")
+	assert.NotPanics(t, func() { div.SetInnerHTML(`<svg><a$b></a$b></svg>`) })
+}
+
+func TestNamespace_AttributeNS(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	use := doc.CreateElementNS(dom.NS_SVG, "use")
+
+	attr := use.SetAttributeNS(dom.NS_XLINK, "xlink:href", "#a")
+	if assert.NotNil(t, attr) {
+		assert.Equal(t, dom.NS_XLINK, attr.NamespaceURI())
+		assert.Equal(t, "xlink", attr.Prefix())
+		assert.Equal(t, "href", attr.LocalName())
+		assert.Equal(t, "xlink:href", attr.Name())
+	}
+	assert.Equal(t, "#a", use.GetAttributeNS(dom.NS_XLINK, "href"))
+	assert.Equal(t, "#a", use.GetAttribute("xlink:href"))
+	assert.True(t, use.HasAttributeNS(dom.NS_XLINK, "href"))
+	assert.False(t, use.HasAttributeNS("", "href"))
+
+	// Setting the attribute again changes the value
+	use.SetAttributeNS(dom.NS_XLINK, "xlink:href", "#b")
+	assert.Equal(t, "#b", use.GetAttributeNS(dom.NS_XLINK, "href"))
+	assert.Len(t, use.Attributes(), 1)
+
+	// Attributes without a namespace
+	use.SetAttribute("width", "10")
+	assert.Equal(t, "10", use.GetAttributeNS("", "width"))
+
+	use.RemoveAttributeNS(dom.NS_XLINK, "href")
+	assert.False(t, use.HasAttribute("xlink:href"))
+}
+
+func TestNamespace_Parse(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	div := doc.CreateElement("div")
+	div.SetInnerHTML(`<svg viewBox="0 0 10 10"><use xlink:href="#a"></use><foreignObject><p>text</p></foreignObject></svg><math><mi>x</mi></math>`)
+
+	svg := div.FirstElementChild()
+	assert.Equal(t, dom.NS_SVG, svg.NamespaceURI())
+	assert.Equal(t, "0 0 10 10", svg.GetAttribute("viewBox"))
+	assert.Equal(t, "#a", svg.QuerySelector("use").GetAttributeNS(dom.NS_XLINK, "href"))
+
+	// Elements in a foreign object are HTML elements
+	foreign := svg.GetElementsByTagName("foreignObject")
+	if assert.Len(t, foreign, 1) {
+		assert.Equal(t, dom.NS_SVG, foreign[0].NamespaceURI())
+		assert.Equal(t, dom.NS_HTML, foreign[0].FirstElementChild().NamespaceURI())
+	}
+	assert.Equal(t, dom.NS_MATHML, div.QuerySelector("mi").NamespaceURI())
+
+	// The serialisation keeps the case of foreign names
+	assert.Equal(t, `<svg viewBox="0 0 10 10"><use xlink:href="#a"></use><foreignObject><p>text</p></foreignObject></svg><math><mi>x</mi></math>`, div.InnerHTML())
+
+	// Elements parsed with a foreign context are in the namespace
+	svg.SetInnerHTML(`<circle r="1"/>`)
+	assert.Equal(t, dom.NS_SVG, svg.FirstElementChild().NamespaceURI())
+}
+
+func TestNamespace_Clone(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	svg := doc.CreateElementNS(dom.NS_SVG, "svg")
+	svg.AppendChild(doc.CreateElementNS(dom.NS_SVG, "use")).(dom.Element).SetAttributeNS(dom.NS_XLINK, "xlink:href", "#a")
+
+	clone := svg.CloneNode(true).(dom.Element)
+	assert.Equal(t, dom.NS_SVG, clone.NamespaceURI())
+	use := clone.FirstElementChild()
+	if assert.NotNil(t, use) {
+		assert.Equal(t, dom.NS_SVG, use.NamespaceURI())
+		assert.Equal(t, "#a", use.GetAttributeNS(dom.NS_XLINK, "href"))
+	}
+}
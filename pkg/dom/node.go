@@ -5,6 +5,8 @@ package dom
 import (
 	"fmt"
 	"io"
+	"reflect"
+	"slices"
 	"strings"
 
 	// Packages
@@ -15,35 +17,65 @@ import (
 // TYPES
 
 type node struct {
-	document dom.Document
-	parent   dom.Node
-	name     string
-	nodetype dom.NodeType
-	children []dom.Node
-	cdata    string
+	document  dom.Document
+	parent    dom.Node
+	namespace string
+	name      string
+	nodetype  dom.NodeType
+	children  []dom.Node
+	cdata     string
+
+	// Observers and event listeners registered on this node
+	observers []*registration
+	listeners map[string][]*listener
+
+	// The node type which embeds this node
+	self dom.Node
 }
 
 /////////////////////////////////////////////////////////////////////
 // LIFECYCLE
 
+// NewNode returns a new node, where elements are in the HTML namespace
 func NewNode(doc dom.Document, name string, nodetype dom.NodeType, cdata string) dom.Node {
-	node := &node{doc, nil, name, nodetype, nil, cdata}
+	namespace := ""
+	if nodetype == dom.ELEMENT_NODE {
+		namespace = dom.NS_HTML
+	}
+	return newNode(doc, namespace, name, nodetype, cdata)
+}
+
+// newNode returns a new node with a namespace, where elements in the HTML
+// namespace have a type determined by the tag name
+func newNode(doc dom.Document, namespace, name string, nodetype dom.NodeType, cdata string) dom.Node {
+	node := &node{document: doc, namespace: namespace, name: name, nodetype: nodetype, cdata: cdata}
 	switch nodetype {
 	case dom.DOCUMENT_NODE:
-		return &document{node, nil, nil, nil, nil}
+		node.self = &document{node: node}
 	case dom.DOCUMENT_TYPE_NODE:
-		return &doctype{node, "", ""}
+		node.self = &doctype{node, "", ""}
 	case dom.ELEMENT_NODE:
-		return &element{node, NewTokenList(), map[string]dom.Attr{}}
+		elem := &element{node: node, classlist: NewTokenList(), style: newStyle()}
+		elem.classlist.change = elem.classListChanged
+		elem.style.change = elem.styleChanged
+		if namespace == dom.NS_HTML {
+			node.self = newHTMLElement(elem)
+			customElementsOf(doc).upgrade(elem)
+		} else {
+			node.self = elem
+		}
 	case dom.TEXT_NODE:
-		return &text{node}
+		node.self = &text{node}
 	case dom.COMMENT_NODE:
-		return &comment{node}
+		node.self = &comment{node}
+	case dom.DOCUMENT_FRAGMENT_NODE:
+		node.self = &fragment{node}
 	case dom.ATTRIBUTE_NODE:
-		return &attr{node}
+		node.self = &attr{node}
 	default:
-		return node
+		node.self = node
 	}
+	return node.self
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -110,8 +142,10 @@ func (this *node) FirstChild() dom.Node {
 	}
 }
 
+// IsConnected returns true if the node is in a document, including when it
+// is in the shadow tree of an element in a document
 func (this *node) IsConnected() bool {
-	return this.parent != nil
+	return shadowIncludingRoot(this).NodeType() == dom.DOCUMENT_NODE
 }
 
 func (this *node) LastChild() dom.Node {
@@ -192,22 +226,18 @@ func (this *node) Component() dom.Component {
 // PUBLIC METHODS
 
 func (this *node) AppendChild(child dom.Node) dom.Node {
-	node := getNode(child)
-	if node.parent != nil {
-		node.parent.RemoveChild(child)
-	}
-	node.parent = this
-	this.children = append(this.children, child)
+	this.insertNodes(child, nil)
 	return child
 }
 
 func (this *node) CloneNode(deep bool) dom.Node {
-	clone := NewNode(this.document, this.name, this.nodetype, this.cdata)
+	clone := newNode(this.document, this.namespace, this.name, this.nodetype, this.cdata)
 	if deep {
 		getNode(clone).children = make([]dom.Node, len(this.children))
 		for i := range this.children {
 			child := this.children[i].CloneNode(deep)
 			getNode(child).parent = clone
+			getNode(clone).children[i] = child
 		}
 	}
 	return clone
@@ -222,45 +252,157 @@ func (this *node) InsertBefore(new dom.Node, ref dom.Node) dom.Node {
 	if new == nil {
 		return nil
 	}
+	// Ref not in children, return nil
+	if ref != nil && this.indexOf(ref) < 0 {
+		return nil
+	}
 	// 'new' is inserted at the end of parentNode's child nodes
 	// when 'ref' is nil
-	if ref == nil {
-		return this.AppendChild(new)
+	this.insertNodes(new, ref)
+	return new
+}
+
+func (this *node) RemoveChild(child dom.Node) {
+	checkEventLoop()
+	i := this.indexOf(child)
+	if i < 0 {
+		return
 	}
-	// insert node before ref
-	node := getNode(new)
-	for i := range this.children {
-		if this.children[i] != ref {
-			continue
+	record := mutationRecord{kind: mutationChildList, target: this.self, removed: []dom.Node{child}}
+	if i > 0 {
+		record.previousSibling = this.children[i-1]
+	}
+	if i < len(this.children)-1 {
+		record.nextSibling = this.children[i+1]
+	}
+
+	// Deattach child from parent
+	if doc, ok := ownerDocument(this.self).(*document); ok {
+		doc.removing(child)
+	}
+	indexNodes(this.self, []dom.Node{child}, false)
+	getNode(child).parent = nil
+	// Remove child from parent
+	this.children = append(this.children[:i], this.children[i+1:]...)
+	queueMutation(record)
+	customElementsOf(ownerDocument(this.self)).connected(this.self, record.removed, false)
+}
+
+func (this *node) ReplaceChild(new, old dom.Node) {
+	if new == nil || old == nil || new == old || this.indexOf(old) < 0 {
+		return
+	}
+	next := findNextChild(this, old)
+	if next == new {
+		next = findNextChild(this, new)
+	}
+	this.RemoveChild(old)
+	this.insertNodes(new, next)
+}
+
+// IsEqualNode returns true if the nodes have the same type, names,
+// attributes and data, and their children are equal
+func (this *node) IsEqualNode(other dom.Node) bool {
+	if other == nil {
+		return false
+	}
+	that := getNode(other)
+	if this.nodetype != that.nodetype || len(this.children) != len(that.children) {
+		return false
+	}
+	switch this.nodetype {
+	case dom.DOCUMENT_TYPE_NODE:
+		a, b := this.self.(dom.DocumentType), other.(dom.DocumentType)
+		if a.Name() != b.Name() || a.PublicId() != b.PublicId() || a.SystemId() != b.SystemId() {
+			return false
 		}
-		// Detach new from current parent
-		if node.parent != nil {
-			node.parent.RemoveChild(new)
+	case dom.ELEMENT_NODE:
+		if !equalElements(this.self.(dom.Element), other.(dom.Element)) {
+			return false
+		}
+	case dom.ATTRIBUTE_NODE, dom.TEXT_NODE, dom.COMMENT_NODE:
+		if this.namespace != that.namespace || this.name != that.name || this.cdata != that.cdata {
+			return false
 		}
-		// Attach new to this
-		node.parent = this
-		this.children = append(this.children[:i], append([]dom.Node{new}, this.children[i:]...)...)
-		return new
 	}
-	// Ref not in children, return nil
-	return nil
+	for i, child := range this.children {
+		if !child.IsEqualNode(that.children[i]) {
+			return false
+		}
+	}
+	return true
 }
 
-func (this *node) RemoveChild(child dom.Node) {
-	for i, c := range this.children {
-		if c != child {
-			continue
+// CompareDocumentPosition returns the position of the other node relative
+// to this node. Attributes are positioned after their owner element.
+func (this *node) CompareDocumentPosition(other dom.Node) dom.DocumentPosition {
+	if other == nil || this == getNode(other) {
+		return 0
+	}
+
+	// Compare the owner elements of attributes, and attributes of the same
+	// element by name
+	node1, node2 := other, this.self
+	var attr1, attr2 dom.Attr
+	if attr, ok := node1.(dom.Attr); ok {
+		attr1, node1 = attr, attr.OwnerElement()
+	}
+	if attr, ok := node2.(dom.Attr); ok {
+		attr2, node2 = attr, attr.OwnerElement()
+		if attr1 != nil && node1 != nil && node1 == node2 {
+			if attr1.Name() < attr2.Name() {
+				return dom.DOCUMENT_POSITION_IMPLEMENTATION_SPECIFIC | dom.DOCUMENT_POSITION_PRECEDING
+			}
+			return dom.DOCUMENT_POSITION_IMPLEMENTATION_SPECIFIC | dom.DOCUMENT_POSITION_FOLLOWING
 		}
-		// Deattach child from parent
-		getNode(child).parent = nil
-		// Remove child from parent
-		this.children = append(this.children[:i], this.children[i+1:]...)
-		return
+	}
+
+	// Nodes in different trees are ordered consistently by their address
+	if node1 == nil || node2 == nil || rangeRoot(node1) != rangeRoot(node2) {
+		position := dom.DOCUMENT_POSITION_DISCONNECTED | dom.DOCUMENT_POSITION_IMPLEMENTATION_SPECIFIC
+		if reflect.ValueOf(getNode(other)).Pointer() < reflect.ValueOf(this).Pointer() {
+			return position | dom.DOCUMENT_POSITION_PRECEDING
+		}
+		return position | dom.DOCUMENT_POSITION_FOLLOWING
+	}
+
+	switch {
+	case (attr1 == nil && node1 != node2 && isInclusiveAncestor(node1, node2)) || (attr2 != nil && node1 == node2):
+		return dom.DOCUMENT_POSITION_CONTAINS | dom.DOCUMENT_POSITION_PRECEDING
+	case (attr2 == nil && node1 != node2 && isInclusiveAncestor(node2, node1)) || (attr1 != nil && node1 == node2):
+		return dom.DOCUMENT_POSITION_CONTAINED_BY | dom.DOCUMENT_POSITION_FOLLOWING
+	case slices.Compare(nodePath(node1), nodePath(node2)) < 0:
+		return dom.DOCUMENT_POSITION_PRECEDING
+	default:
+		return dom.DOCUMENT_POSITION_FOLLOWING
 	}
 }
 
-func (this *node) ReplaceChild(dom.Node, dom.Node) {
-	// TODO
+// GetRootNode returns the topmost ancestor of the node, or the node if it
+// has no parent
+func (this *node) GetRootNode() dom.Node {
+	return rangeRoot(this)
+}
+
+// Normalize removes empty text nodes and merges adjacent text nodes in the
+// descendants of the node
+func (this *node) Normalize() {
+	var text *node
+	for _, child := range slices.Clone(this.children) {
+		c := getNode(child)
+		switch {
+		case c.nodetype != dom.TEXT_NODE:
+			text = nil
+			child.Normalize()
+		case c.cdata == "":
+			this.RemoveChild(child)
+		case text != nil:
+			text.setData(text.cdata + c.cdata)
+			this.RemoveChild(child)
+		default:
+			text = c
+		}
+	}
 }
 
 /////////////////////////////////////////////////////////////////////
@@ -288,32 +430,115 @@ func getNode(n dom.Node) *node {
 		return v.node
 	case *document:
 		return v.node
+	case *fragment:
+		return v.node
+	case interface{ v() *node }:
+		return v.v()
 	default:
 		panic("getNode: unknown node type")
 	}
 }
 
+// indexOf returns the index of child, or -1 if it is not a child
+func (this *node) indexOf(child dom.Node) int {
+	for i, c := range this.children {
+		if c == child {
+			return i
+		}
+	}
+	return -1
+}
+
+// insertNodes inserts a node, or the children of a fragment, before ref or
+// at the end of the children when ref is nil, and queues a single mutation
+// record for the inserted nodes. It panics if the node is an ancestor.
+func (this *node) insertNodes(child dom.Node, ref dom.Node) {
+	checkEventLoop()
+
+	// A node cannot be inserted into itself or its descendants
+	for n := this.self; n != nil; n = n.ParentNode() {
+		if n == child {
+			panic(dom.ErrBadParameter.Withf("cannot insert %q into itself", child.NodeName()))
+		}
+	}
+
+	// A node inserted before itself is inserted before its next sibling,
+	// which is found before the node is detached
+	if ref != nil && ref == child {
+		ref = findNextChild(this, child)
+	}
+
+	// Detach the nodes from their current parent
+	var nodes []dom.Node
+	if child.NodeType() == dom.DOCUMENT_FRAGMENT_NODE {
+		nodes = getNode(child).replaceAll(nil)
+	} else {
+		if parent := getNode(child).parent; parent != nil {
+			parent.RemoveChild(child)
+		}
+		nodes = []dom.Node{child}
+	}
+	if len(nodes) == 0 {
+		return
+	}
+
+	// Insert the nodes
+	i := len(this.children)
+	if ref != nil {
+		i = this.indexOf(ref)
+	}
+	record := mutationRecord{kind: mutationChildList, target: this.self, added: nodes, nextSibling: ref}
+	if i > 0 {
+		record.previousSibling = this.children[i-1]
+	}
+	for _, n := range nodes {
+		getNode(n).parent = this.self
+	}
+	this.children = append(this.children[:i], append(slices.Clone(nodes), this.children[i:]...)...)
+	indexNodes(this.self, nodes, true)
+	queueMutation(record)
+	customElementsOf(ownerDocument(this.self)).connected(this.self, nodes, true)
+}
+
+// replaceAll replaces the children with nodes, which have no parent, and
+// returns the removed children
+func (this *node) replaceAll(nodes []dom.Node) []dom.Node {
+	checkEventLoop()
+	removed := this.children
+	if len(removed) == 0 && len(nodes) == 0 {
+		return nil
+	}
+	if doc, ok := ownerDocument(this.self).(*document); ok {
+		for _, n := range removed {
+			doc.removing(n)
+		}
+	}
+	indexNodes(this.self, removed, false)
+	for _, n := range removed {
+		getNode(n).parent = nil
+	}
+	for _, n := range nodes {
+		getNode(n).parent = this.self
+	}
+	this.children = slices.Clone(nodes)
+	indexNodes(this.self, nodes, true)
+	queueMutation(mutationRecord{kind: mutationChildList, target: this.self, added: nodes, removed: removed})
+	customElementsOf(ownerDocument(this.self)).connected(this.self, removed, false)
+	customElementsOf(ownerDocument(this.self)).connected(this.self, nodes, true)
+	return removed
+}
+
+// setData sets the data of a text or comment node
+func (this *node) setData(data string) {
+	checkEventLoop()
+	record := mutationRecord{kind: mutationCharacterData, target: this.self, oldValue: this.cdata}
+	this.cdata = data
+	queueMutation(record)
+}
+
 // writeNode serializes any node type to HTML
-// This replaces the nodevalue interface write() method
 func writeNode(w io.Writer, n dom.Node) (int, error) {
-	switch v := n.(type) {
-	case *element:
-		return v.write(w)
-	case *attr:
-		return v.write(w)
-	case *text:
-		return v.write(w)
-	case *comment:
-		return v.write(w)
-	case *doctype:
-		return v.write(w)
-	case *node:
-		return v.write(w)
-	case *document:
-		return v.write(w)
-	default:
-		panic("writeNode: unknown node type")
-	}
+	return writeHTML(w, n, "")
 }
 
 // findNextChild finds the next sibling of child in parent's children
@@ -362,24 +587,20 @@ func findPreviousChild(parent *node, child dom.Node) dom.Node {
 	return nil
 }
 
-func (this *node) write(w io.Writer) (int, error) {
-	s := 0
-	if n, err := w.Write([]byte("<" + this.name + ">")); err != nil {
-		return 0, err
-	} else {
-		s += n
+// equalElements returns true if the elements have the same namespace,
+// prefix, local name and attributes
+func equalElements(a, b dom.Element) bool {
+	if a.NamespaceURI() != b.NamespaceURI() || a.Prefix() != b.Prefix() || a.LocalName() != b.LocalName() {
+		return false
 	}
-	for _, child := range this.children {
-		if n, err := writeNode(w, child); err != nil {
-			return 0, err
-		} else {
-			s += n
-		}
+	attrs := a.Attributes()
+	if len(attrs) != len(b.Attributes()) {
+		return false
 	}
-	if n, err := w.Write([]byte("</" + this.name + ">")); err != nil {
-		return 0, err
-	} else {
-		s += n
+	for _, attr := range attrs {
+		if !b.HasAttributeNS(attr.NamespaceURI(), attr.LocalName()) || b.GetAttributeNS(attr.NamespaceURI(), attr.LocalName()) != attr.Value() {
+			return false
+		}
 	}
-	return s, nil
+	return true
 }
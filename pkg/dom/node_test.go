@@ -0,0 +1,98 @@
+package dom_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNode_ParentElement(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	div := doc.CreateElement("div")
+	span := doc.CreateElement("span")
+	text := doc.CreateTextNode("text")
+
+	// The parent of an appended or inserted node is the element, so it can
+	// be used as an element
+	div.AppendChild(span)
+	div.InsertBefore(text, span)
+	for _, child := range []dom.Node{span, text} {
+		parent := child.ParentElement()
+		if assert.NotNil(t, parent) {
+			assert.Equal(t, "DIV", parent.TagName())
+			assert.True(t, div.Equals(parent))
+		}
+		assert.True(t, div.Equals(child.ParentNode()))
+	}
+}
+
+func TestNode_InsertBeforeSelf(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	p := doc.CreateElement("p")
+	a, b := doc.CreateElement("a"), doc.CreateElement("b")
+	p.AppendChild(a)
+	p.AppendChild(b)
+
+	// Inserting a node before itself leaves it in place
+	assert.NotPanics(t, func() {
+		assert.Equal(t, a, p.InsertBefore(a, a))
+		assert.Equal(t, b, p.InsertBefore(b, b))
+	})
+	assert.Equal(t, "<p><a></a><b></b></p>", p.OuterHTML())
+}
+
+func TestNode_ReplaceWithSelf(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	p := doc.CreateElement("p")
+	a, b, i := doc.CreateElement("a"), doc.CreateElement("b"), doc.CreateElement("i")
+	p.AppendChild(a)
+	p.AppendChild(b)
+
+	// Replacing an element with itself leaves it in place
+	assert.NotPanics(t, func() {
+		a.ReplaceWith(a)
+	})
+	assert.Equal(t, "<p><a></a><b></b></p>", p.OuterHTML())
+
+	// Replacing an element with nodes including itself and its sibling
+	a.ReplaceWith(i, b, a)
+	assert.Equal(t, "<p><i></i><b></b><a></a></p>", p.OuterHTML())
+}
+
+func TestNode_CloneNode(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	div := doc.CreateElement("div")
+	span := doc.CreateElement("span")
+	span.AppendChild(doc.CreateTextNode("a"))
+	div.AppendChild(span)
+	div.AppendChild(doc.CreateTextNode("b"))
+
+	// A deep clone has copies of the children, with the clone as parent
+	clone := div.CloneNode(true)
+	if assert.Len(t, clone.ChildNodes(), 2) {
+		assert.Equal(t, "ab", clone.TextContent())
+		assert.False(t, span.Equals(clone.FirstChild()))
+		assert.True(t, clone.Equals(clone.FirstChild().ParentNode()))
+		assert.Equal(t, "a", clone.FirstChild().TextContent())
+	}
+
+	// A shallow clone has no children
+	assert.False(t, div.CloneNode(false).HasChildNodes())
+}
+
+func TestNode_GetWindow(t *testing.T) {
+	// All callers share the same window, so nodes added to its document
+	// by one caller are seen by another
+	window := domPkg.GetWindow()
+	assert.Same(t, window, domPkg.GetWindow())
+	div := window.Document().CreateElement("div")
+	window.Document().Body().AppendChild(div)
+	defer window.Document().Body().RemoveChild(div)
+	assert.True(t, div.Equals(domPkg.GetWindow().Document().Body().LastChild()))
+
+	// A window with a title has its own document
+	assert.NotSame(t, window, domPkg.GetWindowWithTitle("other"))
+}
@@ -43,6 +43,10 @@ func toJSValue(n dom.Node) js.Value {
 		return v.node.Value
 	case *document:
 		return v.node.Value
+	case *fragment:
+		return v.node.Value
+	case interface{ v() js.Value }:
+		return v.v()
 	default:
 		panic("toJSValue: unknown node type")
 	}
@@ -69,7 +73,9 @@ var (
 	cDocument     = js.Global().Get("HTMLDocument")
 	cDocumentType = js.Global().Get("DocumentType")
 	cElement      = js.Global().Get("HTMLElement")
+	cBaseElement  = js.Global().Get("Element")
 	cAttr         = js.Global().Get("Attr")
+	cFragment     = js.Global().Get("DocumentFragment")
 )
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -89,10 +95,13 @@ func NewNode(v js.Value) dom.Node {
 
 		// Check if this prototype matches any known types
 		// For custom elements, we check the prototype itself, not its constructor
+		if elem := newHTMLElement(proto, v); elem != nil {
+			return elem
+		}
 		switch {
 		case proto.Equal(cDocument.Get("prototype")):
 			return &document{node: &node{v}}
-		case proto.Equal(cElement.Get("prototype")):
+		case proto.Equal(cElement.Get("prototype")), proto.Equal(cBaseElement.Get("prototype")):
 			return &element{node: &node{v}}
 		case proto.Equal(cText.Get("prototype")):
 			return &text{node: &node{v}}
@@ -102,6 +111,10 @@ func NewNode(v js.Value) dom.Node {
 			return &doctype{node: &node{v}}
 		case proto.Equal(cAttr.Get("prototype")):
 			return &attr{node: &node{v}}
+		case proto.Equal(cShadowRoot.Get("prototype")):
+			return &shadowRoot{&fragment{node: &node{v}}}
+		case proto.Equal(cFragment.Get("prototype")):
+			return &fragment{node: &node{v}}
 		case proto.Equal(cNode.Get("prototype")):
 			return &node{v}
 		} // Also check constructor for compatibility (legacy behavior)
@@ -222,6 +235,28 @@ func (this *node) Equals(other dom.Node) bool {
 	return this.Equal(toJSValue(other))
 }
 
+func (this *node) IsEqualNode(other dom.Node) bool {
+	if other == nil {
+		return false
+	}
+	return this.Call("isEqualNode", toJSValue(other)).Bool()
+}
+
+func (this *node) CompareDocumentPosition(other dom.Node) dom.DocumentPosition {
+	if other == nil {
+		return 0
+	}
+	return dom.DocumentPosition(this.Call("compareDocumentPosition", toJSValue(other)).Int())
+}
+
+func (this *node) GetRootNode() dom.Node {
+	return NewNode(this.Call("getRootNode"))
+}
+
+func (this *node) Normalize() {
+	this.Call("normalize")
+}
+
 func (this *node) AppendChild(child dom.Node) dom.Node {
 	this.Call("appendChild", toJSValue(child))
 	return child
@@ -279,3 +314,19 @@ func constructor(v js.Value) js.Value {
 		return v.Get("constructor")
 	}
 }
+
+// nullString returns a string, or an empty string for null or undefined
+func nullString(v js.Value) string {
+	if v.IsNull() || v.IsUndefined() {
+		return ""
+	}
+	return v.String()
+}
+
+// nullNamespace returns a namespace, or null for an empty namespace
+func nullNamespace(namespace string) any {
+	if namespace == "" {
+		return nil
+	}
+	return namespace
+}
@@ -3,6 +3,9 @@
 package dom
 
 import (
+	"slices"
+	"sync"
+
 	// Packages
 	dom "github.com/djthorpe/go-wasmbuild"
 )
@@ -11,16 +14,61 @@ import (
 // TYPES
 
 type mutationObserver struct {
-	callback func()
+	callback func([]dom.MutationRecord)
+	records  []dom.MutationRecord
+	targets  []*node
+}
+
+// observerOptions are the parsed options for an observed node
+type observerOptions struct {
+	childList             bool
+	attributes            bool
+	characterData         bool
+	subtree               bool
+	attributeOldValue     bool
+	characterDataOldValue bool
+	attributeFilter       []string
+}
+
+// registration is an observer of a node
+type registration struct {
+	observer *mutationObserver
+	options  observerOptions
+}
+
+type mutationRecord struct {
+	kind            string
+	target          dom.Node
+	added, removed  []dom.Node
+	previousSibling dom.Node
+	nextSibling     dom.Node
+	attributeName   string
+	oldValue        string
 }
 
 // Ensure mutationObserver implements MutationObserver interface
 var _ dom.MutationObserver = (*mutationObserver)(nil)
+var _ dom.MutationRecord = (*mutationRecord)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	mutationChildList     = "childList"
+	mutationAttributes    = "attributes"
+	mutationCharacterData = "characterData"
+)
+
+var (
+	// Observers with records waiting to be delivered
+	pendingObservers []*mutationObserver
+	pendingLock      sync.Mutex
+)
 
 ///////////////////////////////////////////////////////////////////////////////
 // LIFECYCLE
 
-func newMutationObserver(callback func()) *mutationObserver {
+func newMutationObserver(callback func([]dom.MutationRecord)) *mutationObserver {
 	return &mutationObserver{
 		callback: callback,
 	}
@@ -29,10 +77,213 @@ func newMutationObserver(callback func()) *mutationObserver {
 ///////////////////////////////////////////////////////////////////////////////
 // METHODS
 
+// Observe registers the observer on the target node, replacing any
+// existing options for the node. It panics if the options are invalid.
 func (m *mutationObserver) Observe(target dom.Node, options map[string]interface{}) {
-	// No-op for non-WASM builds
+	opts, err := parseObserverOptions(options)
+	if err != nil {
+		panic(err)
+	}
+	n := getNode(target)
+	for _, reg := range n.observers {
+		if reg.observer == m {
+			reg.options = opts
+			return
+		}
+	}
+	n.observers = append(n.observers, &registration{m, opts})
+	m.targets = append(m.targets, n)
 }
 
+// Disconnect removes the observer from all nodes, and discards any records
+// which have not been delivered
 func (m *mutationObserver) Disconnect() {
-	// No-op for non-WASM builds
+	for _, n := range m.targets {
+		n.observers = slices.DeleteFunc(n.observers, func(reg *registration) bool {
+			return reg.observer == m
+		})
+	}
+	m.targets = nil
+	m.TakeRecords()
+}
+
+// TakeRecords returns the records which have not been delivered, and
+// empties the record queue
+func (m *mutationObserver) TakeRecords() []dom.MutationRecord {
+	pendingLock.Lock()
+	defer pendingLock.Unlock()
+	records := m.records
+	m.records = nil
+	return records
+}
+
+// FlushMutations delivers queued mutation records to the observer
+// callbacks, in the same way as the browser does at the end of a task.
+// Mutations made by the callbacks are delivered before it returns.
+func FlushMutations() {
+	for {
+		pendingLock.Lock()
+		observers := pendingObservers
+		pendingObservers = nil
+		pendingLock.Unlock()
+		if len(observers) == 0 {
+			return
+		}
+		for _, m := range observers {
+			if records := m.TakeRecords(); len(records) > 0 && m.callback != nil {
+				m.callback(records)
+			}
+		}
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// RECORD PROPERTIES
+
+func (r *mutationRecord) Type() string {
+	return r.kind
+}
+
+func (r *mutationRecord) Target() dom.Node {
+	return r.target
+}
+
+func (r *mutationRecord) AddedNodes() []dom.Node {
+	return r.added
+}
+
+func (r *mutationRecord) RemovedNodes() []dom.Node {
+	return r.removed
+}
+
+func (r *mutationRecord) PreviousSibling() dom.Node {
+	return r.previousSibling
+}
+
+func (r *mutationRecord) NextSibling() dom.Node {
+	return r.nextSibling
+}
+
+func (r *mutationRecord) AttributeName() string {
+	return r.attributeName
+}
+
+func (r *mutationRecord) OldValue() string {
+	return r.oldValue
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// parseObserverOptions returns the options for observing a node, where
+// the old value and filter options imply the attributes and characterData
+// options
+func parseObserverOptions(options map[string]interface{}) (observerOptions, error) {
+	var opts observerOptions
+	for key, value := range options {
+		switch key {
+		case "childList", "attributes", "characterData", "subtree", "attributeOldValue", "characterDataOldValue":
+			b, ok := value.(bool)
+			if !ok {
+				return opts, dom.ErrBadParameter.Withf("option %q: expected bool", key)
+			}
+			switch key {
+			case "childList":
+				opts.childList = b
+			case "attributes":
+				opts.attributes = b
+			case "characterData":
+				opts.characterData = b
+			case "subtree":
+				opts.subtree = b
+			case "attributeOldValue":
+				opts.attributeOldValue = b
+			case "characterDataOldValue":
+				opts.characterDataOldValue = b
+			}
+		case "attributeFilter":
+			switch v := value.(type) {
+			case []string:
+				opts.attributeFilter = v
+			case []interface{}:
+				for _, name := range v {
+					if name, ok := name.(string); ok {
+						opts.attributeFilter = append(opts.attributeFilter, name)
+					} else {
+						return opts, dom.ErrBadParameter.Withf("option %q: expected strings", key)
+					}
+				}
+			default:
+				return opts, dom.ErrBadParameter.Withf("option %q: expected strings", key)
+			}
+		default:
+			return opts, dom.ErrBadParameter.Withf("unknown option %q", key)
+		}
+	}
+	if _, exists := options["attributes"]; !exists && (opts.attributeOldValue || opts.attributeFilter != nil) {
+		opts.attributes = true
+	}
+	if _, exists := options["characterData"]; !exists && opts.characterDataOldValue {
+		opts.characterData = true
+	}
+	if !opts.childList && !opts.attributes && !opts.characterData {
+		return opts, dom.ErrBadParameter.With("one of childList, attributes or characterData is required")
+	}
+	return opts, nil
+}
+
+// queueMutation queues a copy of the record for each observer which is
+// interested in a mutation of the target or its ancestors
+func queueMutation(record mutationRecord) {
+	var observers []*mutationObserver
+	var oldValues []bool
+	for n := record.target; n != nil; n = n.ParentNode() {
+		for _, reg := range getNode(n).observers {
+			opts := reg.options
+			if n != record.target && !opts.subtree {
+				continue
+			}
+			switch record.kind {
+			case mutationChildList:
+				if !opts.childList {
+					continue
+				}
+			case mutationAttributes:
+				if !opts.attributes {
+					continue
+				}
+				if opts.attributeFilter != nil && !slices.Contains(opts.attributeFilter, record.attributeName) {
+					continue
+				}
+			case mutationCharacterData:
+				if !opts.characterData {
+					continue
+				}
+			}
+			oldValue := (record.kind == mutationAttributes && opts.attributeOldValue) || (record.kind == mutationCharacterData && opts.characterDataOldValue)
+			if i := slices.Index(observers, reg.observer); i >= 0 {
+				oldValues[i] = oldValues[i] || oldValue
+			} else {
+				observers = append(observers, reg.observer)
+				oldValues = append(oldValues, oldValue)
+			}
+		}
+	}
+	if len(observers) == 0 {
+		return
+	}
+
+	// Queue the records
+	pendingLock.Lock()
+	defer pendingLock.Unlock()
+	for i, m := range observers {
+		r := record
+		if !oldValues[i] {
+			r.oldValue = ""
+		}
+		m.records = append(m.records, &r)
+		if !slices.Contains(pendingObservers, m) {
+			pendingObservers = append(pendingObservers, m)
+		}
+	}
 }
@@ -0,0 +1,65 @@
+//go:build !js
+
+package dom_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserver_FlushMutations(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	div := doc.CreateElement("div")
+
+	var calls [][]dom.MutationRecord
+	observer := domPkg.GetWindow().NewMutationObserver(func(records []dom.MutationRecord) {
+		calls = append(calls, records)
+		// Mutations made by the callback are delivered in the same flush
+		if len(calls) == 1 {
+			div.SetAttribute("data-calls", "1")
+		}
+	})
+	defer observer.Disconnect()
+	observer.Observe(div, map[string]interface{}{"childList": true, "attributes": true})
+
+	// Records are queued until flushed
+	div.AppendChild(doc.CreateElement("span"))
+	div.AppendChild(doc.CreateElement("span"))
+	assert.Empty(t, calls)
+
+	domPkg.FlushMutations()
+	if assert.Len(t, calls, 2) {
+		assert.Len(t, calls[0], 2)
+		if assert.Len(t, calls[1], 1) {
+			assert.Equal(t, "data-calls", calls[1][0].AttributeName())
+		}
+	}
+
+	// Flushing with no records does not call the callback
+	domPkg.FlushMutations()
+	assert.Len(t, calls, 2)
+}
+
+func TestObserver_SetInnerHTML(t *testing.T) {
+	div := domPkg.GetWindow().Document().CreateElement("div")
+	div.SetInnerHTML(`<p>old</p>`)
+
+	var records []dom.MutationRecord
+	observer := domPkg.GetWindow().NewMutationObserver(func(r []dom.MutationRecord) {
+		records = append(records, r...)
+	})
+	defer observer.Disconnect()
+	observer.Observe(div, map[string]interface{}{"childList": true})
+
+	// Replacing the contents is a single mutation
+	div.SetInnerHTML(`<p>new</p><p>new</p>`)
+	domPkg.FlushMutations()
+	if assert.Len(t, records, 1) {
+		assert.Len(t, records[0].AddedNodes(), 2)
+		assert.Len(t, records[0].RemovedNodes(), 1)
+	}
+}
@@ -0,0 +1,141 @@
+package dom_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+// observe returns an observer for the target, which is disconnected when
+// the test completes
+func observe(t *testing.T, target dom.Node, options map[string]interface{}) dom.MutationObserver {
+	t.Helper()
+	observer := domPkg.GetWindow().NewMutationObserver(func([]dom.MutationRecord) {})
+	observer.Observe(target, options)
+	t.Cleanup(observer.Disconnect)
+	return observer
+}
+
+func TestObserver_ChildList(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	ul := doc.CreateElement("ul")
+	first := ul.AppendChild(doc.CreateElement("li"))
+	observer := observe(t, ul, map[string]interface{}{"childList": true})
+
+	second := ul.AppendChild(doc.CreateElement("li"))
+	ul.RemoveChild(first)
+
+	records := observer.TakeRecords()
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, "childList", records[0].Type())
+		assert.True(t, ul.Equals(records[0].Target()))
+		if assert.Len(t, records[0].AddedNodes(), 1) {
+			assert.True(t, second.Equals(records[0].AddedNodes()[0]))
+		}
+		assert.Empty(t, records[0].RemovedNodes())
+		assert.True(t, first.Equals(records[0].PreviousSibling()))
+		assert.Nil(t, records[0].NextSibling())
+
+		if assert.Len(t, records[1].RemovedNodes(), 1) {
+			assert.True(t, first.Equals(records[1].RemovedNodes()[0]))
+		}
+		assert.Nil(t, records[1].PreviousSibling())
+		assert.True(t, second.Equals(records[1].NextSibling()))
+	}
+
+	// Records are only returned once
+	assert.Empty(t, observer.TakeRecords())
+}
+
+func TestObserver_Fragment(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	ul := doc.CreateElement("ul")
+	observer := observe(t, ul, map[string]interface{}{"childList": true})
+
+	// Inserting a fragment is a single mutation
+	fragment := doc.CreateDocumentFragment()
+	for i := 0; i < 3; i++ {
+		fragment.AppendChild(doc.CreateElement("li"))
+	}
+	ul.AppendChild(fragment)
+
+	records := observer.TakeRecords()
+	if assert.Len(t, records, 1) {
+		assert.Len(t, records[0].AddedNodes(), 3)
+	}
+}
+
+func TestObserver_Attributes(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	div := doc.CreateElement("div")
+	div.SetAttribute("class", "a")
+	observer := observe(t, div, map[string]interface{}{
+		"attributeOldValue": true,
+		"attributeFilter":   []interface{}{"class", "title"},
+	})
+
+	div.SetAttribute("title", "hello")
+	div.SetAttribute("id", "ignored")
+	div.ClassList().Add("b")
+	div.RemoveAttribute("title")
+
+	records := observer.TakeRecords()
+	if assert.Len(t, records, 3) {
+		assert.Equal(t, "attributes", records[0].Type())
+		assert.True(t, div.Equals(records[0].Target()))
+		assert.Equal(t, "title", records[0].AttributeName())
+		assert.Equal(t, "", records[0].OldValue())
+		assert.Equal(t, "class", records[1].AttributeName())
+		assert.Equal(t, "a", records[1].OldValue())
+		assert.Equal(t, "title", records[2].AttributeName())
+		assert.Equal(t, "hello", records[2].OldValue())
+	}
+}
+
+func TestObserver_Subtree(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	div := doc.CreateElement("div")
+	p := div.AppendChild(doc.CreateElement("p")).(dom.Element)
+	text := p.AppendChild(doc.CreateTextNode("hello")).(dom.Text)
+
+	// Without subtree, mutations of descendants are not observed
+	direct := observe(t, div, map[string]interface{}{"childList": true, "attributes": true})
+	subtree := observe(t, div, map[string]interface{}{"childList": true, "attributes": true, "characterData": true, "characterDataOldValue": true, "subtree": true})
+
+	p.SetAttribute("class", "para")
+	p.AppendChild(doc.CreateElement("br"))
+	text.SetData("world")
+
+	assert.Empty(t, direct.TakeRecords())
+	records := subtree.TakeRecords()
+	if assert.Len(t, records, 3) {
+		assert.Equal(t, "attributes", records[0].Type())
+		assert.True(t, p.Equals(records[0].Target()))
+		assert.Equal(t, "childList", records[1].Type())
+		assert.Equal(t, "characterData", records[2].Type())
+		assert.True(t, text.Equals(records[2].Target()))
+		assert.Equal(t, "hello", records[2].OldValue())
+	}
+}
+
+func TestObserver_Disconnect(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	div := doc.CreateElement("div")
+	observer := observe(t, div, map[string]interface{}{"childList": true})
+
+	div.AppendChild(doc.CreateElement("span"))
+	observer.Disconnect()
+	div.AppendChild(doc.CreateElement("span"))
+	assert.Empty(t, observer.TakeRecords())
+}
+
+func TestObserver_InvalidOptions(t *testing.T) {
+	observer := domPkg.GetWindow().NewMutationObserver(func([]dom.MutationRecord) {})
+	defer observer.Disconnect()
+	assert.Panics(t, func() {
+		observer.Observe(domPkg.GetWindow().Document().CreateElement("div"), map[string]interface{}{"subtree": true})
+	})
+}
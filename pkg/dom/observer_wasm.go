@@ -17,16 +17,21 @@ type mutationObserver struct {
 	callback js.Func
 }
 
+type mutationRecord struct {
+	js.Value
+}
+
 // Ensure mutationObserver implements MutationObserver interface
 var _ dom.MutationObserver = (*mutationObserver)(nil)
+var _ dom.MutationRecord = (*mutationRecord)(nil)
 
 ///////////////////////////////////////////////////////////////////////////////
 // LIFECYCLE
 
-func newMutationObserver(callback func()) *mutationObserver {
+func newMutationObserver(callback func([]dom.MutationRecord)) *mutationObserver {
 	// Create the callback function
 	jsCallback := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		callback()
+		callback(fromRecordList(args[0]))
 		return nil
 	})
 
@@ -73,3 +78,61 @@ func (m *mutationObserver) Disconnect() {
 	m.observer.Call("disconnect")
 	m.callback.Release()
 }
+
+func (m *mutationObserver) TakeRecords() []dom.MutationRecord {
+	return fromRecordList(m.observer.Call("takeRecords"))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// RECORD PROPERTIES
+
+func (r *mutationRecord) Type() string {
+	return r.Get("type").String()
+}
+
+func (r *mutationRecord) Target() dom.Node {
+	return NewNode(r.Get("target"))
+}
+
+func (r *mutationRecord) AddedNodes() []dom.Node {
+	return fromNodeList(r.Get("addedNodes"))
+}
+
+func (r *mutationRecord) RemovedNodes() []dom.Node {
+	return fromNodeList(r.Get("removedNodes"))
+}
+
+func (r *mutationRecord) PreviousSibling() dom.Node {
+	return NewNode(r.Get("previousSibling"))
+}
+
+func (r *mutationRecord) NextSibling() dom.Node {
+	return NewNode(r.Get("nextSibling"))
+}
+
+func (r *mutationRecord) AttributeName() string {
+	if name := r.Get("attributeName"); name.Type() == js.TypeString {
+		return name.String()
+	}
+	return ""
+}
+
+func (r *mutationRecord) OldValue() string {
+	if value := r.Get("oldValue"); value.Type() == js.TypeString {
+		return value.String()
+	}
+	return ""
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// fromRecordList returns the records in a javascript array
+func fromRecordList(v js.Value) []dom.MutationRecord {
+	length := v.Get("length").Int()
+	result := make([]dom.MutationRecord, 0, length)
+	for i := 0; i < length; i++ {
+		result = append(result, &mutationRecord{v.Index(i)})
+	}
+	return result
+}
@@ -0,0 +1,191 @@
+//go:build !js
+
+package dom
+
+import (
+	"io"
+	"mime"
+	"strings"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	html "golang.org/x/net/html"
+	atom "golang.org/x/net/html/atom"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	mimetypeHTML = "text/html"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Read parses a document from r. Only HTML is supported, where the
+// mimetype is "text/html" or empty.
+func (this *window) Read(r io.Reader, mimetype string) (dom.Document, error) {
+	if mimetype != "" {
+		if mediatype, _, err := mime.ParseMediaType(mimetype); err != nil {
+			return nil, dom.ErrBadParameter.With(err)
+		} else if mediatype != mimetypeHTML {
+			return nil, dom.ErrNotImplemented.Withf("unsupported mimetype %q", mediatype)
+		}
+	}
+
+	// Parse the document
+	root, err := html.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the document, which has a doctype, a root element and any
+	// comments before or after the root element
+	doc := NewDocument()
+	for n := root.FirstChild; n != nil; n = n.NextSibling {
+		switch n.Type {
+		case html.DoctypeNode:
+			doctype := NewNode(doc, n.Data, dom.DOCUMENT_TYPE_NODE, "").(*doctype)
+			for _, attr := range n.Attr {
+				switch attr.Key {
+				case "public":
+					doctype.publicid = attr.Val
+				case "system":
+					doctype.systemid = attr.Val
+				}
+			}
+			doc.doctype = doctype
+		case html.ElementNode, html.CommentNode:
+			doc.AppendChild(importNode(doc, n))
+		}
+	}
+
+	// Return success
+	return doc, nil
+}
+
+// ParseFragment parses HTML from r as the contents of the context
+// element, or the body element if context is nil, and returns the parsed
+// nodes, which have no parent
+func (this *window) ParseFragment(r io.Reader, context dom.Element) ([]dom.Node, error) {
+	doc := this.document
+	if context != nil {
+		if owner, ok := context.OwnerDocument().(*document); ok {
+			doc = owner
+		}
+	}
+	return parseFragment(doc, r, context)
+}
+
+// SetInnerHTML replaces the children of the element, or the content of a
+// template element, with nodes parsed from HTML
+func (this *element) SetInnerHTML(data string) {
+	nodes, err := parseFragment(this.document.(*document), strings.NewReader(data), this)
+	if err != nil {
+		// Reading from a string does not return an error
+		panic(err)
+	}
+	getNode(contentOf(this.domElement())).replaceAll(nodes)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// parseFragment parses HTML as the contents of the context element, and
+// returns the nodes created in the document
+func parseFragment(doc *document, r io.Reader, context dom.Element) ([]dom.Node, error) {
+	tag, namespace := "body", ""
+	if context != nil {
+		tag, namespace = context.LocalName(), parserNamespace(context.NamespaceURI())
+	}
+	nodes, err := html.ParseFragment(r, &html.Node{
+		Type:      html.ElementNode,
+		Data:      tag,
+		DataAtom:  atom.Lookup([]byte(tag)),
+		Namespace: namespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dom.Node, 0, len(nodes))
+	for _, n := range nodes {
+		if node := importNode(doc, n); node != nil {
+			result = append(result, node)
+		}
+	}
+	return result, nil
+}
+
+// importNode creates a node in the document from a parsed HTML node and
+// its descendants, or returns nil for nodes which are not imported
+func importNode(doc *document, n *html.Node) dom.Node {
+	switch n.Type {
+	case html.TextNode:
+		return doc.CreateTextNode(n.Data)
+	case html.CommentNode:
+		return doc.CreateComment(n.Data)
+	case html.ElementNode:
+		// Elements and attributes in foreign content have a namespace, and
+		// their names are not validated, as the parser accepts names which
+		// are not XML names
+		var elem dom.Element
+		if namespace, exists := parserNamespaces[n.Namespace]; exists {
+			elem = newNode(doc, namespace, n.Data, dom.ELEMENT_NODE, "").(dom.Element)
+		} else {
+			elem = doc.CreateElement(n.Data)
+		}
+		for _, attr := range n.Attr {
+			if namespace, exists := parserNamespaces[attr.Namespace]; exists {
+				elem.SetAttributeNS(namespace, attr.Namespace+":"+attr.Key, attr.Val)
+			} else {
+				elem.SetAttribute(attr.Key, attr.Val)
+			}
+		}
+		// The children of a template are imported into its content, and a
+		// declarative shadow root template is attached as a shadow root
+		parent := contentOf(elem)
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if importShadow(doc, elem, child) != nil {
+				continue
+			} else if node := importNode(doc, child); node != nil {
+				parent.AppendChild(node)
+			}
+		}
+		return elem
+	default:
+		return nil
+	}
+}
+
+// importShadow attaches a shadow root to the host when n is a template with
+// a valid shadowrootmode attribute, and the host can have a shadow root and
+// does not already have one. The children of the template are imported into
+// the shadow root, which is returned, or nil when n is not imported.
+func importShadow(doc *document, host dom.Element, n *html.Node) dom.ShadowRoot {
+	if n.Type != html.ElementNode || n.DataAtom != atom.Template || n.Namespace != "" {
+		return nil
+	}
+	var mode dom.ShadowRootMode
+	for _, attr := range n.Attr {
+		if attr.Namespace == "" && attr.Key == "shadowrootmode" {
+			mode = dom.ShadowRootMode(strings.ToLower(attr.Val))
+		}
+	}
+	if mode != dom.SHADOW_ROOT_OPEN && mode != dom.SHADOW_ROOT_CLOSED {
+		return nil
+	}
+	elem := elementOf(host)
+	if elem == nil || elem.shadow != nil || !elem.canHostShadow() {
+		return nil
+	}
+
+	// Attach the shadow root and import the contents of the template
+	shadow := elem.AttachShadow(mode)
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if node := importNode(doc, child); node != nil {
+			shadow.AppendChild(node)
+		}
+	}
+	return shadow
+}
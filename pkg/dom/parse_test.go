@@ -0,0 +1,182 @@
+package dom_test
+
+import (
+	"strings"
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_InnerHTML(t *testing.T) {
+	tests := []struct {
+		name, html, expected string
+	}{
+		{"elements", `<p>Hello <b>world</b></p>`, `<p>Hello <b>world</b></p>`},
+		{"comment", `<!--comment--><span class="a">x</span>`, `<!--comment--><span class="a">x</span>`},
+		{"entities", `a &amp; b &lt; c`, `a &amp; b &lt; c`},
+		{"implicit tbody", `<table><tr><td>1</td></tr></table>`, `<table><tbody><tr><td>1</td></tr></tbody></table>`},
+		{"unclosed list items", `<ul><li>One<li>Two</ul>`, `<ul><li>One</li><li>Two</li></ul>`},
+		{"unclosed paragraphs", `<p>One<p>Two`, `<p>One</p><p>Two</p>`},
+		{"misnested", `<b><i>x</b></i>`, `<b><i>x</i></b>`},
+		{"empty", ``, ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			div := domPkg.GetWindow().Document().CreateElement("div")
+			div.SetInnerHTML(tt.html)
+			assert.Equal(t, tt.expected, div.InnerHTML())
+			assert.Equal(t, "<div>"+tt.expected+"</div>", div.OuterHTML())
+
+			// Parsing the output again results in the same output
+			div.SetInnerHTML(div.InnerHTML())
+			assert.Equal(t, tt.expected, div.InnerHTML())
+		})
+	}
+}
+
+func TestParse_VoidElements(t *testing.T) {
+	div := domPkg.GetWindow().Document().CreateElement("div")
+	div.SetInnerHTML(`<p>a<br>b<img src="x.png"><input type="text">c</p>`)
+
+	p := div.FirstElementChild()
+	if !assert.NotNil(t, p) {
+		t.FailNow()
+	}
+	var names []string
+	for _, child := range p.ChildNodes() {
+		names = append(names, strings.ToLower(child.NodeName()))
+	}
+	assert.Equal(t, []string{"#text", "br", "#text", "img", "input", "#text"}, names)
+	assert.Equal(t, "x.png", p.QuerySelector("img").GetAttribute("src"))
+	assert.False(t, p.QuerySelector("img").HasChildNodes())
+}
+
+func TestParse_Replace(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	div := doc.CreateElement("div")
+	old := div.AppendChild(doc.CreateElement("span"))
+
+	div.SetInnerHTML(`<em>new</em>`)
+	assert.Equal(t, `<em>new</em>`, div.InnerHTML())
+	assert.Nil(t, old.ParentNode())
+	assert.Equal(t, div, div.FirstChild().ParentNode())
+	assert.Equal(t, div, div.FirstChild().ParentElement())
+
+	div.SetInnerHTML("")
+	assert.False(t, div.HasChildNodes())
+}
+
+func TestParse_Context(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+
+	// Table rows are only parsed within a table context
+	tbody := doc.CreateElement("tbody")
+	tbody.SetInnerHTML(`<tr><td>1</td></tr>`)
+	assert.Equal(t, `<tr><td>1</td></tr>`, tbody.InnerHTML())
+
+	// Raw text elements are not parsed
+	pre := doc.CreateElement("textarea")
+	pre.SetInnerHTML(`<b>bold</b>`)
+	assert.Equal(t, 1, len(pre.ChildNodes()))
+	assert.Equal(t, dom.TEXT_NODE, pre.FirstChild().NodeType())
+	assert.Equal(t, `<b>bold</b>`, pre.TextContent())
+}
+
+func TestParse_Fragment(t *testing.T) {
+	window := domPkg.GetWindow()
+
+	nodes, err := window.ParseFragment(strings.NewReader(`text<p>para</p><!--c-->`), nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	if assert.Len(t, nodes, 3) {
+		assert.Equal(t, dom.TEXT_NODE, nodes[0].NodeType())
+		assert.Equal(t, dom.ELEMENT_NODE, nodes[1].NodeType())
+		assert.Equal(t, dom.COMMENT_NODE, nodes[2].NodeType())
+		for _, node := range nodes {
+			assert.Nil(t, node.ParentNode())
+		}
+	}
+
+	// Parse in the context of a select element
+	context := window.Document().CreateElement("select")
+	nodes, err = window.ParseFragment(strings.NewReader(`<option>1<option>2`), context)
+	if assert.NoError(t, err) && assert.Len(t, nodes, 2) {
+		assert.Equal(t, "<option>1</option>", nodes[0].(dom.Element).OuterHTML())
+		assert.Equal(t, "<option>2</option>", nodes[1].(dom.Element).OuterHTML())
+	}
+}
+
+func TestParse_Read(t *testing.T) {
+	window := domPkg.GetWindow()
+
+	doc, err := window.Read(strings.NewReader(`<!DOCTYPE html><html><head><title>Test</title><meta charset="utf-8"></head><body><p>x</p></body></html>`), "text/html")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	if assert.NotNil(t, doc.Doctype()) {
+		assert.Equal(t, "html", doc.Doctype().Name())
+	}
+	assert.Equal(t, "Test", doc.Title())
+	if assert.NotNil(t, doc.Body()) {
+		assert.Equal(t, "<p>x</p>", doc.Body().InnerHTML())
+	}
+	assert.NotNil(t, doc.QuerySelector("head > meta[charset]"))
+	assert.NotEqual(t, window.Document(), doc)
+}
+
+func TestParse_ReadImplicit(t *testing.T) {
+	window := domPkg.GetWindow()
+
+	// The html, head and body elements are implied
+	doc, err := window.Read(strings.NewReader(`<title>Implicit</title><p>Hello<p>World`), "text/html; charset=utf-8")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Nil(t, doc.Doctype())
+	assert.Equal(t, "Implicit", doc.Title())
+	if assert.NotNil(t, doc.Body()) {
+		assert.Equal(t, "<p>Hello</p><p>World</p>", doc.Body().InnerHTML())
+		assert.Equal(t, "HTML", doc.Body().ParentElement().TagName())
+	}
+	for _, p := range doc.QuerySelectorAll("p") {
+		assert.Equal(t, doc, p.OwnerDocument())
+	}
+}
+
+func TestParse_ReadComments(t *testing.T) {
+	doc, err := domPkg.GetWindow().Read(strings.NewReader(`<!DOCTYPE html><!--before--><html><head></head><body><p>x</p></body></html><!--after-->`), "")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	// Comments outside the root element are children of the document
+	var names []string
+	for _, child := range doc.ChildNodes() {
+		names = append(names, strings.ToLower(child.NodeName()))
+	}
+	assert.Equal(t, []string{"#comment", "html", "#comment"}, names)
+	assert.Equal(t, "HTML", doc.DocumentElement().TagName())
+	assert.Equal(t, "before", doc.FirstChild().(dom.Comment).Data())
+	assert.Equal(t, "after", doc.LastChild().(dom.Comment).Data())
+
+	// The comments are kept by a deep clone
+	clone := doc.CloneNode(true)
+	assert.Len(t, clone.ChildNodes(), 3)
+	assert.Equal(t, "after", clone.LastChild().(dom.Comment).Data())
+}
+
+func TestParse_ReadDoctype(t *testing.T) {
+	doc, err := domPkg.GetWindow().Read(strings.NewReader(`<!DOCTYPE html PUBLIC "-//W3C//DTD HTML 4.01//EN" "http://www.w3.org/TR/html4/strict.dtd"><p>x`), "")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	if assert.NotNil(t, doc.Doctype()) {
+		assert.Equal(t, "html", doc.Doctype().Name())
+		assert.Equal(t, "-//W3C//DTD HTML 4.01//EN", doc.Doctype().PublicId())
+		assert.Equal(t, "http://www.w3.org/TR/html4/strict.dtd", doc.Doctype().SystemId())
+	}
+}
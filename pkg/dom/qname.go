@@ -0,0 +1,82 @@
+package dom
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// splitName returns the prefix and local name of a qualified name
+func splitName(name string) (string, string) {
+	if prefix, local, found := strings.Cut(name, ":"); found {
+		return prefix, local
+	}
+	return "", name
+}
+
+// validateName panics if a qualified name is not valid for a namespace,
+// where the prefix and local name must be XML names without a colon
+func validateName(namespace, name string) {
+	prefix, local := splitName(name)
+	switch {
+	case !isNCName(local) || (strings.Contains(name, ":") && !isNCName(prefix)):
+		panic(dom.ErrBadParameter.Withf("invalid name %q", name))
+	case prefix != "" && namespace == "":
+		panic(dom.ErrBadParameter.Withf("prefix %q without a namespace", prefix))
+	case prefix == "xml" && namespace != dom.NS_XML:
+		panic(dom.ErrBadParameter.Withf("prefix %q is not in the XML namespace", prefix))
+	case (prefix == "xmlns" || name == "xmlns") != (namespace == dom.NS_XMLNS):
+		panic(dom.ErrBadParameter.Withf("name %q does not match namespace %q", name, namespace))
+	}
+}
+
+// isNCName returns true if a name is a valid XML name without a colon,
+// which starts with a letter or underscore
+func isNCName(name string) bool {
+	if name == "" || !utf8.ValidString(name) {
+		return false
+	}
+	for i, r := range name {
+		if !isNameStartChar(r) && (i == 0 || !isNameChar(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// isNameStartChar returns true if a rune can start an XML name, other than
+// a colon
+func isNameStartChar(r rune) bool {
+	switch {
+	case r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z', r == '_':
+		return true
+	case r >= 0xC0 && r <= 0xD6, r >= 0xD8 && r <= 0xF6, r >= 0xF8 && r <= 0x2FF:
+		return true
+	case r >= 0x370 && r <= 0x37D, r >= 0x37F && r <= 0x1FFF, r >= 0x200C && r <= 0x200D:
+		return true
+	case r >= 0x2070 && r <= 0x218F, r >= 0x2C00 && r <= 0x2FEF, r >= 0x3001 && r <= 0xD7FF:
+		return true
+	case r >= 0xF900 && r <= 0xFDCF, r >= 0xFDF0 && r <= 0xFFFD, r >= 0x10000 && r <= 0xEFFFF:
+		return true
+	default:
+		return false
+	}
+}
+
+// isNameChar returns true if a rune can appear after the first rune of an
+// XML name, other than a colon
+func isNameChar(r rune) bool {
+	switch {
+	case isNameStartChar(r), r >= '0' && r <= '9', r == '-', r == '.', r == 0xB7:
+		return true
+	case r >= 0x300 && r <= 0x36F, r >= 0x203F && r <= 0x2040:
+		return true
+	default:
+		return false
+	}
+}
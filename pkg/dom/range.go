@@ -0,0 +1,346 @@
+//go:build !js
+
+package dom
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf16"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// TYPES
+
+type domRange struct {
+	document    *document
+	start, end  dom.Node
+	startOffset int
+	endOffset   int
+}
+
+var _ dom.Range = (*domRange)(nil)
+
+/////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// newRange returns a collapsed range at the start of the document
+func newRange(doc *document) *domRange {
+	return &domRange{document: doc, start: doc, end: doc}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (this *domRange) String() string {
+	var b strings.Builder
+	b.WriteString("<DOMRange")
+	fmt.Fprintf(&b, " start=%q:%d", this.start.NodeName(), this.startOffset)
+	fmt.Fprintf(&b, " end=%q:%d", this.end.NodeName(), this.endOffset)
+	b.WriteString(">")
+	return b.String()
+}
+
+/////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (this *domRange) StartContainer() dom.Node {
+	return this.start
+}
+
+func (this *domRange) StartOffset() int {
+	return this.startOffset
+}
+
+func (this *domRange) EndContainer() dom.Node {
+	return this.end
+}
+
+func (this *domRange) EndOffset() int {
+	return this.endOffset
+}
+
+func (this *domRange) Collapsed() bool {
+	return this.start == this.end && this.startOffset == this.endOffset
+}
+
+func (this *domRange) CommonAncestorContainer() dom.Node {
+	ancestors := inclusiveAncestors(this.end)
+	for node := this.start; node != nil; node = node.ParentNode() {
+		if slices.Contains(ancestors, node) {
+			return node
+		}
+	}
+	return nil
+}
+
+/////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS - BOUNDARY
+
+// SetStart sets the start of the range, and collapses the range to the
+// start if the end is before the start or in a different tree
+func (this *domRange) SetStart(node dom.Node, offset int) {
+	checkBoundary(node, offset)
+	this.start, this.startOffset = node, offset
+	if rangeRoot(node) != rangeRoot(this.end) || compareBoundary(node, offset, this.end, this.endOffset) > 0 {
+		this.end, this.endOffset = node, offset
+	}
+}
+
+// SetEnd sets the end of the range, and collapses the range to the
+// end if the start is after the end or in a different tree
+func (this *domRange) SetEnd(node dom.Node, offset int) {
+	checkBoundary(node, offset)
+	this.end, this.endOffset = node, offset
+	if rangeRoot(node) != rangeRoot(this.start) || compareBoundary(this.start, this.startOffset, node, offset) > 0 {
+		this.start, this.startOffset = node, offset
+	}
+}
+
+// SelectNode sets the range to contain the node
+func (this *domRange) SelectNode(node dom.Node) {
+	parent := node.ParentNode()
+	if parent == nil {
+		panic(dom.ErrBadParameter.With("node has no parent"))
+	}
+	index := nodeIndex(node)
+	this.start, this.startOffset = parent, index
+	this.end, this.endOffset = parent, index+1
+}
+
+// SelectNodeContents sets the range to contain the contents of the node
+func (this *domRange) SelectNodeContents(node dom.Node) {
+	if node.NodeType() == dom.DOCUMENT_TYPE_NODE {
+		panic(dom.ErrBadParameter.With("invalid node type"))
+	}
+	this.start, this.startOffset = node, 0
+	this.end, this.endOffset = node, nodeLength(node)
+}
+
+// Collapse sets the end of the range to the start, or the start of the
+// range to the end
+func (this *domRange) Collapse(toStart bool) {
+	if toStart {
+		this.end, this.endOffset = this.start, this.startOffset
+	} else {
+		this.start, this.startOffset = this.end, this.endOffset
+	}
+}
+
+/////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS - CONTENTS
+
+// CloneContents returns a fragment with a copy of the contents of the range
+func (this *domRange) CloneContents() dom.DocumentFragment {
+	return this.contents(false)
+}
+
+// ExtractContents moves the contents of the range into a fragment, and
+// collapses the range
+func (this *domRange) ExtractContents() dom.DocumentFragment {
+	return this.contents(true)
+}
+
+// DeleteContents removes the contents of the range from the tree, and
+// collapses the range
+func (this *domRange) DeleteContents() {
+	this.contents(true)
+}
+
+/////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// contents implements the clone and extract algorithms of the DOM standard,
+// where partially contained nodes are copied into the fragment, and fully
+// contained nodes are moved into the fragment when extract is true
+func (this *domRange) contents(extract bool) dom.DocumentFragment {
+	doc := this.document
+	if owner, ok := this.start.OwnerDocument().(*document); ok {
+		doc = owner
+	}
+	fragment := doc.CreateDocumentFragment()
+	if this.Collapsed() {
+		return fragment
+	}
+
+	// When the range is within a single text or comment node, copy the
+	// selected characters
+	start, startOffset, end, endOffset := this.start, this.startOffset, this.end, this.endOffset
+	if start == end && isCharacterData(start) {
+		fragment.AppendChild(cloneData(start, startOffset, endOffset, extract))
+		return fragment
+	}
+
+	// Determine the children of the common ancestor which are partially
+	// or fully contained by the range
+	common := this.CommonAncestorContainer()
+	var first, last dom.Node
+	var contained []dom.Node
+	for child := common.FirstChild(); child != nil; child = child.NextSibling() {
+		switch {
+		case isInclusiveAncestor(child, start) && !isInclusiveAncestor(start, end):
+			first = child
+		case isInclusiveAncestor(child, end) && !isInclusiveAncestor(end, start):
+			last = child
+		case compareBoundary(common, nodeIndex(child), start, startOffset) >= 0 && compareBoundary(common, nodeIndex(child)+1, end, endOffset) <= 0:
+			contained = append(contained, child)
+		}
+	}
+
+	// Determine where the range is collapsed to after extraction
+	collapseNode, collapseOffset := start, startOffset
+	if !isInclusiveAncestor(start, end) {
+		ref := start
+		for !isInclusiveAncestor(ref.ParentNode(), end) {
+			ref = ref.ParentNode()
+		}
+		collapseNode, collapseOffset = ref.ParentNode(), nodeIndex(ref)+1
+	}
+
+	// Copy the partially contained start node
+	if first != nil {
+		if isCharacterData(first) {
+			fragment.AppendChild(cloneData(first, startOffset, nodeLength(first), extract))
+		} else {
+			clone := first.CloneNode(false)
+			fragment.AppendChild(clone)
+			subrange := &domRange{document: doc, start: start, startOffset: startOffset, end: first, endOffset: nodeLength(first)}
+			clone.AppendChild(subrange.contents(extract))
+		}
+	}
+
+	// Move or copy the contained nodes
+	for _, child := range contained {
+		if extract {
+			fragment.AppendChild(child)
+		} else {
+			fragment.AppendChild(child.CloneNode(true))
+		}
+	}
+
+	// Copy the partially contained end node
+	if last != nil {
+		if isCharacterData(last) {
+			fragment.AppendChild(cloneData(last, 0, endOffset, extract))
+		} else {
+			clone := last.CloneNode(false)
+			fragment.AppendChild(clone)
+			subrange := &domRange{document: doc, start: last, startOffset: 0, end: end, endOffset: endOffset}
+			clone.AppendChild(subrange.contents(extract))
+		}
+	}
+
+	// Collapse the range
+	if extract {
+		this.start, this.startOffset = collapseNode, collapseOffset
+		this.end, this.endOffset = collapseNode, collapseOffset
+	}
+
+	// Return the fragment
+	return fragment
+}
+
+// checkBoundary panics if the boundary point is not valid
+func checkBoundary(node dom.Node, offset int) {
+	if node == nil {
+		panic(dom.ErrBadParameter.With("missing boundary node"))
+	}
+	if node.NodeType() == dom.DOCUMENT_TYPE_NODE || node.NodeType() == dom.ATTRIBUTE_NODE {
+		panic(dom.ErrBadParameter.Withf("invalid boundary node %q", node.NodeName()))
+	}
+	if offset < 0 || offset > nodeLength(node) {
+		panic(dom.ErrBadParameter.Withf("invalid boundary offset %d", offset))
+	}
+}
+
+// compareBoundary returns -1, 0 or 1 when the first boundary point is
+// before, equal to or after the second boundary point, in a tree with a
+// common root. Boundary points are compared by the path of child indexes
+// from the root, followed by the offset.
+func compareBoundary(a dom.Node, aOffset int, b dom.Node, bOffset int) int {
+	return slices.Compare(append(nodePath(a), aOffset), append(nodePath(b), bOffset))
+}
+
+// nodePath returns the child indexes from the root of the tree to node
+func nodePath(node dom.Node) []int {
+	var path []int
+	for ; node.ParentNode() != nil; node = node.ParentNode() {
+		path = append(path, nodeIndex(node))
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// nodeIndex returns the index of the node within its parent
+func nodeIndex(node dom.Node) int {
+	index := 0
+	for sibling := node.PreviousSibling(); sibling != nil; sibling = sibling.PreviousSibling() {
+		index++
+	}
+	return index
+}
+
+// nodeLength returns the number of UTF-16 code units in a text or comment
+// node, or the number of children otherwise
+func nodeLength(node dom.Node) int {
+	switch node.NodeType() {
+	case dom.DOCUMENT_TYPE_NODE:
+		return 0
+	case dom.TEXT_NODE, dom.COMMENT_NODE:
+		return dataLength(getNode(node).cdata)
+	default:
+		return len(getNode(node).children)
+	}
+}
+
+// rangeRoot returns the root of the tree which contains node
+func rangeRoot(node dom.Node) dom.Node {
+	for parent := node.ParentNode(); parent != nil; parent = parent.ParentNode() {
+		node = parent
+	}
+	return getNode(node).self
+}
+
+// inclusiveAncestors returns the node and its ancestors
+func inclusiveAncestors(node dom.Node) []dom.Node {
+	var result []dom.Node
+	for ; node != nil; node = node.ParentNode() {
+		result = append(result, node)
+	}
+	return result
+}
+
+// isInclusiveAncestor returns true if ancestor is node or an ancestor of node
+func isInclusiveAncestor(ancestor, node dom.Node) bool {
+	return slices.Contains(inclusiveAncestors(node), ancestor)
+}
+
+func isCharacterData(node dom.Node) bool {
+	return node.NodeType() == dom.TEXT_NODE || node.NodeType() == dom.COMMENT_NODE
+}
+
+// cloneData returns a copy of a text or comment node with the UTF-16 code
+// units between start and end, which are removed from the node when extract
+// is true
+func cloneData(node dom.Node, start, end int, extract bool) dom.Node {
+	n := getNode(node)
+	data := utf16.Encode([]rune(n.cdata))
+	clone := NewNode(n.document, n.name, n.nodetype, string(utf16.Decode(data[start:end])))
+	if extract {
+		n.setData(string(utf16.Decode(slices.Concat(data[:start], data[end:]))))
+	}
+	return clone
+}
+
+// dataLength returns the length of the data of a text or comment node in
+// UTF-16 code units, which is how offsets are counted in the browser
+func dataLength(data string) int {
+	length := 0
+	for _, r := range data {
+		length += utf16.RuneLen(r)
+	}
+	return length
+}
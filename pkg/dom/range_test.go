@@ -0,0 +1,156 @@
+package dom_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+// rangeFixture returns <div><p>Hello <b>bold</b> world</p><p>Second</p></div>
+func rangeFixture() dom.Element {
+	div := domPkg.GetWindow().Document().CreateElement("div")
+	div.SetInnerHTML(`<p>Hello <b>bold</b> world</p><p>Second</p>`)
+	return div
+}
+
+// fragmentHTML returns the HTML for the children of a fragment
+func fragmentHTML(fragment dom.DocumentFragment) string {
+	div := domPkg.GetWindow().Document().CreateElement("div")
+	div.AppendChild(fragment)
+	return div.InnerHTML()
+}
+
+func TestRange_Create(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	r := doc.CreateRange()
+	if !assert.NotNil(t, r) {
+		t.FailNow()
+	}
+	assert.True(t, r.Collapsed())
+	assert.True(t, doc.Equals(r.StartContainer()))
+	assert.Equal(t, 0, r.StartOffset())
+	assert.Equal(t, "", fragmentHTML(r.CloneContents()))
+}
+
+func TestRange_Boundary(t *testing.T) {
+	div := rangeFixture()
+	p := div.FirstElementChild()
+	text := p.FirstChild()
+	r := domPkg.GetWindow().Document().CreateRange()
+
+	r.SetStart(text, 2)
+	r.SetEnd(p, 2)
+	assert.True(t, text.Equals(r.StartContainer()))
+	assert.Equal(t, 2, r.StartOffset())
+	assert.True(t, p.Equals(r.EndContainer()))
+	assert.Equal(t, 2, r.EndOffset())
+	assert.False(t, r.Collapsed())
+	assert.True(t, p.Equals(r.CommonAncestorContainer()))
+
+	// Setting the end before the start collapses the range
+	r.SetEnd(text, 1)
+	assert.True(t, r.Collapsed())
+	assert.Equal(t, 1, r.StartOffset())
+
+	r.SelectNode(p)
+	assert.True(t, div.Equals(r.StartContainer()))
+	assert.Equal(t, 0, r.StartOffset())
+	assert.Equal(t, 1, r.EndOffset())
+
+	r.SelectNodeContents(div)
+	assert.Equal(t, 0, r.StartOffset())
+	assert.Equal(t, 2, r.EndOffset())
+	r.Collapse(false)
+	assert.True(t, r.Collapsed())
+	assert.Equal(t, 2, r.StartOffset())
+
+	// Invalid offsets panic
+	assert.Panics(t, func() {
+		r.SetStart(p, 10)
+	})
+}
+
+func TestRange_CloneContents(t *testing.T) {
+	div := rangeFixture()
+	p := div.FirstElementChild()
+	r := domPkg.GetWindow().Document().CreateRange()
+
+	// Within a single text node
+	r.SetStart(p.FirstChild(), 1)
+	r.SetEnd(p.FirstChild(), 4)
+	assert.Equal(t, "ell", fragmentHTML(r.CloneContents()))
+
+	// Across elements, with partially selected nodes copied
+	r.SetEnd(p.QuerySelector("b").FirstChild(), 2)
+	assert.Equal(t, "ello <b>bo</b>", fragmentHTML(r.CloneContents()))
+
+	r.SetEnd(div.LastElementChild().FirstChild(), 3)
+	assert.Equal(t, "<p>ello <b>bold</b> world</p><p>Sec</p>", fragmentHTML(r.CloneContents()))
+
+	// The tree is not modified
+	assert.Equal(t, `<p>Hello <b>bold</b> world</p><p>Second</p>`, div.InnerHTML())
+}
+
+func TestRange_ExtractContents(t *testing.T) {
+	div := rangeFixture()
+	p := div.FirstElementChild()
+	r := domPkg.GetWindow().Document().CreateRange()
+
+	r.SetStart(p.FirstChild(), 5)
+	r.SetEnd(p.LastChild(), 1)
+	assert.Equal(t, " <b>bold</b> ", fragmentHTML(r.ExtractContents()))
+	assert.Equal(t, `<p>Helloworld</p><p>Second</p>`, div.InnerHTML())
+
+	// The range is collapsed to where the contents were removed
+	assert.True(t, r.Collapsed())
+	assert.True(t, p.Equals(r.StartContainer()))
+	assert.Equal(t, 1, r.StartOffset())
+}
+
+func TestRange_DeleteContents(t *testing.T) {
+	div := rangeFixture()
+	r := domPkg.GetWindow().Document().CreateRange()
+
+	r.SetStart(div.FirstElementChild().FirstChild(), 2)
+	r.SetEnd(div.LastElementChild().FirstChild(), 3)
+	r.DeleteContents()
+	assert.Equal(t, `<p>He</p><p>ond</p>`, div.InnerHTML())
+	assert.True(t, r.Collapsed())
+	assert.True(t, div.Equals(r.StartContainer()))
+	assert.Equal(t, 1, r.StartOffset())
+
+	// Selecting and deleting a node removes it
+	r.SelectNode(div.FirstElementChild())
+	r.DeleteContents()
+	assert.Equal(t, `<p>ond</p>`, div.InnerHTML())
+}
+
+func TestRange_NonASCII(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	div := doc.CreateElement("div")
+	div.SetInnerHTML(`<p>héllo 🌍 world</p>`)
+	text := div.FirstElementChild().FirstChild().(dom.Text)
+	r := doc.CreateRange()
+
+	// Offsets and lengths are counted in UTF-16 code units
+	assert.Equal(t, 14, text.Length())
+	r.SetEnd(text, text.Length())
+	r.SetStart(text, 1)
+	r.SetEnd(text, 8)
+	assert.Equal(t, "éllo 🌍", fragmentHTML(r.CloneContents()))
+
+	r.SelectNodeContents(text)
+	assert.Equal(t, 14, r.EndOffset())
+	r.SetStart(text, 6)
+	r.SetEnd(text, 9)
+	assert.Equal(t, "🌍 ", fragmentHTML(r.ExtractContents()))
+	assert.Equal(t, "héllo world", text.Data())
+	assert.Equal(t, 11, text.Length())
+
+	// Comments are counted in the same way
+	comment := doc.CreateComment("é🌍")
+	assert.Equal(t, 3, comment.Length())
+}
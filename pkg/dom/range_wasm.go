@@ -0,0 +1,92 @@
+//go:build js
+
+package dom
+
+import (
+	"fmt"
+	"syscall/js"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// TYPES
+
+type domRange struct {
+	js.Value
+}
+
+var _ dom.Range = (*domRange)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (this *domRange) String() string {
+	str := "<DOMRange"
+	str += fmt.Sprintf(" start=%q:%d", this.StartContainer().NodeName(), this.StartOffset())
+	str += fmt.Sprintf(" end=%q:%d", this.EndContainer().NodeName(), this.EndOffset())
+	return str + ">"
+}
+
+/////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (this *domRange) StartContainer() dom.Node {
+	return NewNode(this.Get("startContainer"))
+}
+
+func (this *domRange) StartOffset() int {
+	return this.Get("startOffset").Int()
+}
+
+func (this *domRange) EndContainer() dom.Node {
+	return NewNode(this.Get("endContainer"))
+}
+
+func (this *domRange) EndOffset() int {
+	return this.Get("endOffset").Int()
+}
+
+func (this *domRange) Collapsed() bool {
+	return this.Get("collapsed").Bool()
+}
+
+func (this *domRange) CommonAncestorContainer() dom.Node {
+	return NewNode(this.Get("commonAncestorContainer"))
+}
+
+/////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (this *domRange) SetStart(node dom.Node, offset int) {
+	this.Call("setStart", toJSValue(node), offset)
+}
+
+func (this *domRange) SetEnd(node dom.Node, offset int) {
+	this.Call("setEnd", toJSValue(node), offset)
+}
+
+func (this *domRange) SelectNode(node dom.Node) {
+	this.Call("selectNode", toJSValue(node))
+}
+
+func (this *domRange) SelectNodeContents(node dom.Node) {
+	this.Call("selectNodeContents", toJSValue(node))
+}
+
+func (this *domRange) Collapse(toStart bool) {
+	this.Call("collapse", toStart)
+}
+
+func (this *domRange) CloneContents() dom.DocumentFragment {
+	return NewNode(this.Call("cloneContents")).(dom.DocumentFragment)
+}
+
+func (this *domRange) ExtractContents() dom.DocumentFragment {
+	return NewNode(this.Call("extractContents")).(dom.DocumentFragment)
+}
+
+func (this *domRange) DeleteContents() {
+	this.Call("deleteContents")
+}
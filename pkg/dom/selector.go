@@ -0,0 +1,667 @@
+//go:build !js
+
+package dom
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// TYPES
+
+// selector is a parsed selector list, which matches an element if any of
+// the complex selectors match
+type selector []*complexSelector
+
+// complexSelector is a sequence of compound selectors separated by
+// combinators, where combinators[i] joins compounds[i] and compounds[i+1]
+type complexSelector struct {
+	compounds   []compound
+	combinators []rune
+}
+
+// compound is a sequence of simple selectors which must all match
+type compound []matcher
+
+// matcher matches a single element, with scope as the element the
+// selector is evaluated against (or nil for a document)
+type matcher func(elem, scope dom.Element) bool
+
+// selectorParser is a recursive descent parser for selectors
+type selectorParser struct {
+	src string
+	pos int
+}
+
+/////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// parseSelector parses a selector list, returning an error if the
+// selector is invalid
+func parseSelector(src string) (selector, error) {
+	p := &selectorParser{src: src}
+	sel, err := p.parseList()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q", p.src[p.pos:])
+	}
+	return sel, nil
+}
+
+// mustParseSelector parses a selector list, and panics if the selector is
+// invalid, in the same way a browser throws a SyntaxError
+func mustParseSelector(src string) selector {
+	sel, err := parseSelector(src)
+	if err != nil {
+		panic(err)
+	}
+	return sel
+}
+
+/////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (this *element) QuerySelector(sel string) dom.Element {
+	return querySelector(this.node, this.domElement(), mustParseSelector(sel))
+}
+
+func (this *element) QuerySelectorAll(sel string) []dom.Element {
+	return querySelectorAll(this.node, this.domElement(), mustParseSelector(sel))
+}
+
+func (this *element) Matches(sel string) bool {
+	return mustParseSelector(sel).match(this.domElement(), this.domElement())
+}
+
+func (this *element) Closest(sel string) dom.Element {
+	s := mustParseSelector(sel)
+	for elem := this.domElement(); elem != nil; elem = elem.ParentElement() {
+		if s.match(elem, this.domElement()) {
+			return elem
+		}
+	}
+	return nil
+}
+
+func (this *document) QuerySelector(sel string) dom.Element {
+	return querySelector(this.node, nil, mustParseSelector(sel))
+}
+
+func (this *document) QuerySelectorAll(sel string) []dom.Element {
+	return querySelectorAll(this.node, nil, mustParseSelector(sel))
+}
+
+/////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS - QUERY
+
+// querySelector returns the first descendant of root in document order
+// which matches the selector
+func querySelector(root *node, scope dom.Element, sel selector) dom.Element {
+	var result dom.Element
+	walkElements(root, func(elem dom.Element) bool {
+		if sel.match(elem, scope) {
+			result = elem
+			return false
+		}
+		return true
+	})
+	return result
+}
+
+// querySelectorAll returns the descendants of root in document order which
+// match the selector
+func querySelectorAll(root *node, scope dom.Element, sel selector) []dom.Element {
+	result := []dom.Element{}
+	walkElements(root, func(elem dom.Element) bool {
+		if sel.match(elem, scope) {
+			result = append(result, elem)
+		}
+		return true
+	})
+	return result
+}
+
+// walkElements calls fn for each descendant element of root in document
+// order, until fn returns false
+func walkElements(root *node, fn func(dom.Element) bool) bool {
+	for _, child := range root.children {
+		if elem, ok := child.(dom.Element); ok {
+			if !fn(elem) {
+				return false
+			}
+		}
+		if !walkElements(getNode(child), fn) {
+			return false
+		}
+	}
+	return true
+}
+
+/////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS - MATCH
+
+func (s selector) match(elem, scope dom.Element) bool {
+	for _, c := range s {
+		if c.match(elem, scope, len(c.compounds)-1) {
+			return true
+		}
+	}
+	return false
+}
+
+// match an element against the compounds up to and including index i,
+// working from right to left
+func (c *complexSelector) match(elem, scope dom.Element, i int) bool {
+	if !c.compounds[i].match(elem, scope) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	switch c.combinators[i-1] {
+	case '>':
+		if parent := elem.ParentElement(); parent != nil {
+			return c.match(parent, scope, i-1)
+		}
+	case ' ':
+		for parent := elem.ParentElement(); parent != nil; parent = parent.ParentElement() {
+			if c.match(parent, scope, i-1) {
+				return true
+			}
+		}
+	case '+':
+		if sibling := elem.PreviousElementSibling(); sibling != nil {
+			return c.match(sibling, scope, i-1)
+		}
+	case '~':
+		for sibling := elem.PreviousElementSibling(); sibling != nil; sibling = sibling.PreviousElementSibling() {
+			if c.match(sibling, scope, i-1) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c compound) match(elem, scope dom.Element) bool {
+	for _, m := range c {
+		if !m(elem, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+/////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS - PARSE
+
+func (p *selectorParser) errorf(format string, args ...any) error {
+	return dom.ErrBadParameter.Withf("invalid selector %q: %s", p.src, fmt.Sprintf(format, args...))
+}
+
+// parseList parses comma-separated complex selectors
+func (p *selectorParser) parseList() (selector, error) {
+	var result selector
+	for {
+		c, err := p.parseComplex()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+		if p.skipSpace(); !p.accept(',') {
+			return result, nil
+		}
+	}
+}
+
+// parseComplex parses compound selectors separated by combinators
+func (p *selectorParser) parseComplex() (*complexSelector, error) {
+	c := new(complexSelector)
+	p.skipSpace()
+	for {
+		compound, err := p.parseCompound()
+		if err != nil {
+			return nil, err
+		}
+		c.compounds = append(c.compounds, compound)
+
+		// Determine the combinator, which may be whitespace
+		space := p.skipSpace()
+		if p.pos >= len(p.src) {
+			return c, nil
+		}
+		switch ch := rune(p.src[p.pos]); ch {
+		case '>', '+', '~':
+			p.pos++
+			p.skipSpace()
+			c.combinators = append(c.combinators, ch)
+		case ',', ')':
+			return c, nil
+		default:
+			if !space {
+				return nil, p.errorf("unexpected %q", p.src[p.pos:])
+			}
+			c.combinators = append(c.combinators, ' ')
+		}
+	}
+}
+
+// parseCompound parses a type selector followed by id, class, attribute
+// and pseudo-class selectors
+func (p *selectorParser) parseCompound() (compound, error) {
+	var result compound
+
+	// Type or universal selector
+	universal := p.accept('*')
+	if !universal {
+		if name := p.parseIdent(); name != "" {
+			result = append(result, func(elem, _ dom.Element) bool {
+				return matchTagName(elem, name)
+			})
+		}
+	}
+
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case '#':
+			p.pos++
+			id := p.parseIdent()
+			if id == "" {
+				return nil, p.errorf("expected identifier after '#'")
+			}
+			result = append(result, func(elem, _ dom.Element) bool {
+				return elem.GetAttribute("id") == id
+			})
+		case '.':
+			p.pos++
+			class := p.parseIdent()
+			if class == "" {
+				return nil, p.errorf("expected identifier after '.'")
+			}
+			result = append(result, func(elem, _ dom.Element) bool {
+				return containsToken(elem.GetAttribute("class"), class)
+			})
+		case '[':
+			p.pos++
+			m, err := p.parseAttr()
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, m)
+		case ':':
+			p.pos++
+			m, err := p.parsePseudo()
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, m)
+		default:
+			if len(result) == 0 && !universal {
+				return nil, p.errorf("unexpected %q", p.src[p.pos:])
+			}
+			return result, nil
+		}
+	}
+	if len(result) == 0 && !universal {
+		return nil, p.errorf("unexpected end of selector")
+	}
+	return result, nil
+}
+
+// parseAttr parses an attribute selector after the opening bracket
+func (p *selectorParser) parseAttr() (matcher, error) {
+	p.skipSpace()
+	name := p.parseIdent()
+	if name == "" {
+		return nil, p.errorf("expected attribute name")
+	}
+	p.skipSpace()
+
+	// Attribute presence
+	if p.accept(']') {
+		return func(elem, _ dom.Element) bool {
+			return elem.HasAttribute(name)
+		}, nil
+	}
+
+	// Operator
+	var op string
+	for _, candidate := range []string{"=", "~=", "|=", "^=", "$=", "*="} {
+		if strings.HasPrefix(p.src[p.pos:], candidate) {
+			op = candidate
+			p.pos += len(candidate)
+			break
+		}
+	}
+	if op == "" {
+		return nil, p.errorf("expected attribute operator")
+	}
+
+	// Value, which is a string or identifier
+	p.skipSpace()
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	// Case-insensitive flag
+	p.skipSpace()
+	fold := false
+	if p.pos < len(p.src) && (p.src[p.pos] == 'i' || p.src[p.pos] == 'I') {
+		fold = true
+		p.pos++
+		p.skipSpace()
+	} else if p.pos < len(p.src) && (p.src[p.pos] == 's' || p.src[p.pos] == 'S') {
+		p.pos++
+		p.skipSpace()
+	}
+	if !p.accept(']') {
+		return nil, p.errorf("expected ']'")
+	}
+	if fold {
+		value = strings.ToLower(value)
+	}
+
+	return func(elem, _ dom.Element) bool {
+		if !elem.HasAttribute(name) {
+			return false
+		}
+		attr := elem.GetAttribute(name)
+		if fold {
+			attr = strings.ToLower(attr)
+		}
+		switch op {
+		case "=":
+			return attr == value
+		case "~=":
+			return containsToken(attr, value)
+		case "|=":
+			return attr == value || strings.HasPrefix(attr, value+"-")
+		case "^=":
+			return value != "" && strings.HasPrefix(attr, value)
+		case "$=":
+			return value != "" && strings.HasSuffix(attr, value)
+		case "*=":
+			return value != "" && strings.Contains(attr, value)
+		}
+		return false
+	}, nil
+}
+
+// parsePseudo parses a pseudo-class after the colon
+func (p *selectorParser) parsePseudo() (matcher, error) {
+	name := strings.ToLower(p.parseIdent())
+	if name == "" {
+		return nil, p.errorf("expected pseudo-class name")
+	}
+
+	// Pseudo-classes without arguments
+	if !p.accept('(') {
+		switch name {
+		case "root":
+			return func(elem, _ dom.Element) bool {
+				parent := elem.ParentNode()
+				return parent != nil && parent.NodeType() == dom.DOCUMENT_NODE
+			}, nil
+		case "scope":
+			return func(elem, scope dom.Element) bool {
+				if scope == nil {
+					parent := elem.ParentNode()
+					return parent != nil && parent.NodeType() == dom.DOCUMENT_NODE
+				}
+				return elem.Equals(scope)
+			}, nil
+		case "empty":
+			return func(elem, _ dom.Element) bool {
+				for child := elem.FirstChild(); child != nil; child = child.NextSibling() {
+					if child.NodeType() == dom.ELEMENT_NODE || child.NodeType() == dom.TEXT_NODE {
+						return false
+					}
+				}
+				return true
+			}, nil
+		case "first-child":
+			return nthMatcher(0, 1, false, false), nil
+		case "last-child":
+			return nthMatcher(0, 1, true, false), nil
+		case "only-child":
+			return both(nthMatcher(0, 1, false, false), nthMatcher(0, 1, true, false)), nil
+		case "first-of-type":
+			return nthMatcher(0, 1, false, true), nil
+		case "last-of-type":
+			return nthMatcher(0, 1, true, true), nil
+		case "only-of-type":
+			return both(nthMatcher(0, 1, false, true), nthMatcher(0, 1, true, true)), nil
+		case "checked":
+			return func(elem, _ dom.Element) bool {
+				return elem.HasAttribute("checked") || elem.HasAttribute("selected")
+			}, nil
+		case "disabled":
+			return func(elem, _ dom.Element) bool {
+				return elem.HasAttribute("disabled")
+			}, nil
+		case "enabled":
+			return func(elem, _ dom.Element) bool {
+				switch strings.ToLower(elem.TagName()) {
+				case "button", "input", "select", "textarea", "option", "fieldset":
+					return !elem.HasAttribute("disabled")
+				}
+				return false
+			}, nil
+		}
+		return nil, p.errorf("unsupported pseudo-class %q", ":"+name)
+	}
+
+	// Pseudo-classes with arguments
+	var m matcher
+	switch name {
+	case "not", "is", "where":
+		sel, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		if name == "not" {
+			m = func(elem, scope dom.Element) bool {
+				return !sel.match(elem, scope)
+			}
+		} else {
+			m = sel.match
+		}
+	case "nth-child", "nth-last-child", "nth-of-type", "nth-last-of-type":
+		end := strings.IndexByte(p.src[p.pos:], ')')
+		if end < 0 {
+			return nil, p.errorf("expected ')'")
+		}
+		a, b, err := parseNth(p.src[p.pos : p.pos+end])
+		if err != nil {
+			return nil, p.errorf("%v", err)
+		}
+		p.pos += end
+		m = nthMatcher(a, b, strings.Contains(name, "last"), strings.HasSuffix(name, "of-type"))
+	default:
+		return nil, p.errorf("unsupported pseudo-class %q", ":"+name+"()")
+	}
+	if p.skipSpace(); !p.accept(')') {
+		return nil, p.errorf("expected ')'")
+	}
+	return m, nil
+}
+
+// parseIdent parses an identifier, including escaped characters, and
+// returns an empty string if there is no identifier
+func (p *selectorParser) parseIdent() string {
+	var b strings.Builder
+	for p.pos < len(p.src) {
+		ch := rune(p.src[p.pos])
+		switch {
+		case ch == '\\' && p.pos+1 < len(p.src):
+			p.pos++
+			r := []rune(p.src[p.pos:])[0]
+			b.WriteRune(r)
+			p.pos += len(string(r))
+		case ch == '-' || ch == '_' || ch >= 0x80 || unicode.IsLetter(ch) || unicode.IsDigit(ch):
+			r := []rune(p.src[p.pos:])[0]
+			b.WriteRune(r)
+			p.pos += len(string(r))
+		default:
+			return b.String()
+		}
+	}
+	return b.String()
+}
+
+// parseValue parses a quoted string or identifier
+func (p *selectorParser) parseValue() (string, error) {
+	if p.pos >= len(p.src) {
+		return "", p.errorf("expected attribute value")
+	}
+	quote := p.src[p.pos]
+	if quote != '"' && quote != '\'' {
+		value := p.parseIdent()
+		if value == "" {
+			return "", p.errorf("expected attribute value")
+		}
+		return value, nil
+	}
+	var b strings.Builder
+	for p.pos++; p.pos < len(p.src); p.pos++ {
+		switch ch := p.src[p.pos]; {
+		case ch == quote:
+			p.pos++
+			return b.String(), nil
+		case ch == '\\' && p.pos+1 < len(p.src):
+			p.pos++
+			b.WriteByte(p.src[p.pos])
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// skipSpace skips whitespace, and returns true if any was skipped
+func (p *selectorParser) skipSpace() bool {
+	start := p.pos
+	for p.pos < len(p.src) && strings.IndexByte(" \t\n\r\f", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+// accept consumes a character if it is next
+func (p *selectorParser) accept(ch byte) bool {
+	if p.pos < len(p.src) && p.src[p.pos] == ch {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+/////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS - HELPERS
+
+// parseNth parses the argument of an :nth-child pseudo-class, which is
+// "odd", "even", or of the form "an+b"
+func parseNth(arg string) (int, int, error) {
+	arg = strings.ToLower(strings.Join(strings.Fields(arg), ""))
+	switch arg {
+	case "odd":
+		return 2, 1, nil
+	case "even":
+		return 2, 0, nil
+	case "":
+		return 0, 0, dom.ErrBadParameter.With("expected argument")
+	}
+
+	// Only b
+	n := strings.IndexByte(arg, 'n')
+	if n < 0 {
+		b, err := strconv.Atoi(arg)
+		if err != nil {
+			return 0, 0, dom.ErrBadParameter.Withf("invalid argument %q", arg)
+		}
+		return 0, b, nil
+	}
+
+	// a, which may be omitted or just a sign
+	var a int
+	switch prefix := arg[:n]; prefix {
+	case "", "+":
+		a = 1
+	case "-":
+		a = -1
+	default:
+		var err error
+		if a, err = strconv.Atoi(prefix); err != nil {
+			return 0, 0, dom.ErrBadParameter.Withf("invalid argument %q", arg)
+		}
+	}
+
+	// b, which may be omitted
+	var b int
+	if suffix := arg[n+1:]; suffix != "" {
+		if suffix[0] != '+' && suffix[0] != '-' {
+			return 0, 0, dom.ErrBadParameter.Withf("invalid argument %q", arg)
+		}
+		var err error
+		if b, err = strconv.Atoi(suffix); err != nil {
+			return 0, 0, dom.ErrBadParameter.Withf("invalid argument %q", arg)
+		}
+	}
+	return a, b, nil
+}
+
+// nthMatcher returns a matcher for elements at positions a*n+b amongst
+// their siblings, counting from the end if last is true, and only counting
+// siblings with the same tag name if ofType is true
+func nthMatcher(a, b int, last, ofType bool) matcher {
+	return func(elem, _ dom.Element) bool {
+		if elem.ParentNode() == nil {
+			return false
+		}
+		index := 1
+		next := elem.PreviousElementSibling
+		if last {
+			next = elem.NextElementSibling
+		}
+		for sibling := next(); sibling != nil; {
+			if !ofType || sibling.TagName() == elem.TagName() {
+				index++
+			}
+			if last {
+				sibling = sibling.NextElementSibling()
+			} else {
+				sibling = sibling.PreviousElementSibling()
+			}
+		}
+		if a == 0 {
+			return index == b
+		}
+		return (index-b)%a == 0 && (index-b)/a >= 0
+	}
+}
+
+// both returns a matcher which matches if both matchers match
+func both(m1, m2 matcher) matcher {
+	return func(elem, scope dom.Element) bool {
+		return m1(elem, scope) && m2(elem, scope)
+	}
+}
+
+// containsToken returns true if a whitespace-separated list contains token
+func containsToken(list, token string) bool {
+	for _, value := range strings.Fields(list) {
+		if value == token {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,199 @@
+package dom_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+// selectorElement creates an element with attributes and children, which
+// are either elements or strings for text
+func selectorElement(tag string, attrs map[string]string, children ...any) dom.Element {
+	doc := domPkg.GetWindow().Document()
+	elem := doc.CreateElement(tag)
+	for name, value := range attrs {
+		elem.SetAttribute(name, value)
+	}
+	for _, child := range children {
+		switch child := child.(type) {
+		case string:
+			elem.AppendChild(doc.CreateTextNode(child))
+		case dom.Node:
+			elem.AppendChild(child)
+		}
+	}
+	return elem
+}
+
+// selectorFixture returns a tree of elements, each with a "data-name"
+// attribute to identify it, attached to the document body
+func selectorFixture(t *testing.T) dom.Element {
+	t.Helper()
+	e := selectorElement
+	root := e("div", map[string]string{"data-name": "root", "class": "fixture"},
+		e("ul", map[string]string{"data-name": "list", "class": "list"},
+			e("li", map[string]string{"data-name": "li1", "class": "item first", "data-id": "1"}, "One"),
+			e("li", map[string]string{"data-name": "li2", "class": "item", "data-id": "2", "lang": "en-GB"}, "Two"),
+			e("li", map[string]string{"data-name": "li3", "class": "item special", "data-id": "3"}, "Three"),
+			e("li", map[string]string{"data-name": "li4", "class": "item", "data-id": "4", "title": "Four Items"}, "Four"),
+		),
+		e("p", map[string]string{"data-name": "p1"}, "Para ", e("span", map[string]string{"data-name": "span"}, "inner")),
+		e("h2", map[string]string{"data-name": "h2"}, "Heading"),
+		e("p", map[string]string{"data-name": "p2", "class": "note", "id": "selector-last"}, "Last"),
+		e("input", map[string]string{"data-name": "input", "type": "checkbox", "checked": "", "disabled": ""}),
+		e("div", map[string]string{"data-name": "empty"}),
+	)
+	body := domPkg.GetWindow().Document().Body()
+	body.AppendChild(root)
+	t.Cleanup(func() {
+		body.RemoveChild(root)
+	})
+	return root
+}
+
+// names returns the "data-name" attributes of elements
+func names(elems []dom.Element) []string {
+	result := []string{}
+	for _, elem := range elems {
+		result = append(result, elem.GetAttribute("data-name"))
+	}
+	return result
+}
+
+func TestSelector_QuerySelectorAll(t *testing.T) {
+	root := selectorFixture(t)
+	tests := []struct {
+		selector string
+		names    []string
+	}{
+		// Type, universal, id and class
+		{"li", []string{"li1", "li2", "li3", "li4"}},
+		{"LI", []string{"li1", "li2", "li3", "li4"}},
+		{"ul > *", []string{"li1", "li2", "li3", "li4"}},
+		{"#selector-last", []string{"p2"}},
+		{"p#selector-last.note", []string{"p2"}},
+		{".item.special", []string{"li3"}},
+		{".missing", []string{}},
+
+		// Selector lists are returned in document order
+		{"p, li.first", []string{"li1", "p1", "p2"}},
+
+		// Combinators
+		{"div span", []string{"span"}},
+		{"div > span", []string{}},
+		{"p > span", []string{"span"}},
+		{"h2 + p", []string{"p2"}},
+		{"h2 ~ *", []string{"p2", "input", "empty"}},
+		{"ul ~ p", []string{"p1", "p2"}},
+		{"li.first ~ li.special + li", []string{"li4"}},
+
+		// Attributes
+		{"[title]", []string{"li4"}},
+		{"[data-id=\"2\"]", []string{"li2"}},
+		{"[data-id='3']", []string{"li3"}},
+		{"[class~=item]", []string{"li1", "li2", "li3", "li4"}},
+		{"[lang|=en]", []string{"li2"}},
+		{"[title^=Four]", []string{"li4"}},
+		{"[title$=Items]", []string{"li4"}},
+		{"[title*=\"r I\"]", []string{"li4"}},
+		{"[title=\"four items\" i]", []string{"li4"}},
+		{"[title^=\"\"]", []string{}},
+
+		// Pseudo-classes
+		{"li:first-child", []string{"li1"}},
+		{"li:last-child", []string{"li4"}},
+		{"li:nth-child(2)", []string{"li2"}},
+		{"li:nth-child(odd)", []string{"li1", "li3"}},
+		{"li:nth-child(even)", []string{"li2", "li4"}},
+		{"li:nth-child(2n+1)", []string{"li1", "li3"}},
+		{"li:nth-child(-n+2)", []string{"li1", "li2"}},
+		{"li:nth-child(n+3)", []string{"li3", "li4"}},
+		{"li:nth-last-child(1)", []string{"li4"}},
+		{"p:first-of-type", []string{"p1"}},
+		{"p:last-of-type", []string{"p2"}},
+		{"p:nth-of-type(2)", []string{"p2"}},
+		{"h2:only-of-type", []string{"h2"}},
+		{"span:only-child", []string{"span"}},
+		{"li:not(.item)", []string{}},
+		{"li:not(.first, [title])", []string{"li2", "li3"}},
+		{":not(li):empty", []string{"input", "empty"}},
+		{"input:checked:disabled", []string{"input"}},
+		{":is(h2, span)", []string{"span", "h2"}},
+		{":scope > p", []string{"p1", "p2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			assert.Equal(t, tt.names, names(root.QuerySelectorAll(tt.selector)))
+		})
+	}
+}
+
+func TestSelector_QuerySelector(t *testing.T) {
+	root := selectorFixture(t)
+
+	elem := root.QuerySelector("li.item")
+	if assert.NotNil(t, elem) {
+		assert.Equal(t, "li1", elem.GetAttribute("data-name"))
+	}
+	assert.Nil(t, root.QuerySelector("table"))
+
+	// Descendants are matched against the whole tree, not just the subtree
+	list := root.QuerySelector("ul")
+	if assert.NotNil(t, list) {
+		assert.Equal(t, []string{"li1", "li2", "li3", "li4"}, names(list.QuerySelectorAll("div li")))
+		assert.Equal(t, []string{}, names(list.QuerySelectorAll("ul")))
+	}
+}
+
+func TestSelector_Document(t *testing.T) {
+	selectorFixture(t)
+	doc := domPkg.GetWindow().Document()
+
+	elem := doc.QuerySelector("#selector-last")
+	if assert.NotNil(t, elem) {
+		assert.Equal(t, "p2", elem.GetAttribute("data-name"))
+	}
+	assert.Equal(t, []string{"li1", "li2", "li3", "li4"}, names(doc.QuerySelectorAll("body .fixture li")))
+	assert.NotEmpty(t, doc.QuerySelectorAll(":root > body"))
+}
+
+func TestSelector_Matches(t *testing.T) {
+	root := selectorFixture(t)
+	li := root.QuerySelector("li:nth-child(3)")
+	if !assert.NotNil(t, li) {
+		t.FailNow()
+	}
+	assert.True(t, li.Matches("li"))
+	assert.True(t, li.Matches(".fixture .special"))
+	assert.True(t, li.Matches("ul > li.item:not(.first)"))
+	assert.False(t, li.Matches("p li"))
+	assert.False(t, li.Matches(".first"))
+}
+
+func TestSelector_Closest(t *testing.T) {
+	root := selectorFixture(t)
+	span := root.QuerySelector("span")
+	if !assert.NotNil(t, span) {
+		t.FailNow()
+	}
+	assert.Equal(t, "span", span.Closest("span").GetAttribute("data-name"))
+	assert.Equal(t, "p1", span.Closest("p").GetAttribute("data-name"))
+	assert.Equal(t, "root", span.Closest(".fixture").GetAttribute("data-name"))
+	assert.Nil(t, span.Closest("ul"))
+}
+
+func TestSelector_Invalid(t *testing.T) {
+	root := selectorFixture(t)
+	for _, selector := range []string{
+		"", "div >", "> div", "[title", "[title=]", "li:nth-child(x)", "li:unknown", "#", ".", "a,", "div!",
+	} {
+		t.Run(selector, func(t *testing.T) {
+			assert.Panics(t, func() {
+				root.QuerySelectorAll(selector)
+			})
+		})
+	}
+}
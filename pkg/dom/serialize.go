@@ -0,0 +1,265 @@
+package dom
+
+import (
+	"io"
+	"strings"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// TYPES
+
+// serializer writes nodes as HTML, following the HTML fragment
+// serialisation algorithm, optionally indenting elements on separate lines
+type serializer struct {
+	w      io.Writer
+	indent string
+	n      int
+	err    error
+}
+
+/////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+// Elements which have no end tag or contents
+var voidElements = map[string]bool{
+	"area": true, "base": true, "basefont": true, "bgsound": true, "br": true,
+	"col": true, "embed": true, "frame": true, "hr": true, "img": true,
+	"input": true, "keygen": true, "link": true, "meta": true, "param": true,
+	"source": true, "track": true, "wbr": true,
+}
+
+// Elements which contain text which is not escaped
+var rawTextElements = map[string]bool{
+	"iframe": true, "noembed": true, "noframes": true, "plaintext": true,
+	"script": true, "style": true, "xmp": true,
+}
+
+// Elements where whitespace is significant, which are not indented
+var preformattedElements = map[string]bool{
+	"pre": true, "textarea": true, "listing": true,
+}
+
+// Boolean attributes, which are written without a value when empty
+var booleanAttributes = map[string]bool{
+	"allowfullscreen": true, "async": true, "autofocus": true, "autoplay": true,
+	"checked": true, "controls": true, "default": true, "defer": true,
+	"disabled": true, "formnovalidate": true, "hidden": true, "inert": true,
+	"ismap": true, "itemscope": true, "loop": true, "multiple": true,
+	"muted": true, "nomodule": true, "novalidate": true, "open": true,
+	"playsinline": true, "readonly": true, "required": true, "reversed": true,
+	"selected": true,
+}
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "\u00a0", "&nbsp;", "<", "&lt;", ">", "&gt;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "\u00a0", "&nbsp;", "\"", "&quot;", "<", "&lt;", ">", "&gt;")
+)
+
+/////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// writeHTML writes a node as HTML. When indent is not empty, elements
+// are written on separate lines and indented by depth, except within
+// preformatted and raw text elements, and whitespace between elements is
+// discarded.
+func writeHTML(w io.Writer, node dom.Node, indent string) (int, error) {
+	s := &serializer{w: w, indent: indent}
+	s.node(node, 0)
+	return s.n, s.err
+}
+
+/////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func (s *serializer) write(str string) {
+	if s.err != nil {
+		return
+	}
+	n, err := io.WriteString(s.w, str)
+	s.n += n
+	s.err = err
+}
+
+// newline ends a line when indenting
+func (s *serializer) newline() {
+	if s.indent != "" {
+		s.write("\n")
+	}
+}
+
+// prefix starts a line when indenting
+func (s *serializer) prefix(depth int) {
+	if s.indent != "" {
+		s.write(strings.Repeat(s.indent, depth))
+	}
+}
+
+func (s *serializer) node(node dom.Node, depth int) {
+	switch node.NodeType() {
+	case dom.DOCUMENT_NODE:
+		// The doctype is written on its own line, followed by the root element
+		if doctype := node.(dom.Document).Doctype(); doctype != nil {
+			s.doctype(doctype)
+			s.write("\n")
+		}
+		for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+			if child.NodeType() != dom.DOCUMENT_TYPE_NODE {
+				s.node(child, depth)
+			}
+		}
+	case dom.DOCUMENT_FRAGMENT_NODE:
+		for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+			s.node(child, depth)
+		}
+	case dom.DOCUMENT_TYPE_NODE:
+		s.doctype(node.(dom.DocumentType))
+		s.newline()
+	case dom.ELEMENT_NODE:
+		s.prefix(depth)
+		s.element(node.(dom.Element), depth)
+		s.newline()
+	case dom.TEXT_NODE:
+		if s.indent == "" {
+			s.text(node)
+		} else if data := strings.TrimSpace(node.(dom.Text).Data()); data != "" {
+			s.prefix(depth)
+			s.write(textEscaper.Replace(data))
+			s.newline()
+		}
+	case dom.COMMENT_NODE:
+		s.prefix(depth)
+		s.write("<!--" + node.(dom.Comment).Data() + "-->")
+		s.newline()
+	case dom.ATTRIBUTE_NODE:
+		s.attr(node.(dom.Attr))
+	}
+}
+
+func (s *serializer) doctype(doctype dom.DocumentType) {
+	s.write("<!DOCTYPE " + doctype.Name())
+	if publicid := doctype.PublicId(); publicid != "" {
+		s.write(" PUBLIC \"" + publicid + "\"")
+		if systemid := doctype.SystemId(); systemid != "" {
+			s.write(" \"" + systemid + "\"")
+		}
+	} else if systemid := doctype.SystemId(); systemid != "" {
+		s.write(" SYSTEM \"" + systemid + "\"")
+	}
+	s.write(">")
+}
+
+// element writes an element, where the name of an HTML element is written
+// in lowercase and the names of other elements keep their case
+func (s *serializer) element(elem dom.Element, depth int) {
+	name, html := elem.TagName(), elem.NamespaceURI() == dom.NS_HTML
+	if html {
+		name = strings.ToLower(name)
+	}
+
+	// Start tag, with attributes in the order they were added
+	s.write("<" + name)
+	for _, attr := range elem.Attributes() {
+		s.write(" ")
+		s.attr(attr)
+	}
+	s.write(">")
+
+	// Void elements have no contents or end tag
+	if html && voidElements[name] {
+		return
+	}
+
+	// Contents are written inline when whitespace is significant
+	inline := s.indent == "" || (html && (rawTextElements[name] || preformattedElements[name]))
+	s.contents(contentOf(elem), elem.ShadowRoot(), inline, depth)
+
+	// End tag
+	s.write("</" + name + ">")
+}
+
+// contents writes the children of a node, preceded by the shadow root of
+// an element when it has an open shadow root. Contents are also written inline when there
+// are no child elements and no shadow root.
+func (s *serializer) contents(parent dom.Node, shadow dom.ShadowRoot, inline bool, depth int) {
+	if !inline && shadow == nil {
+		inline = true
+		for child := parent.FirstChild(); child != nil; child = child.NextSibling() {
+			if child.NodeType() == dom.ELEMENT_NODE {
+				inline = false
+				break
+			}
+		}
+	}
+	if inline {
+		if shadow != nil {
+			s.shadowRoot(shadow, 0)
+		}
+		for child := parent.FirstChild(); child != nil; child = child.NextSibling() {
+			s.inline(child)
+		}
+	} else {
+		s.newline()
+		if shadow != nil {
+			s.prefix(depth + 1)
+			s.shadowRoot(shadow, depth+1)
+			s.newline()
+		}
+		for child := parent.FirstChild(); child != nil; child = child.NextSibling() {
+			s.node(child, depth+1)
+		}
+		s.prefix(depth)
+	}
+}
+
+// shadowRoot writes an open shadow root as a declarative shadow root
+// template. Closed shadow roots are not written, as they are not accessible
+// outside the host.
+func (s *serializer) shadowRoot(shadow dom.ShadowRoot, depth int) {
+	s.write("<template shadowrootmode=\"" + string(shadow.Mode()) + "\">")
+	s.contents(shadow, nil, s.indent == "", depth)
+	s.write("</template>")
+}
+
+// contentOf returns the node which holds the contents of an element, which
+// is the content fragment of a template element
+func contentOf(elem dom.Element) dom.Node {
+	if template, ok := elem.(dom.HTMLTemplateElement); ok {
+		return template.Content()
+	}
+	return elem
+}
+
+// inline writes a node without indentation
+func (s *serializer) inline(node dom.Node) {
+	switch node.NodeType() {
+	case dom.ELEMENT_NODE:
+		s.element(node.(dom.Element), 0)
+	case dom.TEXT_NODE:
+		s.text(node)
+	case dom.COMMENT_NODE:
+		s.write("<!--" + node.(dom.Comment).Data() + "-->")
+	}
+}
+
+// text writes text, which is escaped unless within a raw text element
+func (s *serializer) text(node dom.Node) {
+	if parent := node.ParentElement(); parent != nil && parent.NamespaceURI() == dom.NS_HTML && rawTextElements[strings.ToLower(parent.NodeName())] {
+		s.write(node.(dom.Text).Data())
+	} else {
+		s.write(textEscaper.Replace(node.(dom.Text).Data()))
+	}
+}
+
+// attr writes an attribute, with boolean attributes written without a
+// value when empty
+func (s *serializer) attr(attr dom.Attr) {
+	name, value := attr.Name(), attr.Value()
+	if value == "" && booleanAttributes[strings.ToLower(name)] {
+		s.write(name)
+	} else {
+		s.write(name + "=\"" + attrEscaper.Replace(value) + "\"")
+	}
+}
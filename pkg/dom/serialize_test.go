@@ -0,0 +1,160 @@
+package dom_test
+
+import (
+	"bytes"
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSerialize_VoidElements(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	for _, tag := range []string{"br", "hr", "img", "input", "meta", "link", "wbr"} {
+		t.Run(tag, func(t *testing.T) {
+			assert.Equal(t, "<"+tag+">", doc.CreateElement(tag).OuterHTML())
+		})
+	}
+
+	p := doc.CreateElement("p")
+	p.AppendChild(doc.CreateTextNode("a"))
+	p.AppendChild(doc.CreateElement("br"))
+	p.AppendChild(doc.CreateTextNode("b"))
+	assert.Equal(t, "<p>a<br>b</p>", p.OuterHTML())
+}
+
+func TestSerialize_RawText(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+
+	// Text within script and style elements is not escaped
+	script := doc.CreateElement("script")
+	script.AppendChild(doc.CreateTextNode(`if (a < b && c > d) { x = "<p>" }`))
+	assert.Equal(t, `<script>if (a < b && c > d) { x = "<p>" }</script>`, script.OuterHTML())
+
+	style := doc.CreateElement("style")
+	style.AppendChild(doc.CreateTextNode(`div > p { content: "&" }`))
+	assert.Equal(t, `<style>div > p { content: "&" }</style>`, style.OuterHTML())
+
+	// Text within other elements is escaped
+	textarea := doc.CreateElement("textarea")
+	textarea.AppendChild(doc.CreateTextNode(`a < b & c`))
+	assert.Equal(t, `<textarea>a &lt; b &amp; c</textarea>`, textarea.OuterHTML())
+}
+
+func TestSerialize_Attributes(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	tests := []struct {
+		value, expected string
+	}{
+		{``, `<div title=""></div>`},
+		{`a & b`, `<div title="a &amp; b"></div>`},
+		{`"quoted"`, `<div title="&quot;quoted&quot;"></div>`},
+		{`'single'`, `<div title="'single'"></div>`},
+		{`a < b > c`, `<div title="a &lt; b &gt; c"></div>`},
+		{"a\u00a0b", `<div title="a&nbsp;b"></div>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			div := doc.CreateElement("div")
+			div.SetAttribute("title", tt.value)
+			assert.Equal(t, tt.expected, div.OuterHTML())
+		})
+	}
+}
+
+func TestSerialize_AttributeOrder(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+
+	// Attributes are written in the order they were added, and keep their
+	// position when their value changes
+	a := doc.CreateElement("a")
+	a.SetAttribute("href", "/")
+	a.SetAttribute("class", "link")
+	a.SetAttribute("aria-label", "Home")
+	a.SetAttribute("href", "/home")
+	assert.Equal(t, `<a href="/home" class="link" aria-label="Home"></a>`, a.OuterHTML())
+	assert.Equal(t, []string{"href", "class", "aria-label"}, a.GetAttributeNames())
+
+	// An attribute which is removed and added again is written last
+	a.RemoveAttribute("href")
+	a.SetAttribute("href", "/")
+	assert.Equal(t, `<a class="link" aria-label="Home" href="/"></a>`, a.OuterHTML())
+
+	// Parsed attributes keep their source order
+	div := doc.CreateElement("div")
+	div.SetInnerHTML(`<input type="text" name="q" id="search" required>`)
+	assert.Equal(t, `<input type="text" name="q" id="search" required>`, div.InnerHTML())
+	assert.Equal(t, div.InnerHTML(), div.CloneNode(true).(dom.Element).InnerHTML())
+}
+
+func TestSerialize_Indent(t *testing.T) {
+	window := domPkg.GetWindow()
+	doc := window.Document()
+
+	ul := doc.CreateElement("ul")
+	ul.SetAttribute("class", "list")
+	ul.AppendChild(doc.CreateTextNode("\n  "))
+	for _, text := range []string{"One", "Two"} {
+		li := ul.AppendChild(doc.CreateElement("li"))
+		li.AppendChild(doc.CreateTextNode(text))
+	}
+	div := doc.CreateElement("div")
+	div.AppendChild(doc.CreateComment(" list "))
+	div.AppendChild(ul)
+	div.AppendChild(doc.CreateElement("hr"))
+	pre := div.AppendChild(doc.CreateElement("pre"))
+	pre.AppendChild(doc.CreateTextNode("  a\n  <b>"))
+	input := div.AppendChild(doc.CreateElement("input")).(dom.Element)
+	input.SetAttribute("type", "checkbox")
+	input.SetAttribute("checked", "")
+
+	buf := new(bytes.Buffer)
+	n, err := window.WriteIndent(buf, div, "  ")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, buf.Len(), n)
+	assert.Equal(t, `<div>
+  <!-- list -->
+  <ul class="list">
+    <li>One</li>
+    <li>Two</li>
+  </ul>
+  <hr>
+  <pre>  a
+  &lt;b&gt;</pre>
+  <input type="checkbox" checked>
+</div>
+`, buf.String())
+
+	// An indent is required
+	_, err = window.WriteIndent(buf, div, "")
+	assert.Error(t, err)
+	_, err = window.WriteIndent(buf, nil, "  ")
+	assert.Error(t, err)
+}
+
+func TestSerialize_ClassList(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	div := doc.CreateElement("div")
+
+	// The class attribute reflects changes to the class list
+	div.ClassList().Add("a", "b")
+	assert.Equal(t, "a b", div.GetAttribute("class"))
+	assert.Equal(t, "a b", div.ClassName())
+	assert.Equal(t, `<div class="a b"></div>`, div.OuterHTML())
+
+	div.ClassList().Remove("a")
+	assert.Equal(t, "b", div.GetAttribute("class"))
+
+	// The class list reflects changes to the class attribute
+	div.SetClassName("c d")
+	assert.Equal(t, []string{"c", "d"}, div.ClassList().Values())
+	div.SetAttribute("class", "e")
+	assert.True(t, div.ClassList().Contains("e"))
+	assert.False(t, div.ClassList().Contains("c"))
+	div.RemoveAttribute("class")
+	assert.Equal(t, 0, div.ClassList().Length())
+}
@@ -0,0 +1,228 @@
+//go:build !js
+
+package dom
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// TYPES
+
+type shadowRoot struct {
+	*fragment
+	host *element
+	mode dom.ShadowRootMode
+}
+
+type slotElement struct {
+	*element
+}
+
+var _ dom.ShadowRoot = (*shadowRoot)(nil)
+var _ dom.HTMLSlotElement = (*slotElement)(nil)
+
+/////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+// HTML elements which can host a shadow root, in addition to custom
+// elements
+var shadowHostElements = []string{
+	"article", "aside", "blockquote", "body", "div", "footer", "h1", "h2",
+	"h3", "h4", "h5", "h6", "header", "main", "nav", "p", "section", "span",
+}
+
+/////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// AttachShadow creates a shadow root for the element. It panics if the mode
+// is not valid, the element cannot host a shadow root, or the element
+// already has a shadow root.
+func (this *element) AttachShadow(mode dom.ShadowRootMode) dom.ShadowRoot {
+	if mode != dom.SHADOW_ROOT_OPEN && mode != dom.SHADOW_ROOT_CLOSED {
+		panic(dom.ErrBadParameter.Withf("invalid shadow root mode %q", mode))
+	}
+	if !this.canHostShadow() {
+		panic(dom.ErrBadParameter.Withf("%q cannot host a shadow root", this.LocalName()))
+	}
+	if this.shadow != nil {
+		panic(dom.ErrDuplicateEntry.Withf("%q already has a shadow root", this.LocalName()))
+	}
+
+	// The shadow root is a fragment with a host, which is not its parent
+	fragment := NewNode(this.document, "#document-fragment", dom.DOCUMENT_FRAGMENT_NODE, "").(*fragment)
+	this.shadow = &shadowRoot{fragment, this, mode}
+	fragment.node.self = this.shadow
+	return this.shadow
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (this *shadowRoot) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<DOMShadowRoot mode=%q", this.mode)
+	for c := this.FirstChild(); c != nil; c = c.NextSibling() {
+		fmt.Fprint(&b, " child=", c)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+/////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+// ShadowRoot returns the shadow root of the element, or nil if there is no
+// shadow root or it is closed
+func (this *element) ShadowRoot() dom.ShadowRoot {
+	if this.shadow == nil || this.shadow.mode == dom.SHADOW_ROOT_CLOSED {
+		return nil
+	}
+	return this.shadow
+}
+
+func (this *shadowRoot) Mode() dom.ShadowRootMode {
+	return this.mode
+}
+
+func (this *shadowRoot) Host() dom.Element {
+	return this.host.domElement()
+}
+
+func (this *shadowRoot) InnerHTML() string {
+	buf := new(bytes.Buffer)
+	for child := this.FirstChild(); child != nil; child = child.NextSibling() {
+		writeNode(buf, child)
+	}
+	return buf.String()
+}
+
+// SetInnerHTML replaces the children of the shadow root with nodes parsed
+// from HTML, in the context of the host
+func (this *shadowRoot) SetInnerHTML(data string) {
+	nodes, err := parseFragment(this.host.document.(*document), strings.NewReader(data), this.host)
+	if err != nil {
+		// Reading from a string does not return an error
+		panic(err)
+	}
+	this.node.replaceAll(nodes)
+}
+
+func (this *slotElement) Name() string {
+	return this.GetAttribute("name")
+}
+
+func (this *slotElement) SetName(name string) {
+	this.SetAttribute("name", name)
+}
+
+/////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// AssignedNodes returns the children of the host which are assigned to the
+// slot, or when flatten is true, the flattened assigned nodes
+func (this *slotElement) AssignedNodes(flatten bool) []dom.Node {
+	if flatten {
+		return this.flattened()
+	}
+	return this.assigned()
+}
+
+func (this *slotElement) AssignedElements(flatten bool) []dom.Element {
+	var result []dom.Element
+	for _, node := range this.AssignedNodes(flatten) {
+		if elem, ok := node.(dom.Element); ok {
+			result = append(result, elem)
+		}
+	}
+	return result
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// canHostShadow returns true if a shadow root can be attached to the
+// element, which is a custom element or one of the shadow host elements
+func (this *element) canHostShadow() bool {
+	name := this.LocalName()
+	return this.namespace == dom.NS_HTML && (strings.Contains(name, "-") || slices.Contains(shadowHostElements, name))
+}
+
+// assigned returns the element and text children of the host which are
+// assigned to the slot, when the slot is in a shadow tree
+func (this *slotElement) assigned() []dom.Node {
+	root, ok := rangeRoot(this).(*shadowRoot)
+	if !ok {
+		return nil
+	}
+	var result []dom.Node
+	for _, child := range root.host.children {
+		var name string
+		switch child.NodeType() {
+		case dom.ELEMENT_NODE:
+			name = child.(dom.Element).GetAttribute("slot")
+		case dom.TEXT_NODE:
+		default:
+			continue
+		}
+		if root.slot(name) == this {
+			result = append(result, child)
+		}
+	}
+	return result
+}
+
+// flattened returns the assigned nodes, or the children of the slot when
+// there are none, where slots in a shadow tree are replaced by their
+// flattened assigned nodes
+func (this *slotElement) flattened() []dom.Node {
+	nodes := this.assigned()
+	if len(nodes) == 0 {
+		nodes = this.ChildNodes()
+	}
+	var result []dom.Node
+	for _, node := range nodes {
+		switch node.NodeType() {
+		case dom.ELEMENT_NODE, dom.TEXT_NODE:
+		default:
+			continue
+		}
+		if slot, ok := node.(*slotElement); ok {
+			if _, ok := rangeRoot(slot).(*shadowRoot); ok {
+				result = append(result, slot.flattened()...)
+				continue
+			}
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
+// slot returns the first slot in the shadow tree with a name, or nil
+func (this *shadowRoot) slot(name string) *slotElement {
+	for node := range this.Descendants() {
+		if slot, ok := node.(*slotElement); ok && slot.Name() == name {
+			return slot
+		}
+	}
+	return nil
+}
+
+// shadowIncludingRoot returns the root of a node, continuing from the host
+// of a shadow root
+func shadowIncludingRoot(node dom.Node) dom.Node {
+	root := rangeRoot(node)
+	for {
+		shadow, ok := root.(*shadowRoot)
+		if !ok {
+			return root
+		}
+		root = rangeRoot(shadow.host)
+	}
+}
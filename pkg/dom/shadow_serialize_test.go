@@ -0,0 +1,74 @@
+//go:build !js
+
+package dom_test
+
+import (
+	"strings"
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShadow_Serialize(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	host := doc.CreateElement("div")
+	host.SetInnerHTML(`<span slot="title">Title</span>`)
+	host.AttachShadow(dom.SHADOW_ROOT_OPEN).SetInnerHTML(`<h1><slot name="title"></slot></h1>`)
+
+	// The shadow root is written as a declarative shadow root, before the
+	// children of the host
+	assert.Equal(t, `<div><template shadowrootmode="open"><h1><slot name="title"></slot></h1></template><span slot="title">Title</span></div>`, host.OuterHTML())
+	assert.Equal(t, `<span slot="title">Title</span>`, host.InnerHTML())
+
+	// Closed shadow roots are not written
+	closed := doc.CreateElement("section")
+	closed.AttachShadow(dom.SHADOW_ROOT_CLOSED).SetInnerHTML(`x`)
+	host.AppendChild(closed)
+	assert.Equal(t, `<span slot="title">Title</span><section></section>`, host.InnerHTML())
+}
+
+func TestShadow_Parse(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	host := doc.CreateElement("div")
+	host.SetInnerHTML(`<span slot="title">Title</span>`)
+	host.AttachShadow(dom.SHADOW_ROOT_OPEN).SetInnerHTML(`<h1><slot name="title"></slot></h1>`)
+
+	// A serialized shadow root is parsed as a shadow root of its host
+	div := doc.CreateElement("div")
+	div.SetInnerHTML(host.OuterHTML())
+	parsed := div.FirstElementChild()
+	if shadow := parsed.ShadowRoot(); assert.NotNil(t, shadow) {
+		assert.Equal(t, dom.SHADOW_ROOT_OPEN, shadow.Mode())
+		assert.True(t, parsed.Equals(shadow.Host()))
+		assert.Equal(t, `<h1><slot name="title"></slot></h1>`, shadow.InnerHTML())
+		slot := shadow.QuerySelector("slot").(dom.HTMLSlotElement)
+		assert.Len(t, slot.AssignedNodes(false), 1)
+	}
+	assert.Nil(t, parsed.QuerySelector("template"))
+	assert.Equal(t, host.OuterHTML(), parsed.OuterHTML())
+
+	// A closed shadow root is attached, but is not accessible
+	div.SetInnerHTML(`<section><template shadowrootmode="closed"><p>x</p></template>y</section>`)
+	assert.Nil(t, div.FirstElementChild().ShadowRoot())
+	assert.Equal(t, `<section>y</section>`, div.InnerHTML())
+
+	// Templates with an invalid mode, on elements which cannot host a shadow
+	// root, or after the first shadow root are parsed as templates
+	div.SetInnerHTML(`<div><template shadowrootmode="invalid">a</template></div><ul><template shadowrootmode="open">b</template></ul><p><template shadowrootmode="open">c</template><template shadowrootmode="open">d</template></p>`)
+	assert.Nil(t, div.QuerySelector("div").ShadowRoot())
+	assert.Nil(t, div.QuerySelector("ul").ShadowRoot())
+	assert.Equal(t, "c", div.QuerySelector("p").ShadowRoot().InnerHTML())
+	assert.Len(t, div.QuerySelectorAll("template"), 3)
+
+	// A document is parsed with its shadow roots
+	parsedDoc, err := domPkg.GetWindow().Read(strings.NewReader(`<html><body><main><template shadowrootmode="open"><slot></slot></template>z</main></body></html>`), "text/html")
+	if assert.NoError(t, err) {
+		main := parsedDoc.QuerySelector("main")
+		if assert.NotNil(t, main.ShadowRoot()) {
+			assert.Equal(t, "<slot></slot>", main.ShadowRoot().InnerHTML())
+		}
+	}
+}
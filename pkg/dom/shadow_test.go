@@ -0,0 +1,134 @@
+package dom_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShadow_AttachShadow(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	host := doc.CreateElement("div")
+	assert.Nil(t, host.ShadowRoot())
+
+	root := host.AttachShadow(dom.SHADOW_ROOT_OPEN)
+	assert.Equal(t, dom.DOCUMENT_FRAGMENT_NODE, root.NodeType())
+	assert.Equal(t, dom.SHADOW_ROOT_OPEN, root.Mode())
+	assert.True(t, host.Equals(root.Host()))
+	assert.True(t, root.Equals(host.ShadowRoot()))
+	assert.Nil(t, root.ParentNode())
+
+	// The shadow tree is not part of the children of the host
+	root.SetInnerHTML(`<p class="inner">x</p>`)
+	assert.Equal(t, `<p class="inner">x</p>`, root.InnerHTML())
+	assert.False(t, host.HasChildNodes())
+	assert.Nil(t, host.QuerySelector("p"))
+	assert.NotNil(t, root.QuerySelector("p.inner"))
+
+	// An element can only have one shadow root
+	assert.Panics(t, func() { host.AttachShadow(dom.SHADOW_ROOT_OPEN) })
+
+	// Only some elements can host a shadow root
+	assert.Panics(t, func() { doc.CreateElement("img").AttachShadow(dom.SHADOW_ROOT_OPEN) })
+	assert.NotPanics(t, func() { doc.CreateElement("employee-table").AttachShadow(dom.SHADOW_ROOT_OPEN) })
+
+	// A closed shadow root is not returned by the host
+	closed := doc.CreateElement("span")
+	assert.NotNil(t, closed.AttachShadow(dom.SHADOW_ROOT_CLOSED))
+	assert.Nil(t, closed.ShadowRoot())
+}
+
+func TestShadow_GetRootNode(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	host := doc.CreateElement("div")
+	root := host.AttachShadow(dom.SHADOW_ROOT_OPEN)
+	root.SetInnerHTML(`<p><b>x</b></p>`)
+	b := root.QuerySelector("b")
+
+	// The root of a node in a shadow tree is the shadow root
+	assert.True(t, root.Equals(b.GetRootNode()))
+	assert.False(t, b.IsConnected())
+
+	// Nodes in the shadow tree of a connected host are connected
+	doc.Body().AppendChild(host)
+	defer host.Remove()
+	assert.True(t, root.Equals(b.GetRootNode()))
+	assert.True(t, b.IsConnected())
+}
+
+func TestShadow_Events(t *testing.T) {
+	window := domPkg.GetWindowWithTitle("shadow")
+	doc := window.Document()
+	host := doc.Body().AppendChild(doc.CreateElement("div")).(dom.Element)
+	root := host.AttachShadow(dom.SHADOW_ROOT_CLOSED)
+	root.SetInnerHTML(`<p><button>OK</button></p>`)
+	button := root.QuerySelector("button")
+
+	// Listeners in the shadow tree see the target, and listeners outside
+	// see the host, which is called in the target phase
+	var order []string
+	root.QuerySelector("p").AddEventListener("click", func(target dom.Node) {
+		assert.True(t, button.Equals(target))
+		order = append(order, "p")
+	})
+	host.AddEventHandler("click", func(e dom.Event) {
+		assert.True(t, host.Equals(e.Target()))
+		assert.Equal(t, dom.AT_TARGET, e.EventPhase())
+		order = append(order, "host")
+	})
+	doc.AddEventHandler("click", func(e dom.Event) {
+		assert.True(t, host.Equals(e.Target()))
+		order = append(order, "document")
+	})
+	window.AddEventHandler("click", func(e dom.Event) {
+		assert.True(t, host.Equals(e.Target()))
+		order = append(order, "window")
+	})
+
+	button.Click()
+	assert.Equal(t, []string{"p", "host", "document", "window"}, order)
+
+	// Events which are not composed stay in the shadow tree
+	order = nil
+	button.DispatchEvent(window.NewEvent("click", true, false))
+	assert.Equal(t, []string{"p"}, order)
+}
+
+func TestShadow_Slots(t *testing.T) {
+	doc := domPkg.GetWindow().Document()
+	host := doc.CreateElement("div")
+	host.SetInnerHTML(`<span slot="title">Title</span>text<i>body</i><b slot="missing">x</b><!--c-->`)
+	root := host.AttachShadow(dom.SHADOW_ROOT_OPEN)
+	root.SetInnerHTML(`<h1><slot name="title">Untitled</slot></h1><slot></slot><slot name="footer">Footer</slot>`)
+
+	slots := root.QuerySelectorAll("slot")
+	if !assert.Len(t, slots, 3) {
+		t.FailNow()
+	}
+	title, body, footer := slots[0].(dom.HTMLSlotElement), slots[1].(dom.HTMLSlotElement), slots[2].(dom.HTMLSlotElement)
+	assert.Equal(t, "title", title.Name())
+	assert.Equal(t, "", body.Name())
+
+	// Children are assigned by the slot attribute, and other element and
+	// text children are assigned to the default slot
+	assert.Equal(t, "span", nodeNames(title.AssignedNodes(false)...))
+	assert.Equal(t, "text i", nodeNames(body.AssignedNodes(false)...))
+	assert.Len(t, body.AssignedElements(false), 1)
+
+	// A slot with no assigned nodes falls back to its children when flattened
+	assert.Empty(t, footer.AssignedNodes(false))
+	assert.Equal(t, "Footer", nodeNames(footer.AssignedNodes(true)...))
+
+	// Assignment follows changes to the host and the slot
+	host.QuerySelector("b").SetAttribute("slot", "footer")
+	assert.Equal(t, "b", nodeNames(footer.AssignedNodes(true)...))
+	title.SetName("other")
+	assert.Empty(t, title.AssignedNodes(false))
+
+	// A slot outside a shadow tree has no assigned nodes
+	slot := doc.CreateElement("slot").(dom.HTMLSlotElement)
+	assert.Empty(t, slot.AssignedNodes(false))
+}
@@ -0,0 +1,96 @@
+//go:build js
+
+package dom
+
+import (
+	"syscall/js"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// TYPES
+
+type shadowRoot struct {
+	*fragment
+}
+
+type slotElement struct {
+	*element
+}
+
+var _ dom.ShadowRoot = (*shadowRoot)(nil)
+var _ dom.HTMLSlotElement = (*slotElement)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+var (
+	cShadowRoot      = js.Global().Get("ShadowRoot")
+	cHTMLSlotElement = js.Global().Get("HTMLSlotElement")
+)
+
+/////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+func (e *element) AttachShadow(mode dom.ShadowRootMode) dom.ShadowRoot {
+	return NewNode(e.Call("attachShadow", map[string]any{"mode": string(mode)})).(dom.ShadowRoot)
+}
+
+/////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (e *element) ShadowRoot() dom.ShadowRoot {
+	if root := NewNode(e.Get("shadowRoot")); root != nil {
+		return root.(dom.ShadowRoot)
+	}
+	return nil
+}
+
+func (this *shadowRoot) Mode() dom.ShadowRootMode {
+	return dom.ShadowRootMode(this.Get("mode").String())
+}
+
+func (this *shadowRoot) Host() dom.Element {
+	return NewNode(this.Get("host")).(dom.Element)
+}
+
+func (this *shadowRoot) InnerHTML() string {
+	return this.Get("innerHTML").String()
+}
+
+func (this *shadowRoot) SetInnerHTML(html string) {
+	this.Set("innerHTML", html)
+}
+
+func (this *slotElement) Name() string {
+	return this.Get("name").String()
+}
+
+func (this *slotElement) SetName(name string) {
+	this.Set("name", name)
+}
+
+/////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (this *slotElement) AssignedNodes(flatten bool) []dom.Node {
+	nodes := this.Call("assignedNodes", map[string]any{"flatten": flatten})
+	length := nodes.Get("length").Int()
+	result := make([]dom.Node, 0, length)
+	for i := 0; i < length; i++ {
+		result = append(result, NewNode(nodes.Index(i)))
+	}
+	return result
+}
+
+func (this *slotElement) AssignedElements(flatten bool) []dom.Element {
+	elements := this.Call("assignedElements", map[string]any{"flatten": flatten})
+	length := elements.Get("length").Int()
+	result := make([]dom.Element, 0, length)
+	for i := 0; i < length; i++ {
+		result = append(result, NewNode(elements.Index(i)).(dom.Element))
+	}
+	return result
+}
@@ -0,0 +1,33 @@
+package dom
+
+import (
+	"encoding/json"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// encodeState returns the JSON for a state, and panics if it cannot be
+// encoded
+func encodeState(state any) string {
+	if state == nil {
+		return ""
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		panic(dom.ErrBadParameter.With(err))
+	}
+	return string(data)
+}
+
+// decodeState returns the value of a state encoded as JSON, or nil
+func decodeState(state string) any {
+	var v any
+	if state != "" {
+		_ = json.Unmarshal([]byte(state), &v)
+	}
+	return v
+}
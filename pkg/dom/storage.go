@@ -0,0 +1,115 @@
+package dom
+
+import (
+	"encoding/json"
+	"strings"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// storage is the items in a storage area with keys which start with a
+// prefix, where the prefix is empty for all the items
+type storage struct {
+	area   storageArea
+	prefix string
+}
+
+// storageArea is implemented by the native and browser storage
+type storageArea interface {
+	keys() []string
+	getItem(key string) (string, bool)
+	setItem(key, value string)
+	removeItem(key string)
+	clear()
+}
+
+var _ dom.Storage = (*storage)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (this *storage) Length() int {
+	return len(this.keys())
+}
+
+// Key returns the key at an index, or an empty string if the index is out
+// of range
+func (this *storage) Key(index int) string {
+	if keys := this.keys(); index >= 0 && index < len(keys) {
+		return keys[index]
+	}
+	return ""
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (this *storage) GetItem(key string) (string, bool) {
+	return this.area.getItem(this.prefix + key)
+}
+
+func (this *storage) SetItem(key, value string) {
+	this.area.setItem(this.prefix+key, value)
+}
+
+func (this *storage) RemoveItem(key string) {
+	this.area.removeItem(this.prefix + key)
+}
+
+func (this *storage) Clear() {
+	if this.prefix == "" {
+		this.area.clear()
+		return
+	}
+	for _, key := range this.keys() {
+		this.RemoveItem(key)
+	}
+}
+
+// JSON decodes the JSON-encoded item into v
+func (this *storage) JSON(key string, v any) error {
+	value, exists := this.GetItem(key)
+	if !exists {
+		return dom.ErrNotFound.With(key)
+	}
+	if err := json.Unmarshal([]byte(value), v); err != nil {
+		return dom.ErrBadParameter.Withf("%s: %v", key, err)
+	}
+	return nil
+}
+
+// SetJSON sets the item to the JSON encoding of v
+func (this *storage) SetJSON(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return dom.ErrBadParameter.Withf("%s: %v", key, err)
+	}
+	this.SetItem(key, string(data))
+	return nil
+}
+
+func (this *storage) WithPrefix(prefix string) dom.Storage {
+	return &storage{this.area, this.prefix + prefix}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// keys returns the keys with the prefix, with the prefix removed
+func (this *storage) keys() []string {
+	keys := this.area.keys()
+	if this.prefix == "" {
+		return keys
+	}
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key, ok := strings.CutPrefix(key, this.prefix); ok {
+			result = append(result, key)
+		}
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+//go:build !js
+
+package dom_test
+
+import (
+	"bytes"
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorage_Event(t *testing.T) {
+	source, other := domPkg.GetWindowWithTitle(""), domPkg.GetWindowWithTitle("")
+	source.Location().Assign("/employees")
+	defer source.LocalStorage().Clear()
+
+	var events []string
+	record := func(e dom.Event) {
+		s := e.(dom.StorageEvent)
+		assert.Nil(t, e.Target())
+		events = append(events, s.Key()+":"+s.OldValue()+">"+s.NewValue()+" "+s.URL())
+	}
+	defer source.AddEventHandler("storage", func(e dom.Event) { t.Error("event dispatched to the source window") }).Remove()
+	defer other.AddEventHandler("storage", record).Remove()
+
+	// Events are dispatched to the other windows which share local storage,
+	// unless the value is unchanged
+	source.LocalStorage().WithPrefix("app.").SetItem("page", "1")
+	source.LocalStorage().SetItem("app.page", "1")
+	source.LocalStorage().SetItem("app.page", "2")
+	source.LocalStorage().RemoveItem("app.page")
+	source.LocalStorage().SetItem("app.page", "3")
+	source.LocalStorage().Clear()
+	assert.Equal(t, []string{
+		"app.page:>1 http://localhost/employees",
+		"app.page:1>2 http://localhost/employees",
+		"app.page:2> http://localhost/employees",
+		"app.page:>3 http://localhost/employees",
+		":> http://localhost/employees",
+	}, events)
+
+	// Session storage is not shared
+	events = nil
+	source.SessionStorage().SetItem("page", "1")
+	_, exists := other.SessionStorage().GetItem("page")
+	assert.False(t, exists)
+	assert.Empty(t, events)
+}
+
+func TestStorage_ReadWrite(t *testing.T) {
+	storage := domPkg.GetWindowWithTitle("").LocalStorage()
+	defer storage.Clear()
+	storage.SetItem("theme", "dark")
+
+	var buf bytes.Buffer
+	assert.NoError(t, domPkg.WriteLocalStorage(&buf))
+	assert.Equal(t, "{\"theme\":\"dark\"}\n", buf.String())
+
+	// Reading replaces the items
+	assert.NoError(t, domPkg.ReadLocalStorage(bytes.NewBufferString(`{"page":"2"}`)))
+	assert.Equal(t, 1, storage.Length())
+	value, _ := storage.GetItem("page")
+	assert.Equal(t, "2", value)
+	assert.ErrorIs(t, domPkg.ReadLocalStorage(bytes.NewBufferString(`[]`)), dom.ErrBadParameter)
+}
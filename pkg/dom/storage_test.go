@@ -0,0 +1,87 @@
+package dom_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorage_Items(t *testing.T) {
+	storage := domPkg.GetWindow().SessionStorage()
+	storage.Clear()
+	assert.Equal(t, 0, storage.Length())
+
+	storage.SetItem("page", "1")
+	storage.SetItem("filter", "")
+	value, exists := storage.GetItem("page")
+	assert.True(t, exists)
+	assert.Equal(t, "1", value)
+	value, exists = storage.GetItem("filter")
+	assert.True(t, exists)
+	assert.Equal(t, "", value)
+	_, exists = storage.GetItem("missing")
+	assert.False(t, exists)
+
+	assert.Equal(t, 2, storage.Length())
+	assert.ElementsMatch(t, []string{"page", "filter"}, []string{storage.Key(0), storage.Key(1)})
+	assert.Equal(t, "", storage.Key(2))
+
+	storage.RemoveItem("page")
+	storage.RemoveItem("missing")
+	assert.Equal(t, 1, storage.Length())
+	storage.Clear()
+	assert.Equal(t, 0, storage.Length())
+}
+
+func TestStorage_JSON(t *testing.T) {
+	storage := domPkg.GetWindow().SessionStorage()
+	defer storage.Clear()
+
+	type filters struct {
+		Department string   `json:"department"`
+		Columns    []string `json:"columns"`
+	}
+	assert.NoError(t, storage.SetJSON("filters", filters{"sales", []string{"name", "salary"}}))
+	value, _ := storage.GetItem("filters")
+	assert.Equal(t, `{"department":"sales","columns":["name","salary"]}`, value)
+
+	var result filters
+	assert.NoError(t, storage.JSON("filters", &result))
+	assert.Equal(t, filters{"sales", []string{"name", "salary"}}, result)
+
+	// Missing items, invalid JSON and values which cannot be encoded
+	assert.ErrorIs(t, storage.JSON("missing", &result), dom.ErrNotFound)
+	storage.SetItem("invalid", "{")
+	assert.ErrorIs(t, storage.JSON("invalid", &result), dom.ErrBadParameter)
+	assert.ErrorIs(t, storage.SetJSON("func", func() {}), dom.ErrBadParameter)
+}
+
+func TestStorage_WithPrefix(t *testing.T) {
+	storage := domPkg.GetWindow().SessionStorage()
+	defer storage.Clear()
+	storage.SetItem("other", "x")
+
+	// Keys are prefixed in the storage, and the prefix is removed from keys
+	app := storage.WithPrefix("table-app.")
+	app.SetItem("page", "2")
+	assert.Equal(t, 1, app.Length())
+	assert.Equal(t, "page", app.Key(0))
+	value, _ := storage.GetItem("table-app.page")
+	assert.Equal(t, "2", value)
+	_, exists := app.GetItem("other")
+	assert.False(t, exists)
+
+	// Prefixes can be nested
+	app.WithPrefix("filters.").SetItem("department", "sales")
+	value, _ = storage.GetItem("table-app.filters.department")
+	assert.Equal(t, "sales", value)
+	assert.Equal(t, 3, storage.Length())
+
+	// Clear only removes the items with the prefix
+	app.Clear()
+	assert.Equal(t, 0, app.Length())
+	assert.Equal(t, 1, storage.Length())
+}
@@ -0,0 +1,167 @@
+//go:build !js
+
+package dom
+
+import (
+	"encoding/json"
+	"io"
+	"maps"
+	"slices"
+	"weak"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// memoryStorage holds the items of a storage area in memory, and the
+// windows which share it
+type memoryStorage struct {
+	items   map[string]string
+	windows []weak.Pointer[window]
+}
+
+// windowStorage is the storage area of a window, which dispatches storage
+// events to the other windows which share it when an item changes
+type windowStorage struct {
+	*memoryStorage
+	window *window
+}
+
+type storageEvent struct {
+	*event
+	key, oldValue, newValue, url string
+}
+
+var _ storageArea = (*windowStorage)(nil)
+var _ dom.StorageEvent = (*storageEvent)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+var (
+	// The local storage, which is shared by all native windows
+	localStorage = newMemoryStorage()
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{items: make(map[string]string)}
+}
+
+// attach returns the storage area for a window, and adds the window to
+// the windows which receive storage events
+func (this *memoryStorage) attach(w *window) *windowStorage {
+	this.windows = append(this.windows, weak.Make(w))
+	return &windowStorage{this, w}
+}
+
+func newStorageEvent(key, oldValue, newValue, url string) *storageEvent {
+	e := &storageEvent{event: newEvent("storage", false, false), key: key, oldValue: oldValue, newValue: newValue, url: url}
+	e.self = e
+	return e
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+// LocalStorage returns the storage which is shared by all native windows,
+// and which can be read and written with ReadLocalStorage and
+// WriteLocalStorage
+func (this *window) LocalStorage() dom.Storage {
+	return &storage{area: this.local}
+}
+
+// SessionStorage returns the storage for the window
+func (this *window) SessionStorage() dom.Storage {
+	return &storage{area: this.session}
+}
+
+func (e *storageEvent) Key() string {
+	return e.key
+}
+
+func (e *storageEvent) OldValue() string {
+	return e.oldValue
+}
+
+func (e *storageEvent) NewValue() string {
+	return e.newValue
+}
+
+func (e *storageEvent) URL() string {
+	return e.url
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// ReadLocalStorage replaces the items in local storage with the items in a
+// JSON object, such as a file written by WriteLocalStorage
+func ReadLocalStorage(r io.Reader) error {
+	items := make(map[string]string)
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return dom.ErrBadParameter.With(err)
+	}
+	localStorage.items = items
+	return nil
+}
+
+// WriteLocalStorage writes the items in local storage as a JSON object
+func WriteLocalStorage(w io.Writer) error {
+	return json.NewEncoder(w).Encode(localStorage.items)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// keys returns the keys in sorted order
+func (this *windowStorage) keys() []string {
+	return slices.Sorted(maps.Keys(this.items))
+}
+
+func (this *windowStorage) getItem(key string) (string, bool) {
+	value, exists := this.items[key]
+	return value, exists
+}
+
+func (this *windowStorage) setItem(key, value string) {
+	old, exists := this.items[key]
+	if exists && old == value {
+		return
+	}
+	this.items[key] = value
+	this.changed(key, old, value)
+}
+
+func (this *windowStorage) removeItem(key string) {
+	if old, exists := this.items[key]; exists {
+		delete(this.items, key)
+		this.changed(key, old, "")
+	}
+}
+
+func (this *windowStorage) clear() {
+	if len(this.items) > 0 {
+		clear(this.items)
+		this.changed("", "", "")
+	}
+}
+
+// changed dispatches a storage event to the other windows which share the
+// storage, and forgets windows which no longer exist
+func (this *windowStorage) changed(key, oldValue, newValue string) {
+	this.windows = slices.DeleteFunc(this.windows, func(ptr weak.Pointer[window]) bool {
+		return ptr.Value() == nil
+	})
+	url := this.window.Location().Href()
+	for _, ptr := range slices.Clone(this.windows) {
+		if w := ptr.Value(); w != nil && w != this.window {
+			w.DispatchEvent(newStorageEvent(key, oldValue, newValue, url))
+		}
+	}
+}
@@ -0,0 +1,83 @@
+//go:build js
+
+package dom
+
+import (
+	"syscall/js"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// jsStorage is a browser storage area
+type jsStorage struct {
+	js.Value
+}
+
+type storageEvent struct {
+	*event
+}
+
+var _ storageArea = (*jsStorage)(nil)
+var _ dom.StorageEvent = (*storageEvent)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (this *window) LocalStorage() dom.Storage {
+	return &storage{area: &jsStorage{this.Get("localStorage")}}
+}
+
+func (this *window) SessionStorage() dom.Storage {
+	return &storage{area: &jsStorage{this.Get("sessionStorage")}}
+}
+
+func (e *storageEvent) Key() string {
+	return nullString(e.JSValue().Get("key"))
+}
+
+func (e *storageEvent) OldValue() string {
+	return nullString(e.JSValue().Get("oldValue"))
+}
+
+func (e *storageEvent) NewValue() string {
+	return nullString(e.JSValue().Get("newValue"))
+}
+
+func (e *storageEvent) URL() string {
+	return e.JSValue().Get("url").String()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func (this *jsStorage) keys() []string {
+	keys := make([]string, this.Get("length").Int())
+	for i := range keys {
+		keys[i] = this.Call("key", i).String()
+	}
+	return keys
+}
+
+func (this *jsStorage) getItem(key string) (string, bool) {
+	value := this.Call("getItem", key)
+	if value.IsNull() {
+		return "", false
+	}
+	return value.String(), true
+}
+
+func (this *jsStorage) setItem(key, value string) {
+	this.Call("setItem", key, value)
+}
+
+func (this *jsStorage) removeItem(key string) {
+	this.Call("removeItem", key)
+}
+
+func (this *jsStorage) clear() {
+	this.Call("clear")
+}
@@ -0,0 +1,227 @@
+//go:build !js
+
+package dom
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// TYPES
+
+type style struct {
+	properties []property
+
+	// Called with the new css text when properties are changed
+	change func(string)
+}
+
+type property struct {
+	name, value string
+	important   bool
+}
+
+var _ dom.Style = (*style)(nil)
+
+/////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+func newStyle() *style {
+	return new(style)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (s *style) String() string {
+	return fmt.Sprintf("<DOMStyle %q>", s.CSSText())
+}
+
+/////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (s *style) Length() int {
+	return len(s.properties)
+}
+
+// CSSText returns the declarations, in the order they were set
+func (s *style) CSSText() string {
+	var b strings.Builder
+	for i, p := range s.properties {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(p.name + ": " + p.value)
+		if p.important {
+			b.WriteString(" !important")
+		}
+		b.WriteByte(';')
+	}
+	return b.String()
+}
+
+// SetCSSText replaces all declarations
+func (s *style) SetCSSText(text string) {
+	s.set(text)
+	s.changed()
+}
+
+/////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (s *style) Item(index int) string {
+	if index < 0 || index >= len(s.properties) {
+		return ""
+	}
+	return s.properties[index].name
+}
+
+func (s *style) Names() []string {
+	names := make([]string, 0, len(s.properties))
+	for _, p := range s.properties {
+		names = append(names, p.name)
+	}
+	return names
+}
+
+func (s *style) Get(name string) string {
+	if i := s.index(name); i >= 0 {
+		return s.properties[i].value
+	}
+	return ""
+}
+
+func (s *style) GetPropertyPriority(name string) string {
+	if i := s.index(name); i >= 0 && s.properties[i].important {
+		return "important"
+	}
+	return ""
+}
+
+func (s *style) Set(name, value string) {
+	s.SetProperty(name, value, "")
+}
+
+// SetProperty sets the value and priority of a property, or removes the
+// property when the value is empty. The priority is "important" or empty.
+func (s *style) SetProperty(name, value, priority string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		s.RemoveProperty(name)
+		return
+	}
+	if priority != "" && !strings.EqualFold(priority, "important") {
+		return
+	}
+	if s.setProperty(name, value, priority != "") {
+		s.changed()
+	}
+}
+
+// RemoveProperty removes a property and returns its previous value
+func (s *style) RemoveProperty(name string) string {
+	i := s.index(name)
+	if i < 0 {
+		return ""
+	}
+	value := s.properties[i].value
+	s.properties = slices.Delete(s.properties, i, i+1)
+	s.changed()
+	return value
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// set replaces the declarations with those parsed from the css text,
+// without calling the change function. Invalid declarations are ignored.
+func (s *style) set(text string) {
+	s.properties = s.properties[:0]
+	for _, decl := range splitDeclarations(text) {
+		name, value, ok := strings.Cut(decl, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		important := false
+		if i := strings.LastIndex(value, "!"); i >= 0 && strings.EqualFold(strings.TrimSpace(value[i+1:]), "important") {
+			value, important = strings.TrimSpace(value[:i]), true
+		}
+		if strings.TrimSpace(name) != "" && value != "" {
+			s.setProperty(name, value, important)
+		}
+	}
+}
+
+// setProperty sets a property, keeping its position if it already exists,
+// and returns true if the property changed
+func (s *style) setProperty(name, value string, important bool) bool {
+	name = propertyName(name)
+	if name == "" {
+		return false
+	}
+	p := property{name, value, important}
+	if i := s.index(name); i >= 0 {
+		if s.properties[i] == p {
+			return false
+		}
+		s.properties[i] = p
+	} else {
+		s.properties = append(s.properties, p)
+	}
+	return true
+}
+
+func (s *style) index(name string) int {
+	name = propertyName(name)
+	return slices.IndexFunc(s.properties, func(p property) bool {
+		return p.name == name
+	})
+}
+
+func (s *style) changed() {
+	if s.change != nil {
+		s.change(s.CSSText())
+	}
+}
+
+// propertyName returns the property name in lowercase, except for custom
+// properties which are case-sensitive
+func propertyName(name string) string {
+	name = strings.TrimSpace(name)
+	if strings.HasPrefix(name, "--") {
+		return name
+	}
+	return strings.ToLower(name)
+}
+
+// splitDeclarations splits css text on semicolons which are not within
+// quotes or parentheses
+func splitDeclarations(text string) []string {
+	var result []string
+	var quote rune
+	depth, start := 0, 0
+	for i, r := range text {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case r == ';' && depth == 0:
+			result = append(result, text[start:i])
+			start = i + 1
+		}
+	}
+	return append(result, text[start:])
+}
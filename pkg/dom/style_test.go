@@ -0,0 +1,77 @@
+package dom_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStyle_Set(t *testing.T) {
+	div := domPkg.GetWindow().Document().CreateElement("div")
+	style := div.Style()
+	if !assert.NotNil(t, style) {
+		return
+	}
+	assert.Equal(t, 0, style.Length())
+
+	// Setting properties updates the style attribute
+	style.Set("color", "red")
+	style.SetProperty("margin-top", "4px", "important")
+	assert.Equal(t, "color: red; margin-top: 4px !important;", div.GetAttribute("style"))
+	assert.Equal(t, "red", style.Get("color"))
+	assert.Equal(t, "", style.GetPropertyPriority("color"))
+	assert.Equal(t, "important", style.GetPropertyPriority("margin-top"))
+	assert.Equal(t, []string{"color", "margin-top"}, style.Names())
+	assert.Equal(t, "margin-top", style.Item(1))
+	assert.Equal(t, "", style.Item(2))
+
+	// Setting an existing property keeps its position
+	style.Set("color", "blue")
+	assert.Equal(t, "color: blue; margin-top: 4px !important;", style.CSSText())
+
+	// Setting an empty value removes the property
+	style.Set("color", "")
+	assert.Equal(t, "margin-top: 4px !important;", div.GetAttribute("style"))
+}
+
+func TestStyle_RemoveProperty(t *testing.T) {
+	div := domPkg.GetWindow().Document().CreateElement("div")
+	div.SetAttribute("style", "color: red; display: none")
+
+	assert.Equal(t, "none", div.Style().RemoveProperty("display"))
+	assert.Equal(t, "", div.Style().RemoveProperty("display"))
+	assert.Equal(t, "color: red;", div.GetAttribute("style"))
+}
+
+func TestStyle_Attribute(t *testing.T) {
+	div := domPkg.GetWindow().Document().CreateElement("div")
+	style := div.Style()
+
+	// Setting the attribute updates the style
+	div.SetAttribute("style", "COLOR: red ; background: url(a;b.png); --Custom: 1;")
+	assert.Equal(t, 3, style.Length())
+	assert.Equal(t, "red", style.Get("color"))
+	assert.Equal(t, "url(a;b.png)", style.Get("background"))
+	assert.Equal(t, "1", style.Get("--Custom"))
+
+	// Removing the attribute clears the style
+	div.RemoveAttribute("style")
+	assert.Equal(t, 0, style.Length())
+	assert.Equal(t, "", style.CSSText())
+}
+
+func TestStyle_CSSText(t *testing.T) {
+	div := domPkg.GetWindow().Document().CreateElement("div")
+	style := div.Style()
+
+	style.SetCSSText("width: 10px; height: 20px !important")
+	assert.Equal(t, "width: 10px; height: 20px !important;", div.GetAttribute("style"))
+	assert.Equal(t, "important", style.GetPropertyPriority("height"))
+
+	// Cloned elements have a copy of the style
+	clone := div.CloneNode(false).(dom.Element)
+	assert.Equal(t, "10px", clone.Style().Get("width"))
+}
@@ -0,0 +1,50 @@
+//go:build !js
+
+package dom
+
+import (
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// TYPES
+
+type templateElement struct {
+	*element
+	content *fragment
+}
+
+var _ dom.HTMLTemplateElement = (*templateElement)(nil)
+
+/////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// newTemplateElement returns a template element with an empty content
+// fragment in the same document
+func newTemplateElement(elem *element) *templateElement {
+	content := NewNode(elem.document, "#document-fragment", dom.DOCUMENT_FRAGMENT_NODE, "").(*fragment)
+	return &templateElement{element: elem, content: content}
+}
+
+/////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (t *templateElement) Content() dom.DocumentFragment {
+	return t.content
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// copyTo clones the content into a clone of the template, when the
+// template is deep cloned
+func (t *templateElement) copyTo(clone dom.Node, deep bool) {
+	other, ok := clone.(*templateElement)
+	if !ok || !deep {
+		return
+	}
+	for child := t.content.FirstChild(); child != nil; child = child.NextSibling() {
+		other.content.AppendChild(child.CloneNode(true))
+	}
+}
@@ -0,0 +1,83 @@
+package dom_test
+
+import (
+	"strings"
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplate_Content(t *testing.T) {
+	div := newTree(`<template id="row"><tr><td class="name">x</td></tr></template>`)
+	template, ok := div.FirstChild().(dom.HTMLTemplateElement)
+	if !assert.True(t, ok) {
+		t.FailNow()
+	}
+
+	// The parsed contents are in the content fragment, not the template
+	assert.False(t, template.HasChildNodes())
+	assert.Nil(t, div.QuerySelector("td"))
+	assert.Equal(t, dom.DOCUMENT_FRAGMENT_NODE, template.Content().NodeType())
+	assert.NotNil(t, template.Content().QuerySelector("td.name"))
+
+	// The content is serialised as the contents of the template
+	assert.Equal(t, `<tr><td class="name">x</td></tr>`, template.InnerHTML())
+	assert.Equal(t, `<template id="row"><tr><td class="name">x</td></tr></template>`, div.InnerHTML())
+
+	// Setting the inner HTML replaces the content
+	template.SetInnerHTML(`<p>y</p>`)
+	assert.False(t, template.HasChildNodes())
+	assert.Equal(t, "y", template.Content().FirstChild().TextContent())
+}
+
+func TestTemplate_CreateElement(t *testing.T) {
+	template, ok := domPkg.GetWindow().Document().CreateElement("template").(dom.HTMLTemplateElement)
+	if assert.True(t, ok) {
+		assert.NotNil(t, template.Content())
+		assert.Equal(t, 0, template.Content().ChildElementCount())
+	}
+}
+
+func TestTemplate_CloneNode(t *testing.T) {
+	div := newTree(`<template><b>x</b></template>`)
+	template := div.FirstChild().(dom.HTMLTemplateElement)
+
+	// A deep clone copies the content, and a shallow clone does not
+	deep := template.CloneNode(true).(dom.HTMLTemplateElement)
+	assert.Equal(t, "<b>x</b>", deep.InnerHTML())
+	assert.False(t, deep.Content().Equals(template.Content()))
+	shallow := template.CloneNode(false).(dom.HTMLTemplateElement)
+	assert.Equal(t, "", shallow.InnerHTML())
+}
+
+func TestTemplate_Instantiate(t *testing.T) {
+	div := newTree(`<table><tbody></tbody></table><template><tr><td data-slot="name">-</td><td data-slot="count"></td><td data-slot="action"></td></tr></template>`)
+	tbody := div.QuerySelector("tbody")
+	template := div.QuerySelector("template").(dom.HTMLTemplateElement)
+
+	doc := domPkg.GetWindow().Document()
+	for _, name := range []string{"a", "b"} {
+		tbody.AppendChild(domPkg.Instantiate(template, map[string]any{
+			"name":   name,
+			"count":  len(name),
+			"action": doc.CreateElement("button"),
+		}))
+	}
+	tbody.AppendChild(domPkg.Instantiate(template, nil))
+
+	var rows []string
+	for _, tr := range tbody.QuerySelectorAll("tr") {
+		rows = append(rows, tr.InnerHTML())
+	}
+	assert.Equal(t, strings.Join([]string{
+		`<td data-slot="name">a</td><td data-slot="count">1</td><td data-slot="action"><button></button></td>`,
+		`<td data-slot="name">b</td><td data-slot="count">1</td><td data-slot="action"><button></button></td>`,
+		`<td data-slot="name">-</td><td data-slot="count"></td><td data-slot="action"></td>`,
+	}, "\n"), strings.Join(rows, "\n"))
+
+	// The template content is unchanged
+	assert.Equal(t, "-", template.Content().QuerySelector("td").TextContent())
+}
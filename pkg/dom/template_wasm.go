@@ -0,0 +1,33 @@
+//go:build js
+
+package dom
+
+import (
+	"syscall/js"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// TYPES
+
+type templateElement struct {
+	*element
+}
+
+var _ dom.HTMLTemplateElement = (*templateElement)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+var (
+	cHTMLTemplateElement = js.Global().Get("HTMLTemplateElement")
+)
+
+/////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (t *templateElement) Content() dom.DocumentFragment {
+	return NewNode(t.Get("content")).(dom.DocumentFragment)
+}
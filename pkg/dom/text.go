@@ -4,8 +4,6 @@ package dom
 
 import (
 	"fmt"
-	"html"
-	"io"
 	"strings"
 
 	dom "github.com/djthorpe/go-wasmbuild"
@@ -37,12 +35,16 @@ func (this *text) Data() string {
 }
 
 func (this *text) Length() int {
-	return len(this.cdata)
+	return dataLength(this.cdata)
 }
 
 /////////////////////////////////////////////////////////////////////
 // PUBLIC METHODS
 
+func (this *text) SetData(data string) {
+	this.setData(data)
+}
+
 func (this *text) CloneNode(bool) dom.Node {
 	return NewNode(this.document, this.name, this.nodetype, this.cdata)
 }
@@ -68,7 +70,3 @@ func (this *text) ReplaceChild(dom.Node, dom.Node) {
 func (this *text) v() *node {
 	return this.node
 }
-
-func (this *text) write(w io.Writer) (int, error) {
-	return w.Write([]byte(html.EscapeString(this.cdata)))
-}
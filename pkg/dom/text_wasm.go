@@ -34,6 +34,13 @@ func (this *text) Length() int {
 	return this.Get("length").Int()
 }
 
+/////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (this *text) SetData(data string) {
+	this.Set("data", data)
+}
+
 /////////////////////////////////////////////////////////////////////
 // PRIVATE METHODS
 
@@ -0,0 +1,168 @@
+//go:build !js
+
+package dom
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// clock is a simulated clock for the timers and animation frames of a
+// window, which only moves forward when it is advanced
+type clock struct {
+	now    time.Duration
+	seq    uint64
+	timers []*timer
+}
+
+// timer is a timeout, interval or animation frame. Timers are called in
+// order of the time they are due, with animation frames after other timers
+// which are due at the same time.
+type timer struct {
+	clock    *clock
+	due      time.Duration
+	seq      uint64
+	interval time.Duration
+	callback func()
+	frame    func(time.Duration)
+}
+
+var _ dom.Timer = (*timer)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	// The interval between animation frames
+	frameInterval = time.Second / 60
+
+	// The minimum interval for an interval timer
+	minInterval = time.Millisecond
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+func newClock() *clock {
+	return new(clock)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// SetTimeout calls a function once, when the clock has advanced by the
+// delay. A timeout with no delay is called when the clock is next advanced.
+func (this *window) SetTimeout(callback func(), delay time.Duration) dom.Timer {
+	if callback == nil {
+		panic(dom.ErrBadParameter.With("callback is nil"))
+	}
+	return this.clock.schedule(&timer{due: this.clock.now + max(delay, 0), callback: callback})
+}
+
+// SetInterval calls a function each time the clock advances by the
+// interval, which is at least one millisecond
+func (this *window) SetInterval(callback func(), interval time.Duration) dom.Timer {
+	if callback == nil {
+		panic(dom.ErrBadParameter.With("callback is nil"))
+	}
+	interval = max(interval, minInterval)
+	return this.clock.schedule(&timer{due: this.clock.now + interval, interval: interval, callback: callback})
+}
+
+// RequestAnimationFrame calls a function with the time of the next frame,
+// where frames are sixty times a second
+func (this *window) RequestAnimationFrame(callback func(time.Duration)) dom.Timer {
+	if callback == nil {
+		panic(dom.ErrBadParameter.With("callback is nil"))
+	}
+	due := (this.clock.now/frameInterval + 1) * frameInterval
+	return this.clock.schedule(&timer{due: due, frame: callback})
+}
+
+// Now returns the time on the clock
+func (this *window) Now() time.Duration {
+	return this.clock.now
+}
+
+// Advance holds the event loop and moves the clock forward, calling the
+// queued functions and the timers which are due
+func (this *window) Advance(d time.Duration) {
+	lockEventLoop()
+	defer unlockEventLoop()
+	this.clock.advance(max(d, 0), this.runTasks)
+}
+
+// Cancel removes the timer from the clock
+func (t *timer) Cancel() {
+	if t.clock != nil {
+		t.clock.remove(t)
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// schedule adds a timer to the clock in the order it is due
+func (this *clock) schedule(t *timer) *timer {
+	this.seq++
+	t.clock, t.seq = this, this.seq
+	i, _ := slices.BinarySearchFunc(this.timers, t, compareTimers)
+	this.timers = slices.Insert(this.timers, i, t)
+	return t
+}
+
+// remove removes a timer from the clock
+func (this *clock) remove(t *timer) {
+	t.clock = nil
+	this.timers = slices.DeleteFunc(this.timers, func(other *timer) bool {
+		return other == t
+	})
+}
+
+// advance calls the timers which are due in order, setting the clock to
+// the time each is due, and calls tasks before each timer and at the end.
+// Interval timers are rescheduled before they are called, so they can be
+// cancelled by their callback.
+func (this *clock) advance(d time.Duration, tasks func()) {
+	until := this.now + d
+	for tasks(); len(this.timers) > 0 && this.timers[0].due <= until; tasks() {
+		t := this.timers[0]
+		this.remove(t)
+		this.now = t.due
+		switch {
+		case t.frame != nil:
+			t.frame(t.due)
+		case t.interval > 0:
+			t.due += t.interval
+			this.schedule(t)
+			t.callback()
+		default:
+			t.callback()
+		}
+		FlushMutations()
+	}
+	this.now = until
+}
+
+// compareTimers orders timers by the time they are due, then timers before
+// animation frames, then by the order they were scheduled
+func compareTimers(a, b *timer) int {
+	switch {
+	case a.due != b.due:
+		return cmp.Compare(a.due, b.due)
+	case (a.frame == nil) != (b.frame == nil):
+		if a.frame == nil {
+			return -1
+		}
+		return 1
+	default:
+		return cmp.Compare(a.seq, b.seq)
+	}
+}
@@ -0,0 +1,87 @@
+//go:build !js
+
+package dom_test
+
+import (
+	"testing"
+	"time"
+
+	// Packages
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFakeWindow(t *testing.T) domPkg.FakeWindow {
+	t.Helper()
+	window, ok := domPkg.GetWindowWithTitle("").(domPkg.FakeWindow)
+	if !ok {
+		t.Fatal("not a fake window")
+	}
+	return window
+}
+
+func TestTimer_Timeout(t *testing.T) {
+	window := newFakeWindow(t)
+	var calls []string
+	window.SetTimeout(func() { calls = append(calls, "b") }, 20*time.Millisecond)
+	window.SetTimeout(func() { calls = append(calls, "a") }, 10*time.Millisecond)
+	window.SetTimeout(func() { calls = append(calls, "c") }, 20*time.Millisecond)
+	cancelled := window.SetTimeout(func() { calls = append(calls, "x") }, 5*time.Millisecond)
+	cancelled.Cancel()
+
+	// Timers are not called until the clock is advanced
+	assert.Empty(t, calls)
+	window.Advance(15 * time.Millisecond)
+	assert.Equal(t, []string{"a"}, calls)
+	assert.Equal(t, 15*time.Millisecond, window.Now())
+
+	// Timers due at the same time are called in the order they were set
+	window.Advance(time.Second)
+	assert.Equal(t, []string{"a", "b", "c"}, calls)
+
+	// A timeout with no delay is called when the clock is next advanced
+	window.SetTimeout(func() { calls = append(calls, "d") }, 0)
+	window.Advance(0)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, calls)
+}
+
+func TestTimer_Interval(t *testing.T) {
+	window := newFakeWindow(t)
+	var times []time.Duration
+	var interval interface{ Cancel() }
+	interval = window.SetInterval(func() {
+		times = append(times, window.Now())
+		if len(times) == 3 {
+			interval.Cancel()
+		}
+	}, 100*time.Millisecond)
+	window.Advance(250 * time.Millisecond)
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, times)
+
+	// The interval can be cancelled by its callback
+	window.Advance(time.Second)
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond}, times)
+}
+
+func TestTimer_AnimationFrame(t *testing.T) {
+	window := newFakeWindow(t)
+	frame := time.Second / 60
+	var calls []string
+	var frames []time.Duration
+	var animate func(time.Duration)
+	animate = func(ts time.Duration) {
+		frames = append(frames, ts)
+		calls = append(calls, "frame")
+		if len(frames) < 3 {
+			window.RequestAnimationFrame(animate)
+		}
+	}
+	window.RequestAnimationFrame(animate)
+	window.SetTimeout(func() { calls = append(calls, "timeout") }, frame)
+	window.RequestAnimationFrame(func(time.Duration) { t.Error("cancelled frame was called") }).Cancel()
+
+	// Frames are called with the frame time, after timers due at that time
+	window.Advance(time.Second)
+	assert.Equal(t, []time.Duration{frame, 2 * frame, 3 * frame}, frames)
+	assert.Equal(t, []string{"timeout", "frame", "frame", "frame"}, calls)
+}
@@ -0,0 +1,98 @@
+//go:build js
+
+package dom
+
+import (
+	"syscall/js"
+	"time"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// timer is a timeout, interval or animation frame, which releases the
+// function when it is cancelled or has been called once
+type timer struct {
+	window js.Value
+	cancel string
+	id     js.Value
+	fn     js.Func
+	done   bool
+}
+
+var _ dom.Timer = (*timer)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (this *window) SetTimeout(callback func(), delay time.Duration) dom.Timer {
+	if callback == nil {
+		panic(dom.ErrBadParameter.With("callback is nil"))
+	}
+	t := &timer{window: this.Value, cancel: "clearTimeout"}
+	t.fn = js.FuncOf(func(js.Value, []js.Value) any {
+		defer t.release()
+		callback()
+		return nil
+	})
+	t.id = this.Call("setTimeout", t.fn, delay.Milliseconds())
+	return t
+}
+
+func (this *window) SetInterval(callback func(), interval time.Duration) dom.Timer {
+	if callback == nil {
+		panic(dom.ErrBadParameter.With("callback is nil"))
+	}
+	t := &timer{window: this.Value, cancel: "clearInterval"}
+	t.fn = js.FuncOf(func(js.Value, []js.Value) any {
+		callback()
+		return nil
+	})
+	t.id = this.Call("setInterval", t.fn, max(interval, time.Millisecond).Milliseconds())
+	return t
+}
+
+// RequestAnimationFrame calls a function before the next repaint, with the
+// time since the window was created
+func (this *window) RequestAnimationFrame(callback func(time.Duration)) dom.Timer {
+	if callback == nil {
+		panic(dom.ErrBadParameter.With("callback is nil"))
+	}
+	t := &timer{window: this.Value, cancel: "cancelAnimationFrame"}
+	t.fn = js.FuncOf(func(_ js.Value, args []js.Value) any {
+		defer t.release()
+		callback(time.Duration(args[0].Float() * float64(time.Millisecond)))
+		return nil
+	})
+	t.id = this.Call("requestAnimationFrame", t.fn)
+	return t
+}
+
+// Dispatch queues a function to be called by the browser event loop, with
+// a timeout which has no delay
+func (this *window) Dispatch(fn func()) {
+	if fn == nil {
+		panic(dom.ErrBadParameter.With("function is nil"))
+	}
+	this.SetTimeout(fn, 0)
+}
+
+func (t *timer) Cancel() {
+	if !t.done {
+		t.window.Call(t.cancel, t.id)
+		t.release()
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func (t *timer) release() {
+	if !t.done {
+		t.done = true
+		t.fn.Release()
+	}
+}
@@ -15,6 +15,9 @@ import (
 
 type tokenlist struct {
 	values []string
+
+	// Called with the new value when tokens are added or removed
+	change func(string)
 }
 
 var _ dom.TokenList = (*tokenlist)(nil)
@@ -68,6 +71,7 @@ func (tokenlist *tokenlist) Add(values ...string) {
 			tokenlist.values = append(tokenlist.values, value)
 		}
 	}
+	tokenlist.changed()
 }
 
 func (tokenlist *tokenlist) Remove(values ...string) {
@@ -86,6 +90,7 @@ func (tokenlist *tokenlist) Remove(values ...string) {
 			tokenlist.values = append(tokenlist.values[:i], tokenlist.values[i+1:]...)
 		}
 	}
+	tokenlist.changed()
 }
 
 func (tokenlist *tokenlist) Toggle(value string, force ...bool) bool {
@@ -114,6 +119,21 @@ func (tokenlist *tokenlist) Toggle(value string, force ...bool) bool {
 	}
 }
 
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// set replaces the tokens with the space-separated tokens in value,
+// without calling the change function
+func (tokenlist *tokenlist) set(value string) {
+	tokenlist.values = strings.Fields(value)
+}
+
+func (tokenlist *tokenlist) changed() {
+	if tokenlist.change != nil {
+		tokenlist.change(tokenlist.Value())
+	}
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // STRINGIFY
 
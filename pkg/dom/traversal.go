@@ -0,0 +1,366 @@
+//go:build !js
+
+package dom
+
+import (
+	"slices"
+	"weak"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// TYPES
+
+// traversal is the root and filter shared by tree walkers and node
+// iterators
+type traversal struct {
+	root       dom.Node
+	whatToShow dom.NodeFilter
+	filter     func(dom.Node) dom.FilterResult
+}
+
+type treeWalker struct {
+	traversal
+	current dom.Node
+}
+
+type nodeIterator struct {
+	traversal
+	reference dom.Node
+	before    bool
+}
+
+var _ dom.TreeWalker = (*treeWalker)(nil)
+var _ dom.NodeIterator = (*nodeIterator)(nil)
+
+/////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// CreateTreeWalker returns a tree walker with the root as the current node
+func (this *document) CreateTreeWalker(root dom.Node, whatToShow dom.NodeFilter, filter func(dom.Node) dom.FilterResult) dom.TreeWalker {
+	t := newTraversal(root, whatToShow, filter)
+	return &treeWalker{t, t.root}
+}
+
+// CreateNodeIterator returns a node iterator with the pointer before the
+// root, which is updated when nodes are removed until it is detached
+func (this *document) CreateNodeIterator(root dom.Node, whatToShow dom.NodeFilter, filter func(dom.Node) dom.FilterResult) dom.NodeIterator {
+	t := newTraversal(root, whatToShow, filter)
+	it := &nodeIterator{t, t.root, true}
+	if doc, ok := ownerDocument(t.root).(*document); ok {
+		doc.iterators = append(doc.iterators, weak.Make(it))
+	}
+	return it
+}
+
+func newTraversal(root dom.Node, whatToShow dom.NodeFilter, filter func(dom.Node) dom.FilterResult) traversal {
+	if root == nil {
+		panic(dom.ErrBadParameter.With("root is nil"))
+	}
+	return traversal{getNode(root).self, whatToShow, filter}
+}
+
+/////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (t *traversal) Root() dom.Node {
+	return t.root
+}
+
+func (t *traversal) WhatToShow() dom.NodeFilter {
+	return t.whatToShow
+}
+
+func (w *treeWalker) CurrentNode() dom.Node {
+	return w.current
+}
+
+func (w *treeWalker) SetCurrentNode(node dom.Node) {
+	if node != nil {
+		w.current = getNode(node).self
+	}
+}
+
+func (it *nodeIterator) ReferenceNode() dom.Node {
+	return it.reference
+}
+
+func (it *nodeIterator) PointerBeforeReferenceNode() bool {
+	return it.before
+}
+
+/////////////////////////////////////////////////////////////////////
+// TREE WALKER METHODS
+
+// ParentNode moves to the nearest accepted ancestor within the root
+func (w *treeWalker) ParentNode() dom.Node {
+	for n := w.current; n != nil && n != w.root; {
+		if n = n.ParentNode(); n != nil && w.accept(n) == dom.FILTER_ACCEPT {
+			w.current = n
+			return n
+		}
+	}
+	return nil
+}
+
+func (w *treeWalker) FirstChild() dom.Node {
+	return w.traverseChildren(true)
+}
+
+func (w *treeWalker) LastChild() dom.Node {
+	return w.traverseChildren(false)
+}
+
+func (w *treeWalker) NextSibling() dom.Node {
+	return w.traverseSiblings(true)
+}
+
+func (w *treeWalker) PreviousSibling() dom.Node {
+	return w.traverseSiblings(false)
+}
+
+// PreviousNode moves to the previous accepted node in tree order, where
+// the descendants of rejected nodes are skipped
+func (w *treeWalker) PreviousNode() dom.Node {
+	n := w.current
+	for n != w.root {
+		for sibling := n.PreviousSibling(); sibling != nil; sibling = n.PreviousSibling() {
+			n = sibling
+			result := w.accept(n)
+			for result != dom.FILTER_REJECT && n.LastChild() != nil {
+				n = n.LastChild()
+				result = w.accept(n)
+			}
+			if result == dom.FILTER_ACCEPT {
+				w.current = n
+				return n
+			}
+		}
+		if n == w.root || n.ParentNode() == nil {
+			return nil
+		}
+		n = n.ParentNode()
+		if w.accept(n) == dom.FILTER_ACCEPT {
+			w.current = n
+			return n
+		}
+	}
+	return nil
+}
+
+// NextNode moves to the next accepted node in tree order, where the
+// descendants of rejected nodes are skipped
+func (w *treeWalker) NextNode() dom.Node {
+	n, result := w.current, dom.FILTER_ACCEPT
+	for {
+		for result != dom.FILTER_REJECT && n.FirstChild() != nil {
+			n = n.FirstChild()
+			if result = w.accept(n); result == dom.FILTER_ACCEPT {
+				w.current = n
+				return n
+			}
+		}
+		if n = nextInTree(n, w.root, false); n == nil {
+			return nil
+		}
+		if result = w.accept(n); result == dom.FILTER_ACCEPT {
+			w.current = n
+			return n
+		}
+	}
+}
+
+/////////////////////////////////////////////////////////////////////
+// NODE ITERATOR METHODS
+
+func (it *nodeIterator) NextNode() dom.Node {
+	return it.traverse(true)
+}
+
+func (it *nodeIterator) PreviousNode() dom.Node {
+	return it.traverse(false)
+}
+
+// Detach stops the iterator from being updated when nodes are removed
+func (it *nodeIterator) Detach() {
+	if doc, ok := ownerDocument(it.root).(*document); ok {
+		doc.iterators = slices.DeleteFunc(doc.iterators, func(p weak.Pointer[nodeIterator]) bool {
+			return p.Value() == it
+		})
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// accept returns the filter result for a node, where nodes which are not
+// shown by the whatToShow mask are skipped
+func (t *traversal) accept(n dom.Node) dom.FilterResult {
+	if t.whatToShow&(1<<(n.NodeType()-1)) == 0 {
+		return dom.FILTER_SKIP
+	}
+	if t.filter == nil {
+		return dom.FILTER_ACCEPT
+	}
+	return t.filter(n)
+}
+
+// traverseChildren moves to the first or last accepted child, descending
+// into skipped children
+func (w *treeWalker) traverseChildren(first bool) dom.Node {
+	n := w.child(w.current, first)
+	for n != nil {
+		result := w.accept(n)
+		if result == dom.FILTER_ACCEPT {
+			w.current = n
+			return n
+		}
+		if result == dom.FILTER_SKIP {
+			if child := w.child(n, first); child != nil {
+				n = child
+				continue
+			}
+		}
+		for n != nil {
+			if sibling := w.sibling(n, first); sibling != nil {
+				n = sibling
+				break
+			}
+			parent := n.ParentNode()
+			if parent == nil || parent == w.root || parent == w.current {
+				return nil
+			}
+			n = parent
+		}
+	}
+	return nil
+}
+
+// traverseSiblings moves to the next or previous accepted sibling,
+// descending into skipped siblings and ascending through skipped parents
+func (w *treeWalker) traverseSiblings(next bool) dom.Node {
+	n := w.current
+	if n == w.root {
+		return nil
+	}
+	for {
+		sibling := w.sibling(n, next)
+		for sibling != nil {
+			n = sibling
+			result := w.accept(n)
+			if result == dom.FILTER_ACCEPT {
+				w.current = n
+				return n
+			}
+			sibling = w.child(n, next)
+			if result == dom.FILTER_REJECT || sibling == nil {
+				sibling = w.sibling(n, next)
+			}
+		}
+		if n = n.ParentNode(); n == nil || n == w.root || w.accept(n) == dom.FILTER_ACCEPT {
+			return nil
+		}
+	}
+}
+
+func (w *treeWalker) child(n dom.Node, first bool) dom.Node {
+	if first {
+		return n.FirstChild()
+	}
+	return n.LastChild()
+}
+
+func (w *treeWalker) sibling(n dom.Node, next bool) dom.Node {
+	if next {
+		return n.NextSibling()
+	}
+	return n.PreviousSibling()
+}
+
+// traverse moves the pointer forwards or backwards to the next accepted
+// node
+func (it *nodeIterator) traverse(next bool) dom.Node {
+	n, before := it.reference, it.before
+	for {
+		switch {
+		case next && before, !next && !before:
+			before = !before
+		case next:
+			if n = nextInTree(n, it.root, true); n == nil {
+				return nil
+			}
+		default:
+			if n = previousInTree(n, it.root); n == nil {
+				return nil
+			}
+		}
+		if it.accept(n) == dom.FILTER_ACCEPT {
+			break
+		}
+	}
+	it.reference, it.before = n, before
+	return n
+}
+
+// removing updates the reference node before a node is removed, when the
+// node is the reference node or one of its ancestors
+func (it *nodeIterator) removing(node dom.Node) {
+	if node == it.root || !isInclusiveAncestor(node, it.reference) {
+		return
+	}
+	if it.before {
+		if next := nextInTree(node, it.root, false); next != nil {
+			it.reference = next
+			return
+		}
+		it.before = false
+	}
+	if prev := node.PreviousSibling(); prev != nil {
+		it.reference = lastDescendant(prev)
+	} else {
+		it.reference = node.ParentNode()
+	}
+}
+
+// removing runs the removal steps of the node iterators, before a node is
+// removed from its parent
+func (this *document) removing(node dom.Node) {
+	this.iterators = slices.DeleteFunc(this.iterators, func(p weak.Pointer[nodeIterator]) bool {
+		it := p.Value()
+		if it != nil {
+			it.removing(node)
+		}
+		return it == nil
+	})
+}
+
+// previousInTree returns the node before n in tree order within root, or
+// nil
+func previousInTree(n, root dom.Node) dom.Node {
+	if n == root {
+		return nil
+	}
+	if prev := n.PreviousSibling(); prev != nil {
+		return lastDescendant(prev)
+	}
+	return n.ParentNode()
+}
+
+// lastDescendant returns the last inclusive descendant of n in tree order
+func lastDescendant(n dom.Node) dom.Node {
+	for child := n.LastChild(); child != nil; child = n.LastChild() {
+		n = child
+	}
+	return n
+}
+
+// ownerDocument returns the document of a node, or the node if it is a
+// document
+func ownerDocument(node dom.Node) dom.Document {
+	if doc, ok := node.(dom.Document); ok {
+		return doc
+	}
+	return node.OwnerDocument()
+}
@@ -0,0 +1,169 @@
+package dom_test
+
+import (
+	"strings"
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTree returns a div with the HTML as its contents
+func newTree(html string) dom.Element {
+	div := domPkg.GetWindow().Document().CreateElement("div")
+	div.SetInnerHTML(html)
+	return div
+}
+
+// nodeNames returns the node names of nodes, with the data of text nodes
+func nodeNames(nodes ...dom.Node) string {
+	var result []string
+	for _, node := range nodes {
+		if node == nil {
+			result = append(result, "nil")
+		} else if node.NodeType() == dom.TEXT_NODE {
+			result = append(result, node.TextContent())
+		} else {
+			result = append(result, strings.ToLower(node.NodeName()))
+		}
+	}
+	return strings.Join(result, " ")
+}
+
+func TestTraversal_TreeWalker(t *testing.T) {
+	div := newTree(`<p>a<b>b</b></p><ul><li>c</li></ul>`)
+	walker := domPkg.GetWindow().Document().CreateTreeWalker(div, dom.SHOW_ELEMENT, nil)
+	assert.True(t, div.Equals(walker.Root()))
+	assert.Equal(t, dom.SHOW_ELEMENT, walker.WhatToShow())
+
+	var nodes []dom.Node
+	for n := walker.NextNode(); n != nil; n = walker.NextNode() {
+		nodes = append(nodes, n)
+	}
+	assert.Equal(t, "p b ul li", nodeNames(nodes...))
+
+	// The current node stays on the last node
+	assert.Equal(t, "li", nodeNames(walker.CurrentNode()))
+	assert.Equal(t, "ul", nodeNames(walker.PreviousNode()))
+	assert.Equal(t, "b", nodeNames(walker.PreviousNode()))
+	assert.Equal(t, "p", nodeNames(walker.ParentNode()))
+	assert.Equal(t, "ul", nodeNames(walker.NextSibling()))
+	assert.Equal(t, "nil", nodeNames(walker.NextSibling()))
+	assert.Equal(t, "li", nodeNames(walker.FirstChild()))
+	assert.Equal(t, "ul", nodeNames(walker.ParentNode()))
+	assert.Equal(t, "div", nodeNames(walker.ParentNode()))
+	assert.Equal(t, "nil", nodeNames(walker.ParentNode()))
+	assert.Equal(t, "ul", nodeNames(walker.LastChild()))
+}
+
+func TestTraversal_TreeWalkerFilter(t *testing.T) {
+	div := newTree(`<p>a<b>b</b></p><ul><li>c</li></ul><i>d</i>`)
+
+	// Rejected nodes are skipped with their descendants, and skipped nodes
+	// are skipped without their descendants
+	walker := domPkg.GetWindow().Document().CreateTreeWalker(div, dom.SHOW_ELEMENT|dom.SHOW_TEXT, func(n dom.Node) dom.FilterResult {
+		switch strings.ToLower(n.NodeName()) {
+		case "p":
+			return dom.FILTER_REJECT
+		case "ul":
+			return dom.FILTER_SKIP
+		default:
+			return dom.FILTER_ACCEPT
+		}
+	})
+	var nodes []dom.Node
+	for n := walker.NextNode(); n != nil; n = walker.NextNode() {
+		nodes = append(nodes, n)
+	}
+	assert.Equal(t, "li c i d", nodeNames(nodes...))
+
+	// Children of skipped nodes are treated as children
+	walker.SetCurrentNode(div)
+	assert.Equal(t, "li", nodeNames(walker.FirstChild()))
+	assert.Equal(t, "i", nodeNames(walker.NextSibling()))
+}
+
+func TestTraversal_NodeIterator(t *testing.T) {
+	div := newTree(`<p>a</p><!--c--><b>b</b>`)
+	it := domPkg.GetWindow().Document().CreateNodeIterator(div, dom.SHOW_ALL, nil)
+	assert.True(t, div.Equals(it.ReferenceNode()))
+	assert.True(t, it.PointerBeforeReferenceNode())
+
+	// The root is the first node
+	var nodes []dom.Node
+	for n := it.NextNode(); n != nil; n = it.NextNode() {
+		nodes = append(nodes, n)
+	}
+	assert.Equal(t, "div p a #comment b b", nodeNames(nodes...))
+	assert.False(t, it.PointerBeforeReferenceNode())
+
+	// Moving backwards returns the reference node first
+	assert.Equal(t, "b", nodeNames(it.PreviousNode()))
+	assert.True(t, it.PointerBeforeReferenceNode())
+	assert.Equal(t, "b", nodeNames(it.PreviousNode()))
+	assert.Equal(t, "#comment", nodeNames(it.PreviousNode()))
+	it.Detach()
+}
+
+func TestTraversal_NodeIteratorRemove(t *testing.T) {
+	div := newTree(`<p></p><i></i><b></b>`)
+	it := domPkg.GetWindow().Document().CreateNodeIterator(div, dom.SHOW_ELEMENT, nil)
+	it.NextNode()
+	assert.Equal(t, "p", nodeNames(it.NextNode()))
+	assert.Equal(t, "i", nodeNames(it.NextNode()))
+
+	// When the reference node is removed, the reference moves to the
+	// previous node, and iteration continues from there
+	div.RemoveChild(div.QuerySelector("i"))
+	assert.Equal(t, "p", nodeNames(it.ReferenceNode()))
+	assert.Equal(t, "b", nodeNames(it.NextNode()))
+
+	// When the pointer is before the reference node, the reference moves
+	// to the next node
+	assert.Equal(t, "b", nodeNames(it.PreviousNode()))
+	div.QuerySelector("b").Remove()
+	assert.Equal(t, "p", nodeNames(it.ReferenceNode()))
+	assert.False(t, it.PointerBeforeReferenceNode())
+	assert.Equal(t, "nil", nodeNames(it.NextNode()))
+}
+
+func TestTraversal_Iterators(t *testing.T) {
+	div := newTree(`<p>a<b>b</b></p><ul><li>c</li></ul><i>d</i>`)
+	ul := div.QuerySelector("ul")
+
+	var nodes []dom.Node
+	for n := range div.Descendants() {
+		nodes = append(nodes, n)
+	}
+	assert.Equal(t, "p a b b ul li c i d", nodeNames(nodes...))
+
+	nodes = nil
+	for n := range div.QuerySelector("li").FirstChild().Ancestors() {
+		nodes = append(nodes, n)
+	}
+	assert.Equal(t, "li ul div", nodeNames(nodes...))
+
+	nodes = nil
+	for n := range ul.NextSiblings() {
+		nodes = append(nodes, n)
+	}
+	assert.Equal(t, "i", nodeNames(nodes...))
+
+	nodes = nil
+	for n := range ul.PreviousSiblings() {
+		nodes = append(nodes, n)
+	}
+	assert.Equal(t, "p", nodeNames(nodes...))
+
+	// Iteration stops when the loop ends early
+	nodes = nil
+	for n := range div.Descendants() {
+		if strings.EqualFold(n.NodeName(), "ul") {
+			break
+		}
+		nodes = append(nodes, n)
+	}
+	assert.Equal(t, "p a b b", nodeNames(nodes...))
+}
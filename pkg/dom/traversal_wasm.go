@@ -0,0 +1,126 @@
+//go:build js
+
+package dom
+
+import (
+	"runtime"
+	"syscall/js"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+)
+
+/////////////////////////////////////////////////////////////////////
+// TYPES
+
+// traversal wraps a tree walker or node iterator
+type traversal struct {
+	js.Value
+}
+
+type treeWalker struct {
+	traversal
+}
+
+type nodeIterator struct {
+	traversal
+}
+
+var _ dom.TreeWalker = (*treeWalker)(nil)
+var _ dom.NodeIterator = (*nodeIterator)(nil)
+
+/////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+func (this *document) CreateTreeWalker(root dom.Node, whatToShow dom.NodeFilter, filter func(dom.Node) dom.FilterResult) dom.TreeWalker {
+	w := new(treeWalker)
+	w.Value = this.Call("createTreeWalker", toJSValue(root), uint32(whatToShow), nodeFilter(w, filter))
+	return w
+}
+
+func (this *document) CreateNodeIterator(root dom.Node, whatToShow dom.NodeFilter, filter func(dom.Node) dom.FilterResult) dom.NodeIterator {
+	it := new(nodeIterator)
+	it.Value = this.Call("createNodeIterator", toJSValue(root), uint32(whatToShow), nodeFilter(it, filter))
+	return it
+}
+
+/////////////////////////////////////////////////////////////////////
+// PROPERTIES
+
+func (t *traversal) Root() dom.Node {
+	return NewNode(t.Get("root"))
+}
+
+func (t *traversal) WhatToShow() dom.NodeFilter {
+	return dom.NodeFilter(uint32(t.Get("whatToShow").Float()))
+}
+
+func (w *treeWalker) CurrentNode() dom.Node {
+	return NewNode(w.Get("currentNode"))
+}
+
+func (w *treeWalker) SetCurrentNode(node dom.Node) {
+	if node != nil {
+		w.Set("currentNode", toJSValue(node))
+	}
+}
+
+func (it *nodeIterator) ReferenceNode() dom.Node {
+	return NewNode(it.Get("referenceNode"))
+}
+
+func (it *nodeIterator) PointerBeforeReferenceNode() bool {
+	return it.Get("pointerBeforeReferenceNode").Bool()
+}
+
+/////////////////////////////////////////////////////////////////////
+// METHODS
+
+func (w *treeWalker) ParentNode() dom.Node {
+	return NewNode(w.Call("parentNode"))
+}
+
+func (w *treeWalker) FirstChild() dom.Node {
+	return NewNode(w.Call("firstChild"))
+}
+
+func (w *treeWalker) LastChild() dom.Node {
+	return NewNode(w.Call("lastChild"))
+}
+
+func (w *treeWalker) PreviousSibling() dom.Node {
+	return NewNode(w.Call("previousSibling"))
+}
+
+func (w *treeWalker) NextSibling() dom.Node {
+	return NewNode(w.Call("nextSibling"))
+}
+
+func (t *traversal) PreviousNode() dom.Node {
+	return NewNode(t.Call("previousNode"))
+}
+
+func (t *traversal) NextNode() dom.Node {
+	return NewNode(t.Call("nextNode"))
+}
+
+func (it *nodeIterator) Detach() {
+	it.Call("detach")
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// nodeFilter returns a function which calls the filter, or nil if there
+// is no filter. The function is released when the owner is garbage
+// collected.
+func nodeFilter[T any](owner *T, filter func(dom.Node) dom.FilterResult) any {
+	if filter == nil {
+		return nil
+	}
+	fn := js.FuncOf(func(_ js.Value, args []js.Value) any {
+		return int(filter(NewNode(args[0])))
+	})
+	runtime.AddCleanup(owner, func(fn js.Func) { fn.Release() }, fn)
+	return fn
+}
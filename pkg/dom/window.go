@@ -5,11 +5,14 @@ package dom
 import (
 	"fmt"
 	"io"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	dom "github.com/djthorpe/go-wasmbuild"
-	html "golang.org/x/net/html"
 )
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -17,19 +20,89 @@ import (
 
 type window struct {
 	*document
+	events        *node
+	clock         *clock
+	history       *history
+	local         *windowStorage
+	session       *windowStorage
+	width, height int
+	media         []*mediaQueryList
+	custom        *customElementRegistry
+
+	// Functions queued by Dispatch, and whether a goroutine is calling them
+	tasks    []func()
+	taskLock sync.Mutex
+	running  bool
+}
+
+// FakeWindow is implemented by the native window, so that timers,
+// animation frames and media queries can be tested deterministically
+type FakeWindow interface {
+	dom.Window
+
+	// Now returns the time on the clock since the window was created
+	Now() time.Duration
+
+	// Advance runs the event loop, moving the clock forward and calling the
+	// timers and animation frames which are due in time order. Functions
+	// queued with Dispatch are called before the timers, and mutation
+	// records are delivered after each function or timer is called. It
+	// cannot be called from the event loop.
+	Advance(time.Duration)
+
+	// Resize sets the viewport size, dispatches a resize event and calls
+	// the listeners of media queries which have changed
+	Resize(width, height int)
 }
 
+var _ FakeWindow = (*window)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+var (
+	globalWindow     *window
+	globalWindowOnce sync.Once
+
+	// eventLoop is held while dispatched functions and timers are called,
+	// so that only one is called at a time in any window, and loopOwner is
+	// the goroutine which holds it, or zero
+	eventLoop sync.Mutex
+	loopOwner atomic.Uint64
+)
+
+const (
+	// The initial URL and viewport size of a window
+	defaultURL    = "http://localhost/"
+	defaultWidth  = 1024
+	defaultHeight = 768
+)
+
 ///////////////////////////////////////////////////////////////////////////////
 // LIFECYCLE
 
 // GetWindow returns a global window object
 func GetWindow() dom.Window {
-	return &window{NewHTMLDocument("")}
+	globalWindowOnce.Do(func() {
+		globalWindow = newWindow(NewHTMLDocument(""))
+	})
+	return globalWindow
 }
 
-// GetWindowWithTitle returns a global window object
+// GetWindowWithTitle returns a new window object
 func GetWindowWithTitle(title string) dom.Window {
-	return &window{NewHTMLDocument(title)}
+	return newWindow(NewHTMLDocument(title))
+}
+
+func newWindow(doc *document) *window {
+	w := &window{document: doc, clock: newClock(), width: defaultWidth, height: defaultHeight}
+	w.events = &node{}
+	doc.window = w
+	w.history = newHistory(w, defaultURL)
+	w.local = localStorage.attach(w)
+	w.session = newMemoryStorage().attach(w)
+	w.custom = newCustomElementRegistry(w)
+	return w
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -51,95 +124,137 @@ func (this *window) Document() dom.Document {
 	return this.document
 }
 
-// Write the nodes inside a document, or the node itself otherwise
+// Write the HTML for a node
 func (this *window) Write(w io.Writer, node dom.Node) (int, error) {
 	if node == nil {
 		return 0, dom.ErrBadParameter
 	}
-	switch node.NodeType() {
-	case dom.DOCUMENT_TYPE_NODE:
-		var s int
-		for child := node.FirstChild(); child != nil; child = child.NextSibling() {
-			if n, err := writeNode(w, child); err != nil {
-				return 0, err
-			} else {
-				s += n
-			}
-		}
-		return s, nil
-	default:
-		return writeNode(w, node)
+	return writeHTML(w, node, "")
+}
+
+// WriteIndent writes the HTML for a node, with each element on a separate
+// line, indented by depth
+func (this *window) WriteIndent(w io.Writer, node dom.Node, indent string) (int, error) {
+	if node == nil || indent == "" {
+		return 0, dom.ErrBadParameter
 	}
+	return writeHTML(w, node, indent)
 }
 
-// Read in a document from a string, and set mimetype
-func (this *window) Read(r io.Reader, mimetype string) (dom.Document, error) {
-	var node dom.Element
+func (this *window) NewMutationObserver(callback func([]dom.MutationRecord)) dom.MutationObserver {
+	return newMutationObserver(callback)
+}
 
-	parser := html.NewTokenizer(r)
-	doc := NewDocument()
+// Dispatch queues a function to be called on the event loop, and starts a
+// goroutine to call the queued functions in order if there is none
+func (this *window) Dispatch(fn func()) {
+	if fn == nil {
+		panic(dom.ErrBadParameter.With("function is nil"))
+	}
+	this.taskLock.Lock()
+	defer this.taskLock.Unlock()
+	this.tasks = append(this.tasks, fn)
+	if !this.running {
+		this.running = true
+		go this.loop()
+	}
+}
+
+// AddEventHandler adds a listener for events dispatched to the window
+func (this *window) AddEventHandler(eventType string, callback func(dom.Event), options ...dom.EventListenerOption) dom.EventListener {
+	l := newListener(callback, options...)
+	this.events.addListener(eventType, l)
+	return &eventListener{this.events, eventType, l}
+}
+
+// DispatchEvent dispatches an event to the listeners of the window, and
+// returns false if the event was cancelled
+func (this *window) DispatchEvent(e dom.Event) bool {
+	b, ok := e.(baseEvent)
+	if !ok {
+		panic(dom.ErrBadParameter.Withf("unsupported event %T", e))
+	}
+	evt := b.base()
+	if evt.dispatching {
+		panic(dom.ErrBadParameter.Withf("event %q is already being dispatched", evt.kind))
+	}
+	evt.dispatching, evt.stopped, evt.immediate = true, false, false
+	evt.target = nil
+	evt.call(this.events, nil, dom.AT_TARGET, true)
+	if !evt.stopped {
+		evt.call(this.events, nil, dom.AT_TARGET, false)
+	}
+	evt.dispatching, evt.phase, evt.currentTarget = false, dom.EVENT_NONE, nil
+	return !evt.canceled
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
 
+// loop holds the event loop and calls the queued functions, until there
+// are no more
+func (this *window) loop() {
+	lockEventLoop()
+	defer unlockEventLoop()
 	for {
-		tt := parser.Next()
-		switch tt {
-		case html.ErrorToken:
-			if err := parser.Err(); err == io.EOF {
-				return doc, nil
-			} else {
-				return nil, err
-			}
-		case html.CommentToken:
-			if node != nil {
-				node.AppendChild(doc.CreateComment(string(parser.Text())))
-			}
-		case html.TextToken:
-			if node != nil {
-				node.AppendChild(doc.CreateTextNode(string(parser.Text())))
-			}
-		case html.DoctypeToken:
-			fmt.Println("TODO doctype=", string(parser.Text()))
-		case html.SelfClosingTagToken:
-			elem := readCreateElement(doc, parser)
-			if node == nil {
-				doc.AppendChild(elem)
-			} else {
-				node.AppendChild(elem)
-			}
-		case html.StartTagToken:
-			elem := readCreateElement(doc, parser)
-			if node == nil {
-				node = doc.AppendChild(elem).(dom.Element)
-			} else {
-				node = node.AppendChild(elem).(dom.Element)
-			}
-		case html.EndTagToken:
-			tag, _ := parser.TagName()
-			if node == nil || node.NodeName() != string(tag) {
-				return nil, dom.ErrUnexpectedResponse.With("Unclosed tag: ", node.NodeName(), strconv.Quote(string(tag)))
-			} else {
-				node = node.ParentElement()
-			}
-		default:
-			return nil, dom.ErrBadParameter.With(tt)
+		this.runTasks()
+		this.taskLock.Lock()
+		if len(this.tasks) == 0 {
+			this.running = false
+			this.taskLock.Unlock()
+			return
 		}
+		this.taskLock.Unlock()
 	}
 }
 
-func (this *window) NewMutationObserver(callback func()) dom.MutationObserver {
-	return newMutationObserver(callback)
+// runTasks calls the functions queued by Dispatch in order, including any
+// which are queued while they are called, and delivers mutation records
+// after each one. It is called with the event loop held.
+func (this *window) runTasks() {
+	for {
+		this.taskLock.Lock()
+		tasks := this.tasks
+		this.tasks = nil
+		this.taskLock.Unlock()
+		if len(tasks) == 0 {
+			return
+		}
+		for _, task := range tasks {
+			task()
+			FlushMutations()
+		}
+	}
 }
 
-func readCreateElement(doc dom.Document, parser *html.Tokenizer) dom.Element {
-	tag, hasattr := parser.TagName()
-	elem := doc.CreateElement(string(tag))
-	if hasattr {
-		for {
-			name, value, more := parser.TagAttr()
-			if !more {
-				break
-			}
-			elem.SetAttribute(string(name), string(value))
-		}
+// lockEventLoop holds the event loop for the calling goroutine
+func lockEventLoop() {
+	eventLoop.Lock()
+	loopOwner.Store(goroutineID())
+}
+
+// unlockEventLoop releases the event loop
+func unlockEventLoop() {
+	loopOwner.Store(0)
+	eventLoop.Unlock()
+}
+
+// checkEventLoop panics when the DOM is modified by a goroutine while the
+// event loop is held by another, rather than modifying it concurrently
+func checkEventLoop() {
+	if owner := loopOwner.Load(); owner != 0 && owner != goroutineID() {
+		panic(dom.ErrInternalAppError.With("the DOM was modified outside the event loop, use Dispatch"))
+	}
+}
+
+// goroutineID returns the identifier of the calling goroutine, from the
+// first line of its stack trace
+func goroutineID() uint64 {
+	var buf [64]byte
+	line := strings.TrimPrefix(string(buf[:runtime.Stack(buf[:], false)]), "goroutine ")
+	if i := strings.IndexByte(line, ' '); i > 0 {
+		line = line[:i]
 	}
-	return elem
+	id, _ := strconv.ParseUint(line, 10, 64)
+	return id
 }
@@ -4,12 +4,12 @@ package dom
 
 import (
 	"fmt"
-	"html"
 	"io"
 	"syscall/js"
 
 	// Packages
 	dom "github.com/djthorpe/go-wasmbuild"
+	jsutil "github.com/djthorpe/go-wasmbuild/pkg/js"
 )
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -52,103 +52,122 @@ func (this *window) Document() dom.Document {
 	return NewNode(this.Get("document")).(dom.Document)
 }
 
-// Write the HTML inside a node
+// Write the HTML for a node. Elements and documents are serialised by the
+// browser, and other nodes are written in the same way as native builds.
 func (this *window) Write(w io.Writer, node dom.Node) (int, error) {
-	var s int
 	if node == nil {
 		return 0, dom.ErrBadParameter
 	}
 
-	jsVal := toJSValue(node)
-	nodeType := dom.NodeType(jsVal.Get("nodeType").Int())
-
-	switch nodeType {
+	switch node.NodeType() {
 	case dom.DOCUMENT_NODE:
-		// For document, write DOCTYPE if present, then document element
-		if doctype := node.FirstChild(); doctype != nil && doctype.NodeType() == dom.DOCUMENT_TYPE_NODE {
-			if n, err := w.Write([]byte("<!DOCTYPE " + doctype.NodeName() + ">")); err != nil {
+		var s int
+		if doctype := node.(dom.Document).Doctype(); doctype != nil {
+			if n, err := writeHTML(w, doctype, ""); err != nil {
 				return 0, err
 			} else {
 				s += n
 			}
-		}
-		// Write document element (usually <html>)
-		if html := jsVal.Get("documentElement"); !html.IsUndefined() {
-			htmlStr := html.Get("outerHTML").String()
-			if n, err := w.Write([]byte(htmlStr)); err != nil {
+			if n, err := io.WriteString(w, "\n"); err != nil {
 				return 0, err
 			} else {
 				s += n
 			}
 		}
-
-	case dom.ELEMENT_NODE:
-		// For elements, use outerHTML
-		html := jsVal.Get("outerHTML").String()
-		if n, err := w.Write([]byte(html)); err != nil {
-			return 0, err
-		} else {
-			s += n
-		}
-
-	case dom.TEXT_NODE:
-		// For text nodes, write the text content
-		text := jsVal.Get("data").String()
-		if n, err := w.Write([]byte(text)); err != nil {
-			return 0, err
-		} else {
-			s += n
-		}
-
-	case dom.COMMENT_NODE:
-		// For comment nodes, write as HTML comment
-		comment := jsVal.Get("data").String()
-		if n, err := w.Write([]byte("<!--" + comment + "-->")); err != nil {
-			return 0, err
-		} else {
-			s += n
-		}
-
-	case dom.ATTRIBUTE_NODE:
-		// For attribute nodes, format as name="value" with HTML escaping
-		if attr, ok := node.(dom.Attr); ok {
-			name := attr.Name()
-			value := attr.Value()
-			// Escape the value for HTML and wrap in quotes
-			escaped := html.EscapeString(value)
-			formatted := name + "=\"" + escaped + "\""
-			if n, err := w.Write([]byte(formatted)); err != nil {
+		if html := toJSValue(node).Get("documentElement"); html.Truthy() {
+			if n, err := io.WriteString(w, html.Get("outerHTML").String()); err != nil {
 				return 0, err
 			} else {
 				s += n
 			}
 		}
-
+		return s, nil
+	case dom.ELEMENT_NODE:
+		return io.WriteString(w, toJSValue(node).Get("outerHTML").String())
 	default:
-		// For other node types, try outerHTML or fall back to textContent
-		if html := jsVal.Get("outerHTML"); !html.IsUndefined() && html.Type() == js.TypeString {
-			htmlStr := html.String()
-			if n, err := w.Write([]byte(htmlStr)); err != nil {
-				return 0, err
-			} else {
-				s += n
-			}
-		} else if text := jsVal.Get("textContent"); !text.IsUndefined() {
-			textStr := text.String()
-			if n, err := w.Write([]byte(textStr)); err != nil {
-				return 0, err
-			} else {
-				s += n
-			}
-		}
+		return writeHTML(w, node, "")
 	}
+}
 
-	return s, nil
+// WriteIndent writes the HTML for a node, with each element on a separate
+// line, indented by depth
+func (this *window) WriteIndent(w io.Writer, node dom.Node, indent string) (int, error) {
+	if node == nil || indent == "" {
+		return 0, dom.ErrBadParameter
+	}
+	return writeHTML(w, node, indent)
 }
 
-func (this *window) NewMutationObserver(callback func()) dom.MutationObserver {
+// Read parses a document from r with the browser's DOMParser, where the
+// mimetype defaults to "text/html"
+func (this *window) Read(r io.Reader, mimetype string) (dom.Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if mimetype == "" {
+		mimetype = "text/html"
+	}
+
+	// Parse the document, and check for XML parse errors
+	doc := this.Get("DOMParser").New().Call("parseFromString", string(data), mimetype)
+	if errors := doc.Call("getElementsByTagName", "parsererror"); errors.Get("length").Int() > 0 {
+		return nil, dom.ErrBadParameter.With(errors.Index(0).Get("textContent").String())
+	}
+
+	// Return success
+	return NewNode(doc).(dom.Document), nil
+}
+
+// ParseFragment parses HTML from r as the contents of the context
+// element, or the body element if context is nil, and returns the parsed
+// nodes, which have no parent
+func (this *window) ParseFragment(r io.Reader, context dom.Element) ([]dom.Node, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the fragment with a range in the context element
+	document := this.Get("document")
+	contextValue := document.Get("body")
+	if context != nil {
+		contextValue = toJSValue(context)
+		document = contextValue.Get("ownerDocument")
+	}
+	rng := document.Call("createRange")
+	rng.Call("selectNodeContents", contextValue)
+	fragment := rng.Call("createContextualFragment", string(data))
+
+	// Remove the nodes from the fragment
+	result := []dom.Node{}
+	for child := fragment.Get("firstChild"); !child.IsNull(); child = fragment.Get("firstChild") {
+		fragment.Call("removeChild", child)
+		result = append(result, NewNode(child))
+	}
+
+	// Return success
+	return result, nil
+}
+
+func (this *window) NewMutationObserver(callback func([]dom.MutationRecord)) dom.MutationObserver {
 	return newMutationObserver(callback)
 }
 
+// AddEventHandler adds a listener for events dispatched to the window
+func (this *window) AddEventHandler(eventType string, callback func(dom.Event), options ...dom.EventListenerOption) dom.EventListener {
+	return addListener(this.Value, eventType, callback, options...)
+}
+
+// DispatchEvent dispatches an event to the window, and returns false if the
+// event was cancelled
+func (this *window) DispatchEvent(e dom.Event) bool {
+	evt, ok := e.(interface{ JSValue() jsutil.Value })
+	if !ok {
+		panic(dom.ErrBadParameter.Withf("unsupported event %T", e))
+	}
+	return this.Call("dispatchEvent", evt.JSValue()).Bool()
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // PUBLIC METHODS
@@ -0,0 +1,143 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-wasmbuild"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// Rule checks a document or element tree for problems
+type Rule interface {
+	// Return the rule name
+	Name() string
+
+	// Check the tree below root, and report elements with problems
+	Check(root Node, report func(Element, string))
+}
+
+// Diagnostic is a problem reported by a rule
+type Diagnostic struct {
+	Rule    string  `json:"rule"`
+	Path    string  `json:"path"`
+	Message string  `json:"message"`
+	Element Element `json:"-"`
+}
+
+// rule implements the Rule interface with a function
+type rule struct {
+	name  string
+	check func(root Node, report func(Element, string))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// NewRule returns a rule with a name and a check function
+func NewRule(name string, check func(root Node, report func(Element, string))) Rule {
+	return &rule{name, check}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Check the tree below root against the rules, or against the default
+// rules if none are provided, and return any diagnostics in rule order
+func Check(root Node, rules ...Rule) []Diagnostic {
+	if len(rules) == 0 {
+		rules = Rules()
+	}
+	var result []Diagnostic
+	for _, rule := range rules {
+		rule.Check(root, func(elem Element, message string) {
+			result = append(result, Diagnostic{
+				Rule:    rule.Name(),
+				Path:    Path(elem),
+				Message: message,
+				Element: elem,
+			})
+		})
+	}
+	return result
+}
+
+// Path returns a selector-like path to an element from its root, for
+// example "html>body>div#app>button:nth-of-type(2)"
+func Path(elem Element) string {
+	var parts []string
+	for node := Node(elem); node != nil; node = node.ParentNode() {
+		elem, ok := node.(Element)
+		if !ok {
+			break
+		}
+		part := strings.ToLower(elem.TagName())
+		if id := elem.ID(); id != "" {
+			part += "#" + id
+		} else if parent := elem.ParentElement(); parent != nil {
+			// Index amongst siblings with the same tag name
+			index, count := 0, 0
+			for _, child := range parent.Children() {
+				if child.TagName() != elem.TagName() {
+					continue
+				}
+				count++
+				if child.Equals(elem) {
+					index = count
+				}
+			}
+			if count > 1 {
+				part += fmt.Sprintf(":nth-of-type(%d)", index)
+			}
+		}
+		parts = append([]string{part}, parts...)
+	}
+	return strings.Join(parts, ">")
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s (%s)", d.Path, d.Message, d.Rule)
+}
+
+func (r *rule) String() string {
+	return r.name
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// RULE METHODS
+
+func (r *rule) Name() string {
+	return r.name
+}
+
+func (r *rule) Check(root Node, report func(Element, string)) {
+	r.check(root, report)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// walk calls fn for each element below root in document order, including
+// root itself if it is an element
+func walk(root Node, fn func(Element)) {
+	if root == nil {
+		return
+	}
+	if elem, ok := root.(Element); ok {
+		fn(elem)
+	}
+	for child := root.FirstChild(); child != nil; child = child.NextSibling() {
+		walk(child, fn)
+	}
+}
+
+// tagName returns the lowercase tag name of an element
+func tagName(elem Element) string {
+	return strings.ToLower(elem.TagName())
+}
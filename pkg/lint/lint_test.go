@@ -0,0 +1,181 @@
+//go:build !js
+
+package lint_test
+
+import (
+	"testing"
+
+	// Packages
+	dom "github.com/djthorpe/go-wasmbuild"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
+	lint "github.com/djthorpe/go-wasmbuild/pkg/lint"
+	"github.com/stretchr/testify/assert"
+)
+
+// element creates an element with attributes and children, which are
+// either nodes or strings for text
+func element(tag string, attrs map[string]string, children ...any) dom.Element {
+	doc := domPkg.GetWindow().Document()
+	elem := doc.CreateElement(tag)
+	for name, value := range attrs {
+		elem.SetAttribute(name, value)
+	}
+	for _, child := range children {
+		switch child := child.(type) {
+		case string:
+			elem.AppendChild(doc.CreateTextNode(child))
+		case dom.Node:
+			elem.AppendChild(child)
+		}
+	}
+	return elem
+}
+
+// rules returns the rules reported in diagnostics
+func rules(diagnostics []lint.Diagnostic) []string {
+	result := []string{}
+	for _, d := range diagnostics {
+		result = append(result, d.Rule)
+	}
+	return result
+}
+
+func TestLint_ImageAlt(t *testing.T) {
+	tests := []struct {
+		name  string
+		node  dom.Element
+		rules []string
+	}{
+		{"missing alt", element("img", map[string]string{"src": "a.png"}), []string{lint.RuleImageAlt}},
+		{"empty alt", element("img", map[string]string{"alt": ""}), []string{}},
+		{"alt", element("img", map[string]string{"alt": "A picture"}), []string{}},
+		{"presentation", element("img", map[string]string{"role": "presentation"}), []string{}},
+		{"hidden", element("img", map[string]string{"aria-hidden": "true"}), []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.rules, rules(lint.Check(tt.node)))
+		})
+	}
+}
+
+func TestLint_ButtonName(t *testing.T) {
+	tests := []struct {
+		name  string
+		node  dom.Element
+		rules []string
+	}{
+		{"text", element("button", nil, "OK"), []string{}},
+		{"empty", element("button", nil), []string{lint.RuleButtonName}},
+		{"whitespace", element("button", nil, "  "), []string{lint.RuleButtonName}},
+		{"aria-label", element("button", map[string]string{"aria-label": "Close"}), []string{}},
+		{"title", element("button", map[string]string{"title": "Close"}), []string{}},
+		{"image alt", element("button", nil, element("img", map[string]string{"alt": "Close"})), []string{}},
+		{"hidden icon", element("button", nil, element("i", map[string]string{"aria-hidden": "true"}, "x")), []string{lint.RuleButtonName}},
+		{"role", element("div", map[string]string{"role": "button"}), []string{lint.RuleButtonName}},
+		{"input button", element("input", map[string]string{"type": "button"}), []string{lint.RuleButtonName}},
+		{"input button value", element("input", map[string]string{"type": "button", "value": "Go"}), []string{}},
+		{"input submit", element("input", map[string]string{"type": "submit"}), []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.rules, rules(lint.Check(tt.node)))
+		})
+	}
+}
+
+func TestLint_DuplicateID(t *testing.T) {
+	root := element("div", nil,
+		element("span", map[string]string{"id": "a"}),
+		element("span", map[string]string{"id": "b"}),
+		element("span", map[string]string{"id": "a"}),
+		element("span", map[string]string{"id": ""}),
+	)
+	diagnostics := lint.Check(root)
+	if assert.Len(t, diagnostics, 2) {
+		assert.Equal(t, lint.RuleDuplicateID, diagnostics[0].Rule)
+		assert.Equal(t, `div>span#a: duplicate id "a" (duplicate-id)`, diagnostics[0].String())
+		assert.Equal(t, lint.RuleDuplicateID, diagnostics[1].Rule)
+		assert.Equal(t, "div>span:nth-of-type(4)", diagnostics[1].Path)
+	}
+}
+
+func TestLint_AriaRole(t *testing.T) {
+	tests := []struct {
+		role  string
+		rules []string
+	}{
+		{"button", []string{}},
+		{"navigation", []string{}},
+		{"none presentation", []string{}},
+		{"", []string{lint.RuleAriaRole}},
+		{"widget", []string{lint.RuleAriaRole}},
+		{"btn", []string{lint.RuleAriaRole}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			node := element("div", map[string]string{"role": tt.role}, "content")
+			assert.Equal(t, tt.rules, rules(lint.Check(node)))
+		})
+	}
+}
+
+func TestLint_HeadingOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		tags  []string
+		rules []string
+	}{
+		{"sequential", []string{"h1", "h2", "h3"}, []string{}},
+		{"decreasing", []string{"h1", "h2", "h3", "h2", "h1"}, []string{}},
+		{"start at h2", []string{"h2", "h3"}, []string{}},
+		{"skipped", []string{"h1", "h3"}, []string{lint.RuleHeadingOrder}},
+		{"skipped twice", []string{"h1", "h3", "h2", "h4"}, []string{lint.RuleHeadingOrder, lint.RuleHeadingOrder}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := element("div", nil)
+			for _, tag := range tt.tags {
+				root.AppendChild(element("section", nil, element(tag, nil, "Heading")))
+			}
+			assert.Equal(t, tt.rules, rules(lint.Check(root)))
+		})
+	}
+}
+
+func TestLint_InputLabel(t *testing.T) {
+	tests := []struct {
+		name  string
+		node  dom.Element
+		rules []string
+	}{
+		{"input", element("input", nil), []string{lint.RuleInputLabel}},
+		{"select", element("select", nil), []string{lint.RuleInputLabel}},
+		{"textarea", element("textarea", nil), []string{lint.RuleInputLabel}},
+		{"hidden input", element("input", map[string]string{"type": "hidden"}), []string{}},
+		{"aria-label", element("input", map[string]string{"aria-label": "Name"}), []string{}},
+		{"wrapped", element("label", nil, "Name", element("input", nil)), []string{}},
+		{"label for", element("form", nil,
+			element("label", map[string]string{"for": "name"}, "Name"),
+			element("input", map[string]string{"id": "name"}),
+		), []string{}},
+		{"label for other", element("form", nil,
+			element("label", map[string]string{"for": "other"}, "Name"),
+			element("input", map[string]string{"id": "name"}),
+		), []string{lint.RuleInputLabel}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.rules, rules(lint.Check(tt.node)))
+		})
+	}
+}
+
+func TestLint_Document(t *testing.T) {
+	doc := domPkg.NewHTMLDocument("Test")
+	doc.Body().AppendChild(element("img", nil))
+	diagnostics := lint.Check(doc)
+	if assert.Len(t, diagnostics, 1) {
+		assert.Equal(t, "html>body>img", diagnostics[0].Path)
+	}
+}
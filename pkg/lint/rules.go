@@ -0,0 +1,275 @@
+package lint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-wasmbuild"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	RuleImageAlt     = "img-alt"
+	RuleButtonName   = "button-name"
+	RuleDuplicateID  = "duplicate-id"
+	RuleAriaRole     = "aria-role"
+	RuleHeadingOrder = "heading-order"
+	RuleInputLabel   = "input-label"
+)
+
+// Valid WAI-ARIA 1.2 roles, excluding abstract roles
+var ariaRoles = map[string]bool{
+	"alert": true, "alertdialog": true, "application": true, "article": true,
+	"banner": true, "blockquote": true, "button": true, "caption": true,
+	"cell": true, "checkbox": true, "code": true, "columnheader": true,
+	"combobox": true, "complementary": true, "contentinfo": true,
+	"definition": true, "deletion": true, "dialog": true, "directory": true,
+	"document": true, "emphasis": true, "feed": true, "figure": true,
+	"form": true, "generic": true, "graphics-document": true,
+	"graphics-object": true, "graphics-symbol": true, "grid": true,
+	"gridcell": true, "group": true, "heading": true, "img": true,
+	"insertion": true, "link": true, "list": true, "listbox": true,
+	"listitem": true, "log": true, "main": true, "marquee": true,
+	"math": true, "menu": true, "menubar": true, "menuitem": true,
+	"menuitemcheckbox": true, "menuitemradio": true, "meter": true,
+	"navigation": true, "none": true, "note": true, "option": true,
+	"paragraph": true, "presentation": true, "progressbar": true,
+	"radio": true, "radiogroup": true, "region": true, "row": true,
+	"rowgroup": true, "rowheader": true, "scrollbar": true, "search": true,
+	"searchbox": true, "separator": true, "slider": true, "spinbutton": true,
+	"status": true, "strong": true, "subscript": true, "superscript": true,
+	"switch": true, "tab": true, "table": true, "tablist": true,
+	"tabpanel": true, "term": true, "textbox": true, "time": true,
+	"timer": true, "toolbar": true, "tooltip": true, "tree": true,
+	"treegrid": true, "treeitem": true,
+}
+
+// Input types which do not need a label
+var inputWithoutLabel = map[string]bool{
+	"hidden": true, "submit": true, "reset": true, "button": true, "image": true,
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Rules returns the default rule set
+func Rules() []Rule {
+	return []Rule{
+		NewRule(RuleImageAlt, checkImageAlt),
+		NewRule(RuleButtonName, checkButtonName),
+		NewRule(RuleDuplicateID, checkDuplicateID),
+		NewRule(RuleAriaRole, checkAriaRole),
+		NewRule(RuleHeadingOrder, checkHeadingOrder),
+		NewRule(RuleInputLabel, checkInputLabel),
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// RULES
+
+// Images need an alt attribute, which may be empty for decorative images
+func checkImageAlt(root Node, report func(Element, string)) {
+	walk(root, func(elem Element) {
+		if tagName(elem) != "img" || elem.HasAttribute("alt") || isHidden(elem) {
+			return
+		}
+		switch elem.GetAttribute("role") {
+		case "presentation", "none":
+			return
+		}
+		report(elem, "image without alt text")
+	})
+}
+
+// Buttons need an accessible name, from their content or a label
+func checkButtonName(root Node, report func(Element, string)) {
+	walk(root, func(elem Element) {
+		switch {
+		case tagName(elem) == "button", elem.GetAttribute("role") == "button":
+			if isHidden(elem) || hasLabel(elem) || accessibleText(elem) != "" {
+				return
+			}
+		case tagName(elem) == "input":
+			switch strings.ToLower(elem.GetAttribute("type")) {
+			case "submit", "reset":
+				// These have a default label
+				return
+			case "button":
+				if isHidden(elem) || hasLabel(elem) || strings.TrimSpace(elem.GetAttribute("value")) != "" {
+					return
+				}
+			case "image":
+				if isHidden(elem) || hasLabel(elem) || strings.TrimSpace(elem.GetAttribute("alt")) != "" {
+					return
+				}
+			default:
+				return
+			}
+		default:
+			return
+		}
+		report(elem, "button without an accessible name")
+	})
+}
+
+// Element identifiers must be unique
+func checkDuplicateID(root Node, report func(Element, string)) {
+	ids := make(map[string]int)
+	walk(root, func(elem Element) {
+		if !elem.HasAttribute("id") {
+			return
+		}
+		id := elem.GetAttribute("id")
+		if id == "" {
+			report(elem, "empty id attribute")
+			return
+		}
+		ids[id]++
+		if ids[id] == 2 {
+			report(elem, fmt.Sprintf("duplicate id %q", id))
+		} else if ids[id] > 2 {
+			report(elem, fmt.Sprintf("duplicate id %q (%d occurrences)", id, ids[id]))
+		}
+	})
+}
+
+// Role attributes must contain valid, non-abstract WAI-ARIA roles
+func checkAriaRole(root Node, report func(Element, string)) {
+	walk(root, func(elem Element) {
+		if !elem.HasAttribute("role") {
+			return
+		}
+		roles := strings.Fields(strings.ToLower(elem.GetAttribute("role")))
+		if len(roles) == 0 {
+			report(elem, "empty role attribute")
+			return
+		}
+		for _, role := range roles {
+			if !ariaRoles[role] {
+				report(elem, fmt.Sprintf("invalid role %q", role))
+			}
+		}
+	})
+}
+
+// Heading levels should only increase by one at a time
+func checkHeadingOrder(root Node, report func(Element, string)) {
+	last := 0
+	walk(root, func(elem Element) {
+		level := headingLevel(elem)
+		if level == 0 || isHidden(elem) {
+			return
+		}
+		if last > 0 && level > last+1 {
+			report(elem, fmt.Sprintf("heading level %d follows level %d", level, last))
+		}
+		last = level
+	})
+}
+
+// Form inputs need a label
+func checkInputLabel(root Node, report func(Element, string)) {
+	// Find the ids of elements referenced by labels
+	labels := make(map[string]bool)
+	walk(root, func(elem Element) {
+		if tagName(elem) == "label" && elem.HasAttribute("for") {
+			labels[elem.GetAttribute("for")] = true
+		}
+	})
+
+	walk(root, func(elem Element) {
+		switch tagName(elem) {
+		case "input":
+			if inputWithoutLabel[strings.ToLower(elem.GetAttribute("type"))] {
+				return
+			}
+		case "select", "textarea":
+			// Always need a label
+		default:
+			return
+		}
+		if isHidden(elem) || hasLabel(elem) {
+			return
+		}
+		if id := elem.ID(); id != "" && labels[id] {
+			return
+		}
+		for parent := elem.ParentElement(); parent != nil; parent = parent.ParentElement() {
+			if tagName(parent) == "label" {
+				return
+			}
+		}
+		report(elem, fmt.Sprintf("%s without a label", tagName(elem)))
+	})
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// hasLabel returns true if an element is labelled with attributes
+func hasLabel(elem Element) bool {
+	for _, name := range []string{"aria-label", "aria-labelledby", "title"} {
+		if strings.TrimSpace(elem.GetAttribute(name)) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// isHidden returns true if an element is hidden from assistive technology
+func isHidden(elem Element) bool {
+	return elem.HasAttribute("hidden") || elem.GetAttribute("aria-hidden") == "true"
+}
+
+// accessibleText returns the text content of a node, including the alt
+// text of images and labels of elements, and excluding hidden elements
+func accessibleText(node Node) string {
+	var b strings.Builder
+	var fn func(Node)
+	fn = func(node Node) {
+		switch node.NodeType() {
+		case TEXT_NODE:
+			b.WriteString(node.TextContent())
+		case ELEMENT_NODE:
+			elem := node.(Element)
+			if isHidden(elem) {
+				return
+			}
+			if label := strings.TrimSpace(elem.GetAttribute("aria-label")); label != "" {
+				b.WriteString(label)
+				return
+			}
+			if tagName(elem) == "img" {
+				b.WriteString(elem.GetAttribute("alt"))
+				return
+			}
+			for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+				fn(child)
+			}
+		}
+	}
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		fn(child)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// headingLevel returns the level of a heading element, or zero
+func headingLevel(elem Element) int {
+	name := tagName(elem)
+	if len(name) == 2 && name[0] == 'h' && name[1] >= '1' && name[1] <= '6' {
+		return int(name[1] - '0')
+	}
+	if elem.GetAttribute("role") == "heading" {
+		if level, err := strconv.Atoi(elem.GetAttribute("aria-level")); err == nil && level > 0 {
+			return level
+		}
+		// The default level for the heading role is 2
+		return 2
+	}
+	return 0
+}
@@ -51,6 +51,11 @@ func elementFactory(tagName string) Element {
 	return dom.GetWindow().Document().CreateElement(tagName)
 }
 
+// Create a new DOM fragment, to batch the insertion of nodes into a view
+func fragmentFactory() DocumentFragment {
+	return dom.GetWindow().Document().CreateDocumentFragment()
+}
+
 // Create a new DOM text node to be attached to a view
 func textFactory(text string) Node {
 	return dom.GetWindow().Document().CreateTextNode(text)
@@ -39,6 +39,10 @@ type View interface {
 	// Add an event listener to the view's root element
 	AddEventListener(event string, handler func(Node)) View
 
+	// Add an event handler to the view's root element, and return a handle
+	// to remove it
+	AddEventHandler(event string, handler func(Event), opts ...EventListenerOption) EventListener
+
 	// Set options on the view
 	Opts(opts ...Opt) View
 }
@@ -206,14 +210,7 @@ func (v *view) Insert(children ...any) View {
 	if target == nil {
 		target = v.root
 	}
-	firstChild := target.FirstChild()
-	for _, child := range children {
-		if firstChild == nil {
-			target.AppendChild(NodeFromAny(child))
-		} else {
-			target.InsertBefore(NodeFromAny(child), firstChild)
-		}
-	}
+	target.InsertBefore(fragmentFromAny(children...), target.FirstChild())
 	return v
 }
 
@@ -222,9 +219,7 @@ func (v *view) Append(children ...any) View {
 	if target == nil {
 		target = v.root
 	}
-	for _, child := range children {
-		target.AppendChild(NodeFromAny(child))
-	}
+	target.AppendChild(fragmentFromAny(children...))
 	return v
 }
 
@@ -233,6 +228,10 @@ func (v *view) AddEventListener(event string, handler func(Node)) View {
 	return v
 }
 
+func (v *view) AddEventHandler(event string, handler func(Event), opts ...EventListenerOption) EventListener {
+	return v.root.AddEventHandler(event, handler, opts...)
+}
+
 func (v *view) Opts(opts ...Opt) View {
 	if err := applyOpts(v.root, opts...); err != nil {
 		panic(err)
@@ -261,6 +260,16 @@ func NodeFromAny(child any) Node {
 	panic(ErrInternalAppError.Withf("NodeFromAny: unsupported: %T", child))
 }
 
+// fragmentFromAny returns a fragment containing the children, so they can be
+// inserted into the document in a single mutation
+func fragmentFromAny(children ...any) DocumentFragment {
+	fragment := fragmentFactory()
+	for _, child := range children {
+		fragment.AppendChild(NodeFromAny(child))
+	}
+	return fragment
+}
+
 // ViewFromNode returns a View from a Node, or nil if the type is unsupported
 func ViewFromNode(node Node) View {
 	if element, ok := node.(Element); ok {
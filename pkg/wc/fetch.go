@@ -10,6 +10,7 @@ import (
 	"time"
 
 	dom "github.com/djthorpe/go-dom"
+	domPkg "github.com/djthorpe/go-wasmbuild/pkg/dom"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -24,6 +25,7 @@ type Fetcher struct {
 	running  bool
 	client   *http.Client
 	headers  http.Header
+	dispatch func(func())
 }
 
 // FetchResponse represents a fetch response
@@ -34,7 +36,8 @@ type FetchResponse struct {
 	Error      error
 }
 
-// FetchCallback is called when a fetch completes
+// FetchCallback is called on the event loop when a fetch completes, so it
+// can access the DOM
 type FetchCallback func(*FetchResponse)
 
 // FetchOptions configures fetch behavior
@@ -60,6 +63,7 @@ func NewFetcher(baseURL string, interval time.Duration) *Fetcher {
 		running:  false,
 		client:   http.DefaultClient,
 		headers:  make(http.Header),
+		dispatch: domPkg.GetWindow().Dispatch,
 	}
 }
 
@@ -72,6 +76,13 @@ func (f *Fetcher) SetHeader(key, value string) *Fetcher {
 	return f
 }
 
+// SetDispatch sets the function which queues callbacks on the event loop,
+// which is Window.Dispatch by default
+func (f *Fetcher) SetDispatch(dispatch func(func())) *Fetcher {
+	f.dispatch = dispatch
+	return f
+}
+
 // SetFetchMode sets the Fetch API mode (cors, no-cors, same-origin)
 func (f *Fetcher) SetFetchMode(mode string) *Fetcher {
 	f.headers.Set("js.fetch:mode", mode)
@@ -179,7 +190,7 @@ func (f *Fetcher) doFetch(path string, opts *FetchOptions, callback FetchCallbac
 	// Create the HTTP request
 	req, err := http.NewRequest(method, url, body)
 	if err != nil {
-		callback(&FetchResponse{
+		f.call(callback, &FetchResponse{
 			Error: fmt.Errorf("failed to create request: %w", err),
 		})
 		return
@@ -215,7 +226,7 @@ func (f *Fetcher) doFetch(path string, opts *FetchOptions, callback FetchCallbac
 	go func() {
 		resp, err := f.client.Do(req)
 		if err != nil {
-			callback(&FetchResponse{
+			f.call(callback, &FetchResponse{
 				Error: fmt.Errorf("fetch failed: %w", err),
 			})
 			return
@@ -225,7 +236,7 @@ func (f *Fetcher) doFetch(path string, opts *FetchOptions, callback FetchCallbac
 		// Read the response body
 		data, err := io.ReadAll(resp.Body)
 		if err != nil {
-			callback(&FetchResponse{
+			f.call(callback, &FetchResponse{
 				Status:     resp.StatusCode,
 				StatusText: resp.Status,
 				Error:      fmt.Errorf("failed to read response: %w", err),
@@ -234,7 +245,7 @@ func (f *Fetcher) doFetch(path string, opts *FetchOptions, callback FetchCallbac
 		}
 
 		// Call the callback with the response
-		callback(&FetchResponse{
+		f.call(callback, &FetchResponse{
 			Status:     resp.StatusCode,
 			StatusText: resp.Status,
 			Data:       data,
@@ -243,6 +254,13 @@ func (f *Fetcher) doFetch(path string, opts *FetchOptions, callback FetchCallbac
 	}()
 }
 
+// call queues the callback with the response on the event loop
+func (f *Fetcher) call(callback FetchCallback, resp *FetchResponse) {
+	f.dispatch(func() {
+		callback(resp)
+	})
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // HELPER METHODS
 
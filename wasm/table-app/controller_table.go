@@ -190,7 +190,10 @@ func formatSalary(salary float64) string {
 			)
 			locDiv := createField("Location", "location", employee.Location) // Append all fields to form
 			form.Append(posDiv, salDiv, locDiv)                              // Add submit event listener to form
-			form.AddEventListener("submit", func(node Node) {
+			form.AddEventHandler("submit", func(evt Event) {
+				// Prevent the browser from navigating away
+				evt.PreventDefault()
+
 				// Get the form element as js.Value and create jsutil.Form wrapper
 				formElem := form.Element()
 				formJS := formElem.(interface{ JSValue() js.Value }).JSValue()
@@ -275,7 +278,7 @@ func formatSalary(salary float64) string {
 
 	// Add mutation observer to table to automatically update button states
 	// whenever row active states change
-	observer := dom.GetWindow().NewMutationObserver(func() {
+	observer := dom.GetWindow().NewMutationObserver(func([]MutationRecord) {
 		updateButtonStates()
 	})
 	observer.Observe(tableElem, map[string]interface{}{
@@ -408,33 +411,25 @@ func formatSalary(salary float64) string {
 
 	// Function to calculate and update the total salary
 	updateTotalSalary := func() {
-		tbody := tableElem.FirstChild().(Element)
 		totalSalary := 0.0
 
-		// Iterate through all rows in tbody
-		for child := tbody.FirstChild(); child != nil; child = child.NextSibling() {
-			if elem, ok := child.(Element); ok && elem.TagName() == "TR" {
-				// Get the third cell (salary column, index 2)
-				cells := elem.Children()
-				if len(cells) >= 3 {
-					salaryCell := cells[2]
-					salaryText := salaryCell.TextContent()
-
-					// Parse salary (remove $ and commas)
-					cleanSalary := ""
-					for _, ch := range salaryText {
-						if ch != '$' && ch != ',' {
-							cleanSalary += string(ch)
-						}
-					}
+		// Iterate through the salary column (the third cell) in each row
+		for _, salaryCell := range tableElem.QuerySelectorAll(":scope > tbody > tr > td:nth-child(3)") {
+			salaryText := salaryCell.TextContent()
 
-					// Convert to float
-					salaryVal := js.Global().Get("parseFloat").Invoke(cleanSalary).Float()
-					if !js.Global().Get("isNaN").Invoke(salaryVal).Bool() {
-						totalSalary += salaryVal
-					}
+			// Parse salary (remove $ and commas)
+			cleanSalary := ""
+			for _, ch := range salaryText {
+				if ch != '$' && ch != ',' {
+					cleanSalary += string(ch)
 				}
 			}
+
+			// Convert to float
+			salaryVal := js.Global().Get("parseFloat").Invoke(cleanSalary).Float()
+			if !js.Global().Get("isNaN").Invoke(salaryVal).Bool() {
+				totalSalary += salaryVal
+			}
 		}
 
 		// Update footer with new total
@@ -443,22 +438,14 @@ func formatSalary(salary float64) string {
 			"currency": "USD",
 		}).Call("format", totalSalary).String()
 
-		// Find and update the footer
-		tfoot := tableElem.LastChild()
-		if tfootElem, ok := tfoot.(Element); ok && tfootElem.TagName() == "TFOOT" {
-			tfootRow := tfootElem.FirstChild()
-			if tfootRowElem, ok := tfootRow.(Element); ok {
-				cells := tfootRowElem.Children()
-				if len(cells) >= 4 {
-					lastCell := cells[3]
-					for child := lastCell.FirstChild(); child != nil; {
-						next := child.NextSibling()
-						lastCell.RemoveChild(child)
-						child = next
-					}
-					lastCell.AppendChild(dom.GetWindow().Document().CreateTextNode(totalFormatted))
-				}
+		// Find and update the last cell in the footer
+		if lastCell := tableElem.QuerySelector(":scope > tfoot > tr > :nth-child(4)"); lastCell != nil {
+			for child := lastCell.FirstChild(); child != nil; {
+				next := child.NextSibling()
+				lastCell.RemoveChild(child)
+				child = next
 			}
+			lastCell.AppendChild(dom.GetWindow().Document().CreateTextNode(totalFormatted))
 		}
 	}
 